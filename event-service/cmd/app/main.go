@@ -11,11 +11,16 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/robfig/cron/v3"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+
+	"user-service/userclient"
+	"user-service/userclient/requestid"
 )
 
 func main() {
@@ -31,11 +36,19 @@ func main() {
 		&models.Event{},
 		&models.EventSchedule{},
 		&models.Category{},
+		&models.Venue{},
+		&models.EventInventory{},
+		&models.ScheduleSeries{},
 	); err != nil {
 		logger.Error("failed to migrate database", "error", err)
 		os.Exit(1)
 	}
 
+	if err := repository.MigrateEventSearch(db); err != nil {
+		logger.Error("failed to migrate event search", "error", err)
+		os.Exit(1)
+	}
+
 	brokers := config.KafkaBrokers()
 	kafkaProducer := kafka.NewProducer(brokers, logger)
 	defer func() {
@@ -47,10 +60,41 @@ func main() {
 	eventRepo := repository.NewEventRepository(db, logger)
 	scheduleRepo := repository.NewEventScheduleRepository(db, logger)
 	categoryRepo := repository.NewCategoryRepository(db, logger)
+	inventoryRepo := repository.NewEventInventoryRepository(db, logger)
+	venueRepo := repository.NewVenueRepository(db, logger)
+	seriesRepo := repository.NewScheduleSeriesRepository(db, logger)
 
-	eventService := services.NewEventService(eventRepo, categoryRepo, kafkaProducer, logger)
-	scheduleService := services.NewEventScheduleService(scheduleRepo, eventRepo, logger)
+	// роли в организациях хранит user-service
+	userServiceURL := os.Getenv("USER_SERVICE_URL")
+	serviceSecret := os.Getenv("INTERNAL_SERVICE_SECRET")
+	if userServiceURL == "" || serviceSecret == "" {
+		logger.Error("USER_SERVICE_URL and INTERNAL_SERVICE_SECRET must be set")
+		os.Exit(1)
+	}
+	userClient := userclient.New(userServiceURL, "event-service", serviceSecret)
+
+	eventService := services.NewEventService(eventRepo, categoryRepo, venueRepo, kafkaProducer, userClient, logger)
+	scheduleService := services.NewEventScheduleService(scheduleRepo, eventRepo, venueRepo, kafkaProducer, userClient, logger)
 	categoryService := services.NewCategoryService(categoryRepo, logger)
+	venueService := services.NewVenueService(venueRepo, logger)
+
+	// повторяющиеся активности создаются на SCHEDULE_HORIZON_DAYS вперёд
+	horizon := services.DefaultScheduleHorizon
+	if days, err := strconv.Atoi(os.Getenv("SCHEDULE_HORIZON_DAYS")); err == nil && days > 0 {
+		horizon = time.Duration(days) * 24 * time.Hour
+	}
+	seriesService := services.NewScheduleSeriesService(seriesRepo, eventRepo, venueRepo, kafkaProducer, userClient, horizon, logger)
+
+	// выгрузка и удаление данных по запросу пользователя
+	privacyService := services.NewPrivacyService(eventRepo, logger)
+	dataRequestConsumer := kafka.NewDataRequestConsumer(brokers, privacyService, userClient, logger)
+	dataRequestConsumer.Start()
+	defer dataRequestConsumer.Stop()
+
+	// цены и остаток билетов для фильтров поиска
+	inventoryConsumer := kafka.NewInventoryConsumer(brokers, inventoryRepo, logger)
+	inventoryConsumer.Start()
+	defer inventoryConsumer.Stop()
 
 	// Запустить cron для отправки напоминаний
 	c := cron.New()
@@ -63,16 +107,40 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	_, err = c.AddFunc("0 3 * * *", func() { // Каждый день в 3:00 продлеваем серии
+		if err := seriesService.MaterializeDue(context.Background()); err != nil {
+			logger.Error("failed to materialize schedule series", "error", err)
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
 	c.Start()
 	defer c.Stop()
 
+	go func() {
+		if err := seriesService.MaterializeDue(context.Background()); err != nil {
+			logger.Error("failed to materialize schedule series", "error", err)
+		}
+	}()
+
+	identitySecret := os.Getenv("INTERNAL_IDENTITY_SECRET")
+	if identitySecret == "" {
+		logger.Error("INTERNAL_IDENTITY_SECRET is not set")
+		os.Exit(1)
+	}
+
 	r := gin.Default()
+	r.Use(requestid.Middleware())
+	r.Use(userclient.RequireIdentity(identitySecret))
 	transport.RegisterRoutes(
 		r,
 		logger,
 		eventService,
 		scheduleService,
 		categoryService,
+		venueService,
+		seriesService,
 	)
 
 	port := os.Getenv("PORT")
@@ -7,8 +7,10 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/robfig/cron/v3 v3.0.1
 	github.com/segmentio/kafka-go v0.4.50
+	github.com/teambition/rrule-go v1.8.2
 	gorm.io/driver/postgres v1.6.0
 	gorm.io/gorm v1.31.1
+	user-service/userclient v0.0.0
 )
 
 require (
@@ -52,3 +54,5 @@ require (
 	golang.org/x/tools v0.34.0 // indirect
 	google.golang.org/protobuf v1.36.9 // indirect
 )
+
+replace user-service/userclient => ../user-service/userclient
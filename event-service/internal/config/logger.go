@@ -4,6 +4,8 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+
+	"user-service/userclient/requestid"
 )
 
 func InitLogger() *slog.Logger {
@@ -24,5 +26,5 @@ func InitLogger() *slog.Logger {
 		Level: level,
 	})
 
-	return slog.New(handler)
+	return slog.New(requestid.NewHandler(handler))
 }
\ No newline at end of file
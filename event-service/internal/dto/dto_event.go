@@ -9,15 +9,18 @@ const (
 )
 
 type CreateEventRequest struct {
-	Title      string `json:"title" binding:"required,min=5,max=100"`
-	Seats      *int   `json:"seats"`
-	UserID     uint   `json:"user_id" binding:"required"`
-	CategoryID *uint  `json:"category_id"`
+	Title          string `json:"title" binding:"required,min=5,max=100"`
+	Description    string `json:"description" binding:"max=5000"`
+	Seats          *int   `json:"seats"`
+	CategoryID     *uint  `json:"category_id"`
+	VenueID        *uint  `json:"venue_id"`        // Seats не больше вместимости площадки
+	OrganizationID *uint  `json:"organization_id"` // мероприятие от имени организации
 }
 
 type UpdateEventRequest struct {
-	Title      *string `json:"title"`
-	Seats      *int    `json:"seats"`
-	UserID     *uint   `json:"user_id"`
-	CategoryID *uint   `json:"category_id"`
+	Title       *string `json:"title"`
+	Description *string `json:"description" binding:"omitempty,max=5000"`
+	Seats       *int    `json:"seats"`
+	CategoryID  *uint   `json:"category_id"`
+	VenueID     *uint   `json:"venue_id"`
 }
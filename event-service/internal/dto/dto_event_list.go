@@ -1,22 +1,76 @@
 package dto
 
+import (
+	"event-service/internal/models"
+	"time"
+)
+
 const (
-	DefaultPage  = 1
 	DefaultLimit = 10
+	MaxLimit     = 100
+
+	DefaultRadiusKm = 10
+	MaxRadiusKm     = 500
+)
+
+// Поля сортировки списка мероприятий.
+const (
+	SortByRelevance = "relevance"
+	SortByCreatedAt = "created_at"
+	SortByTitle     = "title"
+	SortByDistance  = "distance"
 )
 
 type EventListQuery struct {
+	// Полнотекстовый поиск по названию, описанию и спикерам
+	Q string `form:"q"`
+
 	// Фильтры
-	Title  string `form:"title"`
-	Status string `form:"status"`
+	Title          string     `form:"title"`
+	Status         string     `form:"status"`
+	OrganizationID *uint      `form:"organization_id"`
+	CategoryID     *uint      `form:"category_id"`
+	DateFrom       *time.Time `form:"date_from"` // хотя бы одна активность начинается не раньше
+	DateTo         *time.Time `form:"date_to"`   // и раньше этого момента
+	PriceMin       *int64     `form:"price_min"`
+	PriceMax       *int64     `form:"price_max"`
+	Available      *bool      `form:"available"` // true — есть непроданные билеты
 
-	// Пагинация
-	Page  int `form:"page"`
-	Limit int `form:"limit"`
+	// Мероприятия рядом: площадка не дальше radius_km от точки lat/lng
+	Lat      *float64 `form:"lat"`
+	Lng      *float64 `form:"lng"`
+	RadiusKm *float64 `form:"radius_km"`
+
+	// Пагинация: cursor берётся из next_cursor предыдущей страницы
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
 
 	// Сортивка
-	// sort_by: created_at и title
+	// sort_by: relevance (по умолчанию при q), distance (по умолчанию при
+	// lat/lng), created_at и title
 	// sort_order: asc и desc
 	SortBy    string `form:"sort_by"`
 	SortOrder string `form:"sort_order"`
 }
+
+// EventCursor — позиция после последнего мероприятия страницы. Сортировка
+// входит в курсор, чтобы его нельзя было применить к другому порядку.
+type EventCursor struct {
+	SortBy    string `json:"s"`
+	SortOrder string `json:"o"`
+	Value     string `json:"v"`
+	ID        uint   `json:"id"`
+}
+
+type CategoryFacet struct {
+	CategoryID *uint  `json:"category_id"` // nil — без категории
+	Name       string `json:"name"`
+	Count      int64  `json:"count"`
+}
+
+type EventListResponse struct {
+	Items      []models.Event  `json:"items"`
+	Total      int64           `json:"total"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	Facets     []CategoryFacet `json:"facets"`
+}
@@ -1,17 +1,50 @@
 package dto
 
-import "time"
+import (
+	"event-service/internal/models"
+	"time"
+)
 
 type CreateScheduleRequest struct {
 	ActivityName string    `json:"activity_name" binding:"required,min=3,max=100"`
 	Speaker      string    `json:"speaker" binding:"required,min=3,max=50"`
 	StartAt      time.Time `json:"start_at" binding:"required"`
 	EndAt        time.Time `json:"end_at" binding:"required"`
+	VenueID      *uint     `json:"venue_id"` // зал или площадка, если отличается от площадки мероприятия
+
+	// AllowConflicts сохраняет активность, даже если спикер или зал в это
+	// время заняты; пересечения тогда приходят в warnings.
+	AllowConflicts bool `json:"allow_conflicts"`
 }
 
 type UpdateScheduleRequest struct {
-	ActivityName *string    `json:"activity_name"`
-	Speaker      *string    `json:"speaker"`
-	StartAt      *time.Time `json:"start_at"`
-	EndAt        *time.Time `json:"end_at"`
+	ActivityName   *string    `json:"activity_name" binding:"omitempty,min=3,max=100"`
+	Speaker        *string    `json:"speaker" binding:"omitempty,min=3,max=50"`
+	StartAt        *time.Time `json:"start_at"`
+	EndAt          *time.Time `json:"end_at"`
+	VenueID        *uint      `json:"venue_id"`
+	AllowConflicts bool       `json:"allow_conflicts"`
+}
+
+// Причина пересечения активностей.
+const (
+	ConflictSpeaker = "speaker" // спикер занят в это время
+	ConflictVenue   = "venue"   // зал занят в это время
+)
+
+// ScheduleConflict — активность, с которой пересекается сохраняемая.
+type ScheduleConflict struct {
+	ScheduleID   uint      `json:"schedule_id"`
+	EventID      uint      `json:"event_id"`
+	ActivityName string    `json:"activity_name"`
+	Speaker      string    `json:"speaker"`
+	StartAt      time.Time `json:"start_at"`
+	EndAt        time.Time `json:"end_at"`
+	Reason       string    `json:"reason"`
+}
+
+// ScheduleResponse — сохранённая активность и разрешённые пересечения.
+type ScheduleResponse struct {
+	models.EventSchedule
+	Warnings []ScheduleConflict `json:"warnings,omitempty"`
 }
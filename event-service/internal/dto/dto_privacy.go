@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// UserEventsExport — данные пользователя в event-service для выгрузки.
+type UserEventsExport struct {
+	Events []UserEventExport `json:"events"`
+}
+
+type UserEventExport struct {
+	ID             uint                `json:"id"`
+	Title          string              `json:"title"`
+	Description    string              `json:"description"`
+	Status         string              `json:"status"`
+	Seats          *int                `json:"seats"`
+	OrganizationID *uint               `json:"organization_id"`
+	CategoryID     *uint               `json:"category_id"`
+	CreatedAt      time.Time           `json:"created_at"`
+	Schedule       []UserScheduleEntry `json:"schedule"`
+}
+
+type UserScheduleEntry struct {
+	ActivityName string    `json:"activity_name"`
+	Speaker      string    `json:"speaker"`
+	StartAt      time.Time `json:"start_at"`
+	EndAt        time.Time `json:"end_at"`
+}
+
+// UserEventsErasure — сводка по удалению данных пользователя.
+type UserEventsErasure struct {
+	EventsAnonymized int64 `json:"events_anonymized"`
+}
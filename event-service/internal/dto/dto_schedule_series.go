@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// Область изменения повторения серии.
+const (
+	ScopeThis      = "this"      // только это повторение
+	ScopeFollowing = "following" // это и все следующие
+)
+
+type CreateSeriesRequest struct {
+	ActivityName    string      `json:"activity_name" binding:"required,min=3,max=100"`
+	Speaker         string      `json:"speaker" binding:"required,min=3,max=50"`
+	VenueID         *uint       `json:"venue_id"`
+	StartAt         time.Time   `json:"start_at" binding:"required"` // начало первого повторения
+	DurationMinutes int         `json:"duration_minutes" binding:"required,min=1,max=1440"`
+	Timezone        string      `json:"timezone"`                         // по умолчанию часовой пояс площадки или UTC
+	RRule           string      `json:"rrule" binding:"required,max=255"` // например FREQ=WEEKLY;BYDAY=TU
+	ExDates         []time.Time `json:"exdates"`
+	AllowConflicts  bool        `json:"allow_conflicts"` // как у разовой активности
+}
+
+// UpdateOccurrenceRequest — изменение повторения. RRule можно поменять только
+// для scope=following: с этого повторения серия идёт по новому правилу.
+type UpdateOccurrenceRequest struct {
+	ActivityName    *string    `json:"activity_name" binding:"omitempty,min=3,max=100"`
+	Speaker         *string    `json:"speaker" binding:"omitempty,min=3,max=50"`
+	VenueID         *uint      `json:"venue_id"`
+	StartAt         *time.Time `json:"start_at"`
+	DurationMinutes *int       `json:"duration_minutes" binding:"omitempty,min=1,max=1440"`
+	RRule           *string    `json:"rrule" binding:"omitempty,max=255"`
+	AllowConflicts  bool       `json:"allow_conflicts"`
+}
+
+type OccurrenceScopeQuery struct {
+	Scope string `form:"scope" binding:"omitempty,oneof=this following"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+type CreateVenueRequest struct {
+	Name      string   `json:"name" binding:"required,min=2,max=100"`
+	Address   string   `json:"address" binding:"required,max=255"`
+	Latitude  *float64 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required,min=-180,max=180"`
+	Capacity  int      `json:"capacity" binding:"required,min=1"`
+	Timezone  string   `json:"timezone" binding:"required"`
+}
+
+type UpdateVenueRequest struct {
+	Name      *string  `json:"name" binding:"omitempty,min=2,max=100"`
+	Address   *string  `json:"address" binding:"omitempty,max=255"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Capacity  *int     `json:"capacity" binding:"omitempty,min=1"`
+	Timezone  *string  `json:"timezone"`
+}
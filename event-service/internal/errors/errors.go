@@ -5,6 +5,9 @@ import "errors"
 var (
 	ErrEventIsNil             = errors.New("event is nil")
 	ErrEventScheduleIsNil     = errors.New("event schedule is nil")
+	ErrEventInventoryIsNil    = errors.New("event inventory is nil")
+	ErrVenueIsNil             = errors.New("venue is nil")
+	ErrScheduleSeriesIsNil    = errors.New("schedule series is nil")
 	ErrCategoryIsNil          = errors.New("category is nil")
 	ErrEmptyTitle             = errors.New("title cannot be empty")
 	ErrCategoryNotFound       = errors.New("category not found")
@@ -19,4 +22,21 @@ var (
 	ErrEmptySpeaker           = errors.New("speaker cannot be empty")
 	ErrNotCorrectScheduleTime = errors.New("start time cannot be equal and after end time and vice versa")
 	ErrNotCorrectNum          = errors.New("number cannot be less than 1")
+	ErrForbidden              = errors.New("not allowed to manage this event")
+	ErrOrganizerRequired      = errors.New("only organizers can create events")
+	ErrInvalidCursor          = errors.New("invalid cursor")
+	ErrInvalidDateRange       = errors.New("date_from must be before date_to")
+	ErrInvalidPriceRange      = errors.New("price_min cannot be greater than price_max")
+	ErrInvalidLocation        = errors.New("lat and lng must be set together, radius_km must be positive")
+	ErrVenueNotFound          = errors.New("venue not found")
+	ErrInvalidTimezone        = errors.New("unknown timezone")
+	ErrSeatsExceedCapacity    = errors.New("seats cannot exceed venue capacity")
+	ErrVenueCapacityTooLow    = errors.New("venue capacity is lower than seats of its events")
+	ErrVenueInUse             = errors.New("venue is used by events")
+	ErrScheduleSeriesNotFound = errors.New("schedule series not found")
+	ErrInvalidRRule           = errors.New("invalid recurrence rule")
+	ErrOccurrenceNotFound     = errors.New("occurrence not found in series")
+	ErrRRuleChangeNeedsScope  = errors.New("recurrence rule can only be changed for this and following occurrences")
+	ErrScheduleConflict       = errors.New("schedule overlaps with another activity of the speaker or venue")
+	ErrScheduleInSeries       = errors.New("schedule belongs to a series, change it through the series occurrences")
 )
@@ -0,0 +1,47 @@
+// Package geo — расчёты для поиска мероприятий по координатам без PostGIS.
+package geo
+
+import "math"
+
+// EarthRadiusKm — средний радиус Земли, тот же используется в формуле
+// гаверсинуса в SQL.
+const EarthRadiusKm = 6371.0088
+
+// BoundingBox — прямоугольник в градусах, содержащий круг заданного радиуса.
+// Им отсекаются заведомо далёкие площадки по индексу, точное расстояние
+// считается формулой гаверсинуса.
+type BoundingBox struct {
+	MinLat, MaxLat float64
+	MinLng, MaxLng float64
+}
+
+// Around строит прямоугольник вокруг точки. Если круг задевает полюс или
+// линию перемены дат, ограничение по долготе снимается.
+func Around(lat, lng, radiusKm float64) BoundingBox {
+	angular := radiusKm / EarthRadiusKm
+	dLat := degrees(angular)
+
+	box := BoundingBox{
+		MinLat: lat - dLat,
+		MaxLat: lat + dLat,
+		MinLng: -180,
+		MaxLng: 180,
+	}
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.MinLat = math.Max(box.MinLat, -90)
+		box.MaxLat = math.Min(box.MaxLat, 90)
+		return box
+	}
+
+	dLng := degrees(math.Asin(math.Sin(angular) / math.Cos(radians(lat))))
+	if lng-dLng < -180 || lng+dLng > 180 {
+		return box
+	}
+	box.MinLng = lng - dLng
+	box.MaxLng = lng + dLng
+	return box
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
@@ -0,0 +1,57 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// haversine — эталонное расстояние для проверки прямоугольника.
+func haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * EarthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+func TestAround_ContainsCircle(t *testing.T) {
+	lat, lng, radius := 55.7558, 37.6173, 25.0
+	box := Around(lat, lng, radius)
+
+	// точки на границе круга по сторонам света должны попасть в прямоугольник
+	for bearing := 0.0; bearing < 360; bearing += 15 {
+		pLat, pLng := destination(lat, lng, bearing, radius*0.999)
+		if pLat < box.MinLat || pLat > box.MaxLat || pLng < box.MinLng || pLng > box.MaxLng {
+			t.Fatalf("point at bearing %v (%v, %v) is outside box %+v", bearing, pLat, pLng, box)
+		}
+		if d := haversine(lat, lng, pLat, pLng); math.Abs(d-radius*0.999) > 0.01 {
+			t.Fatalf("unexpected distance %v at bearing %v", d, bearing)
+		}
+	}
+
+	// и прямоугольник не должен быть заметно шире круга
+	if haversine(lat, lng, box.MaxLat, lng) > radius*1.01 {
+		t.Fatalf("box is too tall: %+v", box)
+	}
+}
+
+func TestAround_PoleAndAntimeridian(t *testing.T) {
+	box := Around(89.9, 10, 50)
+	if box.MinLng != -180 || box.MaxLng != 180 || box.MaxLat != 90 {
+		t.Fatalf("expected full longitude range near pole, got %+v", box)
+	}
+
+	box = Around(0, 179.9, 50)
+	if box.MinLng != -180 || box.MaxLng != 180 {
+		t.Fatalf("expected full longitude range near antimeridian, got %+v", box)
+	}
+}
+
+// destination — точка на расстоянии distKm от исходной по азимуту bearing.
+func destination(lat, lng, bearing, distKm float64) (float64, float64) {
+	angular := distKm / EarthRadiusKm
+	lat1, lng1, b := radians(lat), radians(lng), radians(bearing)
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(angular) + math.Cos(lat1)*math.Sin(angular)*math.Cos(b))
+	lng2 := lng1 + math.Atan2(math.Sin(b)*math.Sin(angular)*math.Cos(lat1), math.Cos(angular)-math.Sin(lat1)*math.Sin(lat2))
+	return degrees(lat2), degrees(lng2)
+}
@@ -0,0 +1,158 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"event-service/internal/dto"
+	"log/slog"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"user-service/userclient"
+	"user-service/userclient/requestid"
+)
+
+// UserDataHandler выгружает и обезличивает мероприятия пользователя.
+// Реализуется services.PrivacyService.
+type UserDataHandler interface {
+	Export(ctx context.Context, userID uint) (*dto.UserEventsExport, error)
+	Erase(ctx context.Context, userID uint) (*dto.UserEventsErasure, error)
+}
+
+// DataRequestAcknowledger подтверждает обработку запроса в user-service.
+// Реализуется userclient.Client.
+type DataRequestAcknowledger interface {
+	AcknowledgeDataRequest(ctx context.Context, requestID uint, data any) error
+}
+
+// DataRequestConsumer обрабатывает запросы пользователя на выгрузку и
+// удаление данных. Сообщение подтверждается в Kafka только после ответа
+// user-service, при сбое обработка повторяется.
+type DataRequestConsumer struct {
+	brokers []string
+	privacy UserDataHandler
+	acks    DataRequestAcknowledger
+	log     *slog.Logger
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func NewDataRequestConsumer(
+	brokers []string,
+	privacy UserDataHandler,
+	acks DataRequestAcknowledger,
+	log *slog.Logger,
+) *DataRequestConsumer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &DataRequestConsumer{
+		brokers: brokers,
+		privacy: privacy,
+		acks:    acks,
+		log:     log,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (c *DataRequestConsumer) Start() {
+	for _, topic := range []string{userclient.TopicExportRequested, userclient.TopicDeletionRequested} {
+		go c.consumeTopic(topic)
+	}
+}
+
+func (c *DataRequestConsumer) Stop() {
+	c.cancel()
+}
+
+func (c *DataRequestConsumer) consumeTopic(topic string) {
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  c.brokers,
+		GroupID:  "event-service",
+		Topic:    topic,
+		MinBytes: 1,
+		MaxBytes: 10e6,
+	})
+	defer r.Close()
+
+	for {
+		m, err := r.FetchMessage(c.ctx)
+		if err != nil {
+			if c.ctx.Err() != nil {
+				return
+			}
+			c.log.Warn("failed to read message", "topic", topic, "error", err)
+			continue
+		}
+
+		ctx := requestid.NewContext(c.ctx, requestIDFromHeaders(m.Headers))
+		if !c.handleWithRetry(ctx, m.Value) {
+			return
+		}
+
+		if err := r.CommitMessages(c.ctx, m); err != nil {
+			c.log.WarnContext(ctx, "failed to commit message", "topic", topic, "error", err)
+		}
+	}
+}
+
+// handleWithRetry повторяет обработку, пока она не удастся. false — консьюмер остановлен.
+func (c *DataRequestConsumer) handleWithRetry(ctx context.Context, payload []byte) bool {
+	backoff := time.Second
+	for {
+		err := c.handle(ctx, payload)
+		if err == nil {
+			return true
+		}
+		c.log.WarnContext(ctx, "failed to process data request, retrying", "error", err, "backoff", backoff)
+
+		select {
+		case <-c.ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, time.Minute)
+	}
+}
+
+func (c *DataRequestConsumer) handle(ctx context.Context, payload []byte) error {
+	var msg userclient.DataRequestMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		c.log.ErrorContext(ctx, "failed to unmarshal data request", "error", err)
+		return nil
+	}
+
+	var (
+		result any
+		err    error
+	)
+	switch msg.Type {
+	case userclient.DataRequestExport:
+		result, err = c.privacy.Export(ctx, msg.UserID)
+	case userclient.DataRequestDeletion:
+		result, err = c.privacy.Erase(ctx, msg.UserID)
+	default:
+		c.log.WarnContext(ctx, "unknown data request type", "type", msg.Type, "request_id", msg.RequestID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = c.acks.AcknowledgeDataRequest(ctx, msg.RequestID, result)
+	if errors.Is(err, userclient.ErrDataRequestNotFound) {
+		c.log.WarnContext(ctx, "data request is not expected from event-service", "request_id", msg.RequestID)
+		return nil
+	}
+	return err
+}
+
+func requestIDFromHeaders(headers []kafka.Header) string {
+	for _, h := range headers {
+		if h.Key == requestid.Header && requestid.Valid(string(h.Value)) {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"event-service/internal/models"
+	"log/slog"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"user-service/userclient/requestid"
+)
+
+const ticketInventoryChanged = "ticket.inventory_changed"
+
+// TicketInventoryMessage — снимок цен и остатка билетов мероприятия из ticket-service.
+type TicketInventoryMessage struct {
+	EventID    uint      `json:"event_id"`
+	MinPrice   int64     `json:"min_price"`
+	MaxPrice   int64     `json:"max_price"`
+	Available  int       `json:"available"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// InventoryStore сохраняет снимок для фильтров поиска.
+// Реализуется repository.EventInventoryRepository.
+type InventoryStore interface {
+	Upsert(inventory *models.EventInventory) error
+}
+
+// InventoryConsumer обновляет цены и остаток билетов мероприятий. Ошибка
+// сохранения только логируется: следующий снимок перезапишет данные.
+type InventoryConsumer struct {
+	brokers []string
+	store   InventoryStore
+	log     *slog.Logger
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func NewInventoryConsumer(brokers []string, store InventoryStore, log *slog.Logger) *InventoryConsumer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &InventoryConsumer{
+		brokers: brokers,
+		store:   store,
+		log:     log,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (c *InventoryConsumer) Start() {
+	go c.consume()
+}
+
+func (c *InventoryConsumer) Stop() {
+	c.cancel()
+}
+
+func (c *InventoryConsumer) consume() {
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  c.brokers,
+		GroupID:  "event-service",
+		Topic:    ticketInventoryChanged,
+		MinBytes: 1,
+		MaxBytes: 10e6,
+	})
+	defer r.Close()
+
+	for {
+		m, err := r.ReadMessage(c.ctx)
+		if err != nil {
+			if c.ctx.Err() != nil {
+				return
+			}
+			c.log.Warn("failed to read message", "topic", ticketInventoryChanged, "error", err)
+			continue
+		}
+
+		ctx := requestid.NewContext(c.ctx, requestIDFromHeaders(m.Headers))
+		c.handle(ctx, m.Value)
+	}
+}
+
+func (c *InventoryConsumer) handle(ctx context.Context, payload []byte) {
+	var msg TicketInventoryMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		c.log.ErrorContext(ctx, "failed to unmarshal ticket inventory", "error", err)
+		return
+	}
+
+	err := c.store.Upsert(&models.EventInventory{
+		EventID:    msg.EventID,
+		MinPrice:   msg.MinPrice,
+		MaxPrice:   msg.MaxPrice,
+		Available:  msg.Available,
+		SnapshotAt: msg.OccurredAt,
+	})
+	if err != nil {
+		c.log.WarnContext(ctx, "failed to save ticket inventory", "error", err, "event_id", msg.EventID)
+	}
+}
@@ -8,11 +8,29 @@ import (
 	"time"
 
 	"github.com/segmentio/kafka-go"
+
+	"user-service/userclient/requestid"
+)
+
+const (
+	eventCancelled       = "event.cancelled"
+	eventReminder        = "event.reminder"
+	eventScheduleChanged = "event.schedule_changed"
 )
 
+// Действие с расписанием в ScheduleChangedMessage.
 const (
-	eventCancelled = "event.cancelled"
-	eventReminder  = "event.reminder"
+	ScheduleCreated = "created"
+	ScheduleUpdated = "updated"
+	ScheduleDeleted = "deleted"
+)
+
+// Область изменения: одна активность, повторение серии и следующие за ним,
+// вся серия.
+const (
+	ScheduleScopeThis      = "this"
+	ScheduleScopeFollowing = "following"
+	ScheduleScopeSeries    = "series"
 )
 
 type Producer struct {
@@ -23,6 +41,7 @@ type Producer struct {
 type EventProducer interface {
 	SendEventCancelled(ctx context.Context, eventID uint) error
 	SendEventReminder(ctx context.Context, eventID uint, eventTitle string, eventDate time.Time) error
+	SendScheduleChanged(ctx context.Context, message ScheduleChangedMessage) error
 	Close() error
 }
 
@@ -36,6 +55,31 @@ type EventReminderMessage struct {
 	EventDate  time.Time `json:"event_date"`
 }
 
+// ScheduleChangedMessage — изменение расписания мероприятия для уведомления
+// участников. Для серии ScheduleID — повторение, с которого началось
+// изменение (0 при создании серии), ScheduleIDs — все изменённые
+// повторения; SeriesIDs — серия и те, от которых она
+// отделена: билеты на любую из них действуют на изменённые повторения;
+// Previous* — время до изменения.
+type ScheduleChangedMessage struct {
+	EventID         uint       `json:"event_id"`
+	EventTitle      string     `json:"event_title"`
+	Action          string     `json:"action"`
+	Scope           string     `json:"scope"`
+	ScheduleID      uint       `json:"schedule_id,omitempty"`
+	ScheduleIDs     []uint     `json:"schedule_ids,omitempty"`
+	SeriesID        *uint      `json:"series_id,omitempty"`
+	SeriesIDs       []uint     `json:"series_ids,omitempty"`
+	ActivityName    string     `json:"activity_name"`
+	Speaker         string     `json:"speaker"`
+	VenueID         *uint      `json:"venue_id,omitempty"`
+	StartAt         time.Time  `json:"start_at"`
+	EndAt           time.Time  `json:"end_at"`
+	PreviousStartAt *time.Time `json:"previous_start_at,omitempty"`
+	PreviousEndAt   *time.Time `json:"previous_end_at,omitempty"`
+	OccurredAt      time.Time  `json:"occurred_at"`
+}
+
 func NewProducer(brokers []string, logger *slog.Logger) *Producer {
 	return &Producer{
 		writer: &kafka.Writer{
@@ -65,10 +109,11 @@ func (p *Producer) SendEventCancelled(ctx context.Context, eventID uint) error {
 	}
 
 	kafkaMessage := kafka.Message{
-		Topic: eventCancelled,
-		Key:   []byte(fmt.Sprintf("%d", eventID)),
-		Value: data,
-		Time:  time.Now(),
+		Topic:   eventCancelled,
+		Key:     []byte(fmt.Sprintf("%d", eventID)),
+		Value:   data,
+		Headers: messageHeaders(ctx),
+		Time:    time.Now(),
 	}
 
 	// Retry logic
@@ -76,7 +121,7 @@ func (p *Producer) SendEventCancelled(ctx context.Context, eventID uint) error {
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		err = p.writer.WriteMessages(ctx, kafkaMessage)
 		if err == nil {
-			p.logger.Info("event cancelled message sent",
+			p.logger.InfoContext(ctx, "event cancelled message sent",
 				"event_id", eventID,
 				"topic", eventCancelled)
 			return nil
@@ -84,7 +129,7 @@ func (p *Producer) SendEventCancelled(ctx context.Context, eventID uint) error {
 
 		if attempt < maxRetries-1 {
 			backoff := time.Duration(1<<uint(attempt)) * time.Second
-			p.logger.Warn("failed to send event cancelled message, retrying",
+			p.logger.WarnContext(ctx, "failed to send event cancelled message, retrying",
 				"error", err,
 				"event_id", eventID,
 				"attempt", attempt+1,
@@ -93,7 +138,7 @@ func (p *Producer) SendEventCancelled(ctx context.Context, eventID uint) error {
 		}
 	}
 
-	p.logger.Error("failed to send event cancelled message after retries",
+	p.logger.ErrorContext(ctx, "failed to send event cancelled message after retries",
 		"error", err,
 		"event_id", eventID,
 		"max_retries", maxRetries)
@@ -121,10 +166,11 @@ func (p *Producer) SendEventReminder(ctx context.Context, eventID uint, eventTit
 	}
 
 	kafkaMessage := kafka.Message{
-		Topic: eventReminder,
-		Key:   []byte(fmt.Sprintf("%d", eventID)),
-		Value: data,
-		Time:  time.Now(),
+		Topic:   eventReminder,
+		Key:     []byte(fmt.Sprintf("%d", eventID)),
+		Value:   data,
+		Headers: messageHeaders(ctx),
+		Time:    time.Now(),
 	}
 
 	// Retry logic
@@ -153,3 +199,60 @@ func (p *Producer) SendEventReminder(ctx context.Context, eventID uint, eventTit
 		"max_retries", maxRetries)
 	return err
 }
+
+func (p *Producer) SendScheduleChanged(ctx context.Context, message ScheduleChangedMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		p.logger.Error("failed to marshal schedule changed message", "error", err, "event_id", message.EventID)
+		return err
+	}
+
+	// ключ — мероприятие: изменения одного расписания читаются по порядку
+	kafkaMessage := kafka.Message{
+		Topic:   eventScheduleChanged,
+		Key:     []byte(fmt.Sprintf("%d", message.EventID)),
+		Value:   data,
+		Headers: messageHeaders(ctx),
+		Time:    time.Now(),
+	}
+
+	// Retry logic
+	maxRetries := 3
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		err = p.writer.WriteMessages(ctx, kafkaMessage)
+		if err == nil {
+			p.logger.InfoContext(ctx, "schedule changed message sent",
+				"event_id", message.EventID,
+				"schedule_id", message.ScheduleID,
+				"action", message.Action,
+				"topic", eventScheduleChanged)
+			return nil
+		}
+
+		if attempt < maxRetries-1 {
+			backoff := time.Duration(1<<uint(attempt)) * time.Second
+			p.logger.WarnContext(ctx, "failed to send schedule changed message, retrying",
+				"error", err,
+				"event_id", message.EventID,
+				"attempt", attempt+1,
+				"backoff", backoff)
+			time.Sleep(backoff)
+		}
+	}
+
+	p.logger.ErrorContext(ctx, "failed to send schedule changed message after retries",
+		"error", err,
+		"event_id", message.EventID,
+		"max_retries", maxRetries)
+	return err
+}
+
+// messageHeaders передаёт ID запроса консьюмерам, чтобы по нему
+// можно было связать HTTP-запрос и созданные им уведомления.
+func messageHeaders(ctx context.Context) []kafka.Header {
+	id := requestid.FromContext(ctx)
+	if id == "" {
+		return nil
+	}
+	return []kafka.Header{{Key: requestid.Header, Value: []byte(id)}}
+}
@@ -2,11 +2,21 @@ package models
 
 type Event struct {
 	Base
-	Title      string          `json:"title" gorm:"type:varchar(100);not null"`
-	Status     string          `json:"status" gorm:"type:varchar(20);not null"`
-	Seats      *int            `json:"seats"`
-	UserID     uint            `json:"user_id" gorm:"not null;index"`
-	CategoryID *uint           `json:"category_id" gorm:"index"`
-	Category   *Category       `json:"category" gorm:"foreignKey:CategoryID"`
-	Schedule   []EventSchedule `json:"schedule" gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE"`
+	Title          string          `json:"title" gorm:"type:varchar(100);not null"`
+	Description    string          `json:"description" gorm:"type:text;not null;default:''"`
+	Status         string          `json:"status" gorm:"type:varchar(20);not null"`
+	Seats          *int            `json:"seats"`
+	UserID         uint            `json:"user_id" gorm:"not null;index"`
+	OrganizationID *uint           `json:"organization_id" gorm:"index"` // nil — личное мероприятие UserID
+	CategoryID     *uint           `json:"category_id" gorm:"index"`
+	Category       *Category       `json:"category" gorm:"foreignKey:CategoryID"`
+	VenueID        *uint           `json:"venue_id" gorm:"index"`
+	Venue          *Venue          `json:"venue,omitempty" gorm:"foreignKey:VenueID"`
+	Schedule       []EventSchedule `json:"schedule" gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE"`
+	Inventory      *EventInventory `json:"inventory,omitempty" gorm:"foreignKey:EventID"`
+
+	// Rank и DistanceKm заполняются только в List: релевантность полнотекстового
+	// поиска и расстояние до площадки при поиске по координатам.
+	Rank       float64  `json:"rank,omitempty" gorm:"->;-:migration"`
+	DistanceKm *float64 `json:"distance_km,omitempty" gorm:"->;-:migration"`
 }
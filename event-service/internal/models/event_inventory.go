@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// EventInventory — цены и остаток билетов мероприятия по данным
+// ticket-service (топик ticket.inventory_changed). Хранится последний снимок.
+type EventInventory struct {
+	EventID    uint      `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	MinPrice   int64     `json:"min_price" gorm:"not null;index"`
+	MaxPrice   int64     `json:"max_price" gorm:"not null;index"`
+	Available  int       `json:"available" gorm:"not null"`
+	SnapshotAt time.Time `json:"snapshot_at" gorm:"not null"`
+}
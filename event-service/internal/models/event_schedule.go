@@ -10,6 +10,14 @@ type EventSchedule struct {
 	Event        Event     `json:"-" gorm:"foreignKey:EventID"`
 	ActivityName string    `json:"activity_name" gorm:"type:varchar(100);not null"`
 	Speaker      string    `json:"speaker" gorm:"type:varchar(50);not null"`
+	VenueID      *uint     `json:"venue_id" gorm:"index"` // nil — площадка мероприятия
+	Venue        *Venue    `json:"venue,omitempty" gorm:"foreignKey:VenueID"`
 	StartAt      time.Time `json:"start_at" gorm:"not null"`
 	EndAt        time.Time `json:"end_at" gorm:"not null"`
+
+	// Повторение серии: OccurrenceAt — начало по правилу (RECURRENCE-ID),
+	// Detached — повторение изменено отдельно и серия его не перезаписывает.
+	SeriesID     *uint      `json:"series_id" gorm:"uniqueIndex:idx_schedule_occurrence,priority:1,where:deleted_at IS NULL"`
+	OccurrenceAt *time.Time `json:"occurrence_at,omitempty" gorm:"uniqueIndex:idx_schedule_occurrence,priority:2"`
+	Detached     bool       `json:"detached" gorm:"not null;default:false"`
 }
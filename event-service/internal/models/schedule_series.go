@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// ScheduleSeries — шаблон повторяющейся активности (iCalendar RRULE). По нему
+// в пределах горизонта создаются строки EventSchedule со ссылкой SeriesID.
+type ScheduleSeries struct {
+	Base
+	EventID         uint        `json:"event_id" gorm:"not null;index"`
+	ActivityName    string      `json:"activity_name" gorm:"type:varchar(100);not null"`
+	Speaker         string      `json:"speaker" gorm:"type:varchar(50);not null"`
+	VenueID         *uint       `json:"venue_id" gorm:"index"`
+	StartAt         time.Time   `json:"start_at" gorm:"not null"` // DTSTART — начало первого повторения
+	DurationMinutes int         `json:"duration_minutes" gorm:"not null"`
+	Timezone        string      `json:"timezone" gorm:"type:varchar(64);not null"` // повторения считаются в местном времени
+	RRule           string      `json:"rrule" gorm:"type:varchar(255);not null"`   // без префикса RRULE: и DTSTART
+	ExDates         []time.Time `json:"exdates" gorm:"serializer:json;type:text"`
+	GeneratedUntil  time.Time   `json:"generated_until"` // до этого момента повторения уже созданы
+	// SplitFrom — серии, от которых эта отделена правкой «это и следующие»,
+	// начиная с исходной. Билеты на них действуют и на её повторения.
+	SplitFrom []uint `json:"split_from,omitempty" gorm:"serializer:json;type:text"`
+	// AllowConflicts — повторения сохраняются, даже если спикер или зал
+	// заняты; без него материализация пропускает такие даты через EXDATE.
+	AllowConflicts bool `json:"allow_conflicts"`
+}
+
+func (s *ScheduleSeries) Duration() time.Duration {
+	return time.Duration(s.DurationMinutes) * time.Minute
+}
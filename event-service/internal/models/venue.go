@@ -0,0 +1,14 @@
+package models
+
+// Venue — площадка мероприятия. Координаты нужны для поиска «рядом со мной»,
+// вместимость ограничивает Event.Seats.
+type Venue struct {
+	Base
+	Name      string  `json:"name" gorm:"type:varchar(100);not null"`
+	Address   string  `json:"address" gorm:"type:varchar(255);not null"`
+	Latitude  float64 `json:"latitude" gorm:"not null;index:idx_venues_location,priority:1"`
+	Longitude float64 `json:"longitude" gorm:"not null;index:idx_venues_location,priority:2"`
+	Capacity  int     `json:"capacity" gorm:"not null"`
+	Timezone  string  `json:"timezone" gorm:"type:varchar(64);not null"` // IANA, например Europe/Moscow
+	UserID    uint    `json:"user_id" gorm:"not null;index"`             // кто завёл площадку
+}
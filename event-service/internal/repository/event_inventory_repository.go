@@ -0,0 +1,43 @@
+package repository
+
+import (
+	e "event-service/internal/errors"
+	"event-service/internal/models"
+	"log/slog"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventInventoryRepository interface {
+	// Upsert сохраняет снимок, если он новее сохранённого: снимки одного
+	// мероприятия могут прийти повторно или не по порядку.
+	Upsert(inventory *models.EventInventory) error
+}
+
+type gormEventInventoryRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewEventInventoryRepository(db *gorm.DB, logger *slog.Logger) EventInventoryRepository {
+	return &gormEventInventoryRepository{db: db, logger: logger}
+}
+
+func (r *gormEventInventoryRepository) Upsert(inventory *models.EventInventory) error {
+	if inventory == nil {
+		return e.ErrEventInventoryIsNil
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"min_price", "max_price", "available", "snapshot_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "event_inventories.snapshot_at < excluded.snapshot_at"},
+		}},
+	}).Create(inventory).Error
+	if err != nil {
+		r.logger.Error("failed to upsert event inventory", "error", err, "event_id", inventory.EventID)
+		return err
+	}
+	return nil
+}
@@ -4,9 +4,10 @@ import (
 	"errors"
 	"event-service/internal/dto"
 	e "event-service/internal/errors"
+	"event-service/internal/geo"
 	"event-service/internal/models"
 	"log/slog"
-	"strings"
+	"strconv"
 	"time"
 
 	"gorm.io/gorm"
@@ -17,9 +18,22 @@ type EventRepository interface {
 	GetByID(id uint) (*models.Event, error)
 	Update(event *models.Event) error
 	Delete(id uint) error
-	List(query dto.EventListQuery) ([]models.Event, error)
+	// List ищет мероприятия и возвращает страницу после курсора after (nil —
+	// первая страница). Сортировка и лимит в query уже нормализованы сервисом.
+	List(query dto.EventListQuery, after *dto.EventCursor) (*EventPage, error)
 	GetByUserID(userID uint) ([]models.Event, error)
 	GetEventStartingTomorrow() ([]models.Event, error)
+	// AnonymizeUser отвязывает мероприятия, включая удалённые, от пользователя.
+	AnonymizeUser(userID uint) (int64, error)
+}
+
+// EventPage — страница поиска: Total и Facets считаются по всем
+// подходящим мероприятиям, а не только по странице.
+type EventPage struct {
+	Events  []models.Event
+	Total   int64
+	HasMore bool
+	Facets  []dto.CategoryFacet
 }
 
 type gormEventRepository struct {
@@ -47,7 +61,8 @@ func (r *gormEventRepository) GetByID(id uint) (*models.Event, error) {
 	var event models.Event
 
 	if err := r.db.Preload("Category").
-		Preload("Schedule").
+		Preload("Venue").
+		Preload("Schedule.Venue").
 		First(&event, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			r.logger.Debug("event not found by id", slog.Int("id", int(id)))
@@ -80,62 +95,231 @@ func (r *gormEventRepository) Delete(id uint) error {
 	return nil
 }
 
-func (r *gormEventRepository) List(query dto.EventListQuery) ([]models.Event, error) {
+func (r *gormEventRepository) List(query dto.EventListQuery, after *dto.EventCursor) (*EventPage, error) {
+	var total int64
+	if err := r.filtered(query, true).Count(&total).Error; err != nil {
+		r.logger.Error("failed to count events", "error", err)
+		return nil, err
+	}
+
+	facets, err := r.categoryFacets(query)
+	if err != nil {
+		r.logger.Error("failed to count category facets", "error", err)
+		return nil, err
+	}
+
+	sortExpr, sortArgs := sortKey(query)
+
+	selectSQL, selectArgs := computedColumns(query)
+	db := r.filtered(query, true).
+		Select("events.*, "+selectSQL, selectArgs...)
+
+	if after != nil {
+		op := "<"
+		if query.SortOrder == "asc" {
+			op = ">"
+		}
+		value, err := cursorValue(query.SortBy, after.Value)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("("+sortExpr+", events.id) "+op+" (?, ?)", append(sortArgs, value, after.ID)...)
+	}
+
+	// вычисляемые ключи сортируются по псевдониму из SELECT
+	orderBy := sortExpr
+	switch query.SortBy {
+	case dto.SortByRelevance:
+		orderBy = "rank"
+	case dto.SortByDistance:
+		orderBy = "distance_km"
+	}
+
+	var events []models.Event
+	if err := db.Preload("Category").
+		Preload("Venue").
+		Preload("Schedule").
+		Preload("Inventory").
+		Order(orderBy + " " + query.SortOrder + ", events.id " + query.SortOrder).
+		Limit(query.Limit + 1).
+		Find(&events).Error; err != nil {
+		r.logger.Error("failed to list events", "error", err)
+		return nil, err
+	}
+
+	page := &EventPage{Total: total, Facets: facets}
+	if len(events) > query.Limit {
+		events = events[:query.Limit]
+		page.HasMore = true
+	}
+	page.Events = events
+	return page, nil
+}
+
+// filtered применяет фильтры запроса. Фильтр по категории отключается для
+// фасетов: они показывают, сколько найдётся при выборе другой категории.
+func (r *gormEventRepository) filtered(query dto.EventListQuery, withCategory bool) *gorm.DB {
 	db := r.db.Model(&models.Event{})
 
+	if query.Q != "" {
+		db = db.Where("events.search_vector @@ websearch_to_tsquery('"+searchConfig+"', ?)", query.Q)
+	}
+
 	if query.Title != "" {
-		db = db.Where("title ILIKE ?", "%"+query.Title+"%")
+		db = db.Where("events.title ILIKE ?", "%"+query.Title+"%")
 	}
 
 	if query.Status != "" {
-		db = db.Where("status = ?", query.Status)
+		db = db.Where("events.status = ?", query.Status)
 	}
 
-	sortBy := strings.ToLower(strings.TrimSpace(query.SortBy))
-	sortOrder := strings.ToLower(strings.TrimSpace(query.SortOrder))
+	if query.OrganizationID != nil {
+		db = db.Where("events.organization_id = ?", *query.OrganizationID)
+	}
 
-	validSortFields := map[string]string{
-		"title":      "title",
-		"created_at": "created_at",
+	if withCategory && query.CategoryID != nil {
+		db = db.Where("events.category_id = ?", *query.CategoryID)
 	}
 
-	sortField, ok := validSortFields[sortBy]
-	if !ok {
-		sortField = "created_at"
+	if query.DateFrom != nil || query.DateTo != nil {
+		schedules := r.db.Model(&models.EventSchedule{}).
+			Select("1").
+			Where("event_schedules.event_id = events.id")
+		if query.DateFrom != nil {
+			schedules = schedules.Where("event_schedules.start_at >= ?", *query.DateFrom)
+		}
+		if query.DateTo != nil {
+			schedules = schedules.Where("event_schedules.start_at < ?", *query.DateTo)
+		}
+		db = db.Where("EXISTS (?)", schedules)
 	}
 
-	validOrders := map[string]string{
-		"asc":  "ASC",
-		"desc": "DESC",
+	if nearby(query) {
+		// прямоугольник отсекает далёкие площадки по индексу, круг — точно
+		box := geo.Around(*query.Lat, *query.Lng, *query.RadiusKm)
+		db = db.Joins("JOIN venues ON venues.id = events.venue_id AND venues.deleted_at IS NULL").
+			Where("venues.latitude BETWEEN ? AND ?", box.MinLat, box.MaxLat).
+			Where("venues.longitude BETWEEN ? AND ?", box.MinLng, box.MaxLng).
+			Where(distanceExpr+" <= ?", append(distanceArgs(query), *query.RadiusKm)...)
 	}
 
-	order, ok := validOrders[sortOrder]
-	if !ok {
-		order = "DESC"
+	if query.PriceMin != nil || query.PriceMax != nil || query.Available != nil {
+		db = db.Joins("LEFT JOIN event_inventories inv ON inv.event_id = events.id")
 	}
 
-	if query.Page < 1 {
-		query.Page = dto.DefaultPage
+	// мероприятие подходит, если его диапазон цен пересекается с запрошенным
+	if query.PriceMin != nil {
+		db = db.Where("inv.max_price >= ?", *query.PriceMin)
 	}
 
-	if query.Limit < 1 {
-		query.Limit = dto.DefaultLimit
+	if query.PriceMax != nil {
+		db = db.Where("inv.min_price <= ?", *query.PriceMax)
 	}
 
-	offset := (query.Page - 1) * query.Limit
+	if query.Available != nil {
+		if *query.Available {
+			db = db.Where("inv.available > 0")
+		} else {
+			db = db.Where("COALESCE(inv.available, 0) = 0")
+		}
+	}
 
-	var events []models.Event
+	return db
+}
 
-	if err := db.Preload("Category").
-		Preload("Schedule").
-		Order(sortField + " " + order).
-		Limit(query.Limit).
-		Offset(offset).
-		Find(&events).Error; err != nil {
-		r.logger.Error("failed to list events", "error", err)
+func (r *gormEventRepository) categoryFacets(query dto.EventListQuery) ([]dto.CategoryFacet, error) {
+	var rows []struct {
+		CategoryID *uint
+		Name       *string
+		Count      int64
+	}
+	if err := r.filtered(query, false).
+		Select("events.category_id, categories.name, COUNT(*) AS count").
+		Joins("LEFT JOIN categories ON categories.id = events.category_id").
+		Group("events.category_id, categories.name").
+		Order("count DESC, events.category_id").
+		Scan(&rows).Error; err != nil {
 		return nil, err
 	}
-	return events, nil
+
+	facets := make([]dto.CategoryFacet, 0, len(rows))
+	for _, row := range rows {
+		facet := dto.CategoryFacet{CategoryID: row.CategoryID, Count: row.Count}
+		if row.Name != nil {
+			facet.Name = *row.Name
+		}
+		facets = append(facets, facet)
+	}
+	return facets, nil
+}
+
+// rankExpr — релевантность в float8: значение из курсора сравнивается с ним без потерь.
+const rankExpr = "ts_rank(events.search_vector, websearch_to_tsquery('" + searchConfig + "', ?))::float8"
+
+// distanceExpr — расстояние в километрах от точки (?, ?, ?: lat, lat, lng)
+// до площадки мероприятия по формуле гаверсинуса.
+var distanceExpr = `(2 * ` + strconv.FormatFloat(geo.EarthRadiusKm, 'f', -1, 64) + ` * asin(sqrt(
+	power(sin(radians(venues.latitude - ?) / 2), 2) +
+	cos(radians(?)) * cos(radians(venues.latitude)) *
+	power(sin(radians(venues.longitude - ?) / 2), 2))))`
+
+func nearby(query dto.EventListQuery) bool {
+	return query.Lat != nil && query.Lng != nil && query.RadiusKm != nil
+}
+
+func distanceArgs(query dto.EventListQuery) []any {
+	return []any{*query.Lat, *query.Lat, *query.Lng}
+}
+
+// sortKey — выражение сортировки и его параметры для ORDER BY и курсора.
+func sortKey(query dto.EventListQuery) (string, []any) {
+	switch query.SortBy {
+	case dto.SortByRelevance:
+		return rankExpr, []any{query.Q}
+	case dto.SortByDistance:
+		return distanceExpr, distanceArgs(query)
+	default:
+		return "events." + query.SortBy, nil
+	}
+}
+
+// computedColumns заполняет models.Event.Rank и DistanceKm. Таких колонок
+// нет в таблице, поэтому без запроса и координат они выбираются константами.
+func computedColumns(query dto.EventListQuery) (string, []any) {
+	sql := "0::float8 AS rank"
+	var args []any
+	if query.Q != "" {
+		sql = rankExpr + " AS rank"
+		args = append(args, query.Q)
+	}
+
+	if nearby(query) {
+		sql += ", " + distanceExpr + " AS distance_km"
+		args = append(args, distanceArgs(query)...)
+	} else {
+		sql += ", NULL::float8 AS distance_km"
+	}
+	return sql, args
+}
+
+// cursorValue разбирает ключ сортировки из курсора.
+func cursorValue(sortBy, raw string) (any, error) {
+	switch sortBy {
+	case dto.SortByRelevance, dto.SortByDistance:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, e.ErrInvalidCursor
+		}
+		return v, nil
+	case dto.SortByCreatedAt:
+		v, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return nil, e.ErrInvalidCursor
+		}
+		return v, nil
+	default:
+		return raw, nil
+	}
 }
 
 func (r *gormEventRepository) GetByUserID(userID uint) ([]models.Event, error) {
@@ -186,3 +370,14 @@ func (r *gormEventRepository) GetEventStartingTomorrow() ([]models.Event, error)
 	}
 	return events, nil
 }
+
+func (r *gormEventRepository) AnonymizeUser(userID uint) (int64, error) {
+	res := r.db.Unscoped().Model(&models.Event{}).
+		Where("user_id = ?", userID).
+		Update("user_id", 0)
+	if res.Error != nil {
+		r.logger.Error("failed to anonymize user events", "error", res.Error, "user_id", userID)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
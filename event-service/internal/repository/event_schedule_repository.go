@@ -1,9 +1,15 @@
 package repository
 
 import (
+	"errors"
+	"event-service/internal/dto"
 	e "event-service/internal/errors"
 	"event-service/internal/models"
+	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -12,8 +18,21 @@ type EventScheduleRepository interface {
 	Create(schedule *models.EventSchedule) error
 	GetByID(id uint) (*models.EventSchedule, error)
 	GetByEventID(eventID uint) ([]models.EventSchedule, error)
+	Update(schedule *models.EventSchedule) error
+	Delete(id uint) error
+	// Overlapping — активности других строк, идущие одновременно с [start, end)
+	// у того же спикера или в том же зале. Зал строки без venue_id — площадка
+	// мероприятия; отменённые мероприятия не учитываются.
+	Overlapping(excludeID uint, speaker string, venueID *uint, start, end time.Time) ([]models.EventSchedule, error)
+	// LockResources до конца транзакции блокирует спикера и зал, чтобы
+	// проверка пересечений и запись не перемежались с чужими.
+	LockResources(speaker string, venueID *uint) error
+	Transaction(fn func(repo EventScheduleRepository) error) error
 }
 
+// maxOverlaps ограничивает список пересечений в ответе.
+const maxOverlaps = 20
+
 type gormScheduleRepository struct {
 	db     *gorm.DB
 	logger *slog.Logger
@@ -39,6 +58,9 @@ func (r *gormScheduleRepository) GetByID(id uint) (*models.EventSchedule, error)
 	var schedule models.EventSchedule
 
 	if err := r.db.First(&schedule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrEventScheduleNotFound
+		}
 		r.logger.Error("failed to get schedule by id", "error", err, "id", id)
 		return nil, err
 	}
@@ -49,9 +71,99 @@ func (r *gormScheduleRepository) GetByEventID(eventID uint) ([]models.EventSched
 	var schedules []models.EventSchedule
 
 	if err := r.db.Where("event_id = ?", eventID).
+		Preload("Venue").
 		Find(&schedules).Error; err != nil {
 		r.logger.Error("failed to get schedules by event", "error", err, "event_id", eventID)
 		return nil, err
 	}
 	return schedules, nil
 }
+
+func (r *gormScheduleRepository) Update(schedule *models.EventSchedule) error {
+	if schedule == nil {
+		return e.ErrEventScheduleIsNil
+	}
+	if err := r.db.Omit("Event", "Venue").Save(schedule).Error; err != nil {
+		r.logger.Error("failed to update schedule", "error", err, "id", schedule.ID)
+		return err
+	}
+	return nil
+}
+
+func (r *gormScheduleRepository) Delete(id uint) error {
+	if err := r.db.Delete(&models.EventSchedule{}, id).Error; err != nil {
+		r.logger.Error("failed to delete schedule", "error", err, "id", id)
+		return err
+	}
+	return nil
+}
+
+func (r *gormScheduleRepository) Overlapping(excludeID uint, speaker string, venueID *uint, start, end time.Time) ([]models.EventSchedule, error) {
+	return overlappingSchedules(r.db, r.logger, excludeID, speaker, venueID, start, end)
+}
+
+func (r *gormScheduleRepository) LockResources(speaker string, venueID *uint) error {
+	return lockScheduleResources(r.db, r.logger, speaker, venueID)
+}
+
+// overlappingSchedules — общая для активностей и серий выборка пересечений.
+func overlappingSchedules(db *gorm.DB, logger *slog.Logger, excludeID uint, speaker string, venueID *uint, start, end time.Time) ([]models.EventSchedule, error) {
+	var schedules []models.EventSchedule
+
+	speaker = strings.ToLower(strings.TrimSpace(speaker))
+	var same *gorm.DB
+	switch {
+	case speaker != "" && venueID != nil:
+		same = db.Where("lower(trim(event_schedules.speaker)) = ?", speaker).
+			Or("COALESCE(event_schedules.venue_id, events.venue_id) = ?", *venueID)
+	case speaker != "":
+		same = db.Where("lower(trim(event_schedules.speaker)) = ?", speaker)
+	case venueID != nil:
+		same = db.Where("COALESCE(event_schedules.venue_id, events.venue_id) = ?", *venueID)
+	default:
+		// без спикера и зала пересекаться не с чем
+		return nil, nil
+	}
+
+	if err := db.Joins("JOIN events ON events.id = event_schedules.event_id AND events.deleted_at IS NULL").
+		Where("event_schedules.id <> ?", excludeID).
+		Where("events.status <> ?", string(dto.Cancelled)).
+		Where("event_schedules.start_at < ? AND event_schedules.end_at > ?", end, start).
+		Where(same).
+		Preload("Event").
+		Order("event_schedules.start_at").
+		Limit(maxOverlaps).
+		Find(&schedules).Error; err != nil {
+		logger.Error("failed to find overlapping schedules", "error", err)
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// lockScheduleResources берёт advisory-блокировки спикера и зала. Ключи
+// общие для активностей и серий, поэтому они видят записи друг друга.
+func lockScheduleResources(db *gorm.DB, logger *slog.Logger, speaker string, venueID *uint) error {
+	keys := make([]string, 0, 2)
+	if venueID != nil {
+		keys = append(keys, fmt.Sprintf("schedule:venue:%d", *venueID))
+	}
+	if speaker = strings.ToLower(strings.TrimSpace(speaker)); speaker != "" {
+		keys = append(keys, "schedule:speaker:"+speaker)
+	}
+	// один порядок во всех транзакциях — без взаимных блокировок
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error; err != nil {
+			logger.Error("failed to lock schedule resource", "error", err, "key", key)
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *gormScheduleRepository) Transaction(fn func(repo EventScheduleRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&gormScheduleRepository{db: tx, logger: r.logger})
+	})
+}
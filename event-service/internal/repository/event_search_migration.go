@@ -0,0 +1,63 @@
+package repository
+
+import "gorm.io/gorm"
+
+// searchConfig — конфигурация полнотекстового поиска Postgres. Русская
+// конфигурация стеммит и латиницу (english_stem), названия бывают на обоих языках.
+const searchConfig = "russian"
+
+// eventSearchMigrations поддерживают events.search_vector триггерами: вектор
+// собирается из названия (вес A), описания (B) и спикеров расписания (C),
+// поэтому обновляется и при изменении event_schedules.
+var eventSearchMigrations = []string{
+	`ALTER TABLE events ADD COLUMN IF NOT EXISTS search_vector tsvector`,
+	`CREATE INDEX IF NOT EXISTS idx_events_search_vector ON events USING GIN (search_vector)`,
+	`CREATE OR REPLACE FUNCTION refresh_event_search_vector(target_id bigint) RETURNS void AS $$
+		UPDATE events e SET search_vector =
+			setweight(to_tsvector('` + searchConfig + `', coalesce(e.title, '')), 'A') ||
+			setweight(to_tsvector('` + searchConfig + `', coalesce(e.description, '')), 'B') ||
+			setweight(to_tsvector('` + searchConfig + `', coalesce((
+				SELECT string_agg(s.speaker, ' ') FROM event_schedules s
+				WHERE s.event_id = e.id AND s.deleted_at IS NULL
+			), '')), 'C')
+		WHERE e.id = target_id
+	$$ LANGUAGE sql`,
+	`CREATE OR REPLACE FUNCTION events_search_vector_trigger() RETURNS trigger AS $$
+	BEGIN
+		PERFORM refresh_event_search_vector(NEW.id);
+		RETURN NULL;
+	END
+	$$ LANGUAGE plpgsql`,
+	`DROP TRIGGER IF EXISTS events_search_vector ON events`,
+	`CREATE TRIGGER events_search_vector AFTER INSERT OR UPDATE OF title, description ON events
+		FOR EACH ROW EXECUTE FUNCTION events_search_vector_trigger()`,
+	`CREATE OR REPLACE FUNCTION event_schedules_search_vector_trigger() RETURNS trigger AS $$
+	BEGIN
+		IF TG_OP <> 'INSERT' THEN
+			PERFORM refresh_event_search_vector(OLD.event_id);
+		END IF;
+		IF TG_OP <> 'DELETE' THEN
+			PERFORM refresh_event_search_vector(NEW.event_id);
+		END IF;
+		RETURN NULL;
+	END
+	$$ LANGUAGE plpgsql`,
+	`DROP TRIGGER IF EXISTS event_schedules_search_vector ON event_schedules`,
+	`CREATE TRIGGER event_schedules_search_vector AFTER INSERT OR UPDATE OR DELETE ON event_schedules
+		FOR EACH ROW EXECUTE FUNCTION event_schedules_search_vector_trigger()`,
+	// мероприятия, созданные до появления поиска
+	`SELECT refresh_event_search_vector(id) FROM events WHERE search_vector IS NULL`,
+}
+
+// MigrateEventSearch создаёт колонку, индекс и триггеры полнотекстового
+// поиска. Вызывается после AutoMigrate, повторный запуск безопасен.
+func MigrateEventSearch(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, stmt := range eventSearchMigrations {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
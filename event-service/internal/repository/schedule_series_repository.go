@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"errors"
+	e "event-service/internal/errors"
+	"event-service/internal/models"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ScheduleSeriesRepository interface {
+	Create(series *models.ScheduleSeries) error
+	GetByID(id uint) (*models.ScheduleSeries, error)
+	Update(series *models.ScheduleSeries) error
+	Delete(id uint) error
+	GetByEventID(eventID uint) ([]models.ScheduleSeries, error)
+	// GetDue — серии, повторения которых созданы не до конца горизонта.
+	GetDue(horizon time.Time) ([]models.ScheduleSeries, error)
+
+	// Occurrences — строки расписания серии с OccurrenceAt не раньше from.
+	Occurrences(seriesID uint, from time.Time) ([]models.EventSchedule, error)
+	GetOccurrence(seriesID, scheduleID uint) (*models.EventSchedule, error)
+	SaveOccurrence(schedule *models.EventSchedule) error
+	// DeleteOccurrences удаляет строки окончательно: уникальный индекс
+	// (series_id, occurrence_at) не должен мешать создать повторение снова.
+	DeleteOccurrences(ids []uint) error
+	// Overlapping и LockResources — как у EventScheduleRepository: повторения
+	// проверяются на пересечения тем же способом, что и разовые активности.
+	Overlapping(excludeID uint, speaker string, venueID *uint, start, end time.Time) ([]models.EventSchedule, error)
+	LockResources(speaker string, venueID *uint) error
+
+	// Transaction выполняет fn в одной транзакции; fn работает с репозиторием tx.
+	Transaction(fn func(repo ScheduleSeriesRepository) error) error
+}
+
+type gormScheduleSeriesRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewScheduleSeriesRepository(db *gorm.DB, logger *slog.Logger) ScheduleSeriesRepository {
+	return &gormScheduleSeriesRepository{db: db, logger: logger}
+}
+
+func (r *gormScheduleSeriesRepository) Create(series *models.ScheduleSeries) error {
+	if series == nil {
+		return e.ErrScheduleSeriesIsNil
+	}
+	r.logger.Debug("creating schedule series", slog.Int("event_id", int(series.EventID)))
+	if err := r.db.Create(series).Error; err != nil {
+		r.logger.Error("failed to create schedule series", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *gormScheduleSeriesRepository) GetByID(id uint) (*models.ScheduleSeries, error) {
+	var series models.ScheduleSeries
+
+	if err := r.db.First(&series, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrScheduleSeriesNotFound
+		}
+		r.logger.Error("failed to get schedule series by id", "error", err, "id", id)
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *gormScheduleSeriesRepository) Update(series *models.ScheduleSeries) error {
+	if series == nil {
+		return e.ErrScheduleSeriesIsNil
+	}
+	if err := r.db.Save(series).Error; err != nil {
+		r.logger.Error("failed to update schedule series", "error", err, "id", series.ID)
+		return err
+	}
+	return nil
+}
+
+func (r *gormScheduleSeriesRepository) Delete(id uint) error {
+	if err := r.db.Delete(&models.ScheduleSeries{}, id).Error; err != nil {
+		r.logger.Error("failed to delete schedule series", "error", err, "id", id)
+		return err
+	}
+	return nil
+}
+
+func (r *gormScheduleSeriesRepository) GetByEventID(eventID uint) ([]models.ScheduleSeries, error) {
+	var series []models.ScheduleSeries
+
+	if err := r.db.Where("event_id = ?", eventID).
+		Order("start_at").
+		Find(&series).Error; err != nil {
+		r.logger.Error("failed to get schedule series by event", "error", err, "event_id", eventID)
+		return nil, err
+	}
+	return series, nil
+}
+
+func (r *gormScheduleSeriesRepository) GetDue(horizon time.Time) ([]models.ScheduleSeries, error) {
+	var series []models.ScheduleSeries
+
+	if err := r.db.Where("generated_until < ?", horizon).
+		Find(&series).Error; err != nil {
+		r.logger.Error("failed to get due schedule series", "error", err)
+		return nil, err
+	}
+	return series, nil
+}
+
+func (r *gormScheduleSeriesRepository) Occurrences(seriesID uint, from time.Time) ([]models.EventSchedule, error) {
+	var schedules []models.EventSchedule
+
+	if err := r.db.Where("series_id = ? AND occurrence_at >= ?", seriesID, from).
+		Order("occurrence_at").
+		Find(&schedules).Error; err != nil {
+		r.logger.Error("failed to get series occurrences", "error", err, "series_id", seriesID)
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *gormScheduleSeriesRepository) GetOccurrence(seriesID, scheduleID uint) (*models.EventSchedule, error) {
+	var schedule models.EventSchedule
+
+	if err := r.db.Where("series_id = ?", seriesID).First(&schedule, scheduleID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrOccurrenceNotFound
+		}
+		r.logger.Error("failed to get series occurrence", "error", err, "series_id", seriesID, "id", scheduleID)
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *gormScheduleSeriesRepository) SaveOccurrence(schedule *models.EventSchedule) error {
+	if schedule == nil {
+		return e.ErrEventScheduleIsNil
+	}
+	if err := r.db.Omit("Event", "Venue").Save(schedule).Error; err != nil {
+		r.logger.Error("failed to save series occurrence", "error", err, "series_id", schedule.SeriesID)
+		return err
+	}
+	return nil
+}
+
+func (r *gormScheduleSeriesRepository) DeleteOccurrences(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.db.Unscoped().Delete(&models.EventSchedule{}, ids).Error; err != nil {
+		r.logger.Error("failed to delete series occurrences", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *gormScheduleSeriesRepository) Overlapping(excludeID uint, speaker string, venueID *uint, start, end time.Time) ([]models.EventSchedule, error) {
+	return overlappingSchedules(r.db, r.logger, excludeID, speaker, venueID, start, end)
+}
+
+func (r *gormScheduleSeriesRepository) LockResources(speaker string, venueID *uint) error {
+	return lockScheduleResources(r.db, r.logger, speaker, venueID)
+}
+
+func (r *gormScheduleSeriesRepository) Transaction(fn func(repo ScheduleSeriesRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&gormScheduleSeriesRepository{db: tx, logger: r.logger})
+	})
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"errors"
+	e "event-service/internal/errors"
+	"event-service/internal/models"
+	"log/slog"
+
+	"gorm.io/gorm"
+)
+
+type VenueRepository interface {
+	Create(venue *models.Venue) error
+	GetByID(id uint) (*models.Venue, error)
+	Update(venue *models.Venue) error
+	Delete(id uint) error
+	List() ([]models.Venue, error)
+	// CountEvents — число мероприятий и активностей расписания на площадке.
+	CountEvents(id uint) (int64, error)
+	// MaxEventSeats — наибольшее Seats среди мероприятий площадки, 0 если их нет.
+	MaxEventSeats(id uint) (int, error)
+}
+
+type gormVenueRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewVenueRepository(db *gorm.DB, logger *slog.Logger) VenueRepository {
+	return &gormVenueRepository{db: db, logger: logger}
+}
+
+func (r *gormVenueRepository) Create(venue *models.Venue) error {
+	if venue == nil {
+		return e.ErrVenueIsNil
+	}
+	r.logger.Debug("creating venue", slog.String("name", venue.Name))
+	if err := r.db.Create(venue).Error; err != nil {
+		r.logger.Error("failed to create venue", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *gormVenueRepository) GetByID(id uint) (*models.Venue, error) {
+	var venue models.Venue
+
+	if err := r.db.First(&venue, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			r.logger.Debug("venue not found by id", slog.Int("id", int(id)))
+			return nil, e.ErrVenueNotFound
+		}
+		r.logger.Error("failed to get venue by id", "error", err, "id", id)
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func (r *gormVenueRepository) Update(venue *models.Venue) error {
+	if venue == nil {
+		return e.ErrVenueIsNil
+	}
+	r.logger.Debug("updating venue", slog.Int("id", int(venue.ID)))
+	if err := r.db.Save(venue).Error; err != nil {
+		r.logger.Error("failed to update venue", "error", err, "id", venue.ID)
+		return err
+	}
+	return nil
+}
+
+func (r *gormVenueRepository) Delete(id uint) error {
+	r.logger.Debug("deleting venue", slog.Int("id", int(id)))
+	if err := r.db.Delete(&models.Venue{}, id).Error; err != nil {
+		r.logger.Error("failed to delete venue", "error", err, "id", id)
+		return err
+	}
+	return nil
+}
+
+func (r *gormVenueRepository) List() ([]models.Venue, error) {
+	var venues []models.Venue
+
+	if err := r.db.Order("name").Find(&venues).Error; err != nil {
+		r.logger.Error("failed to list venues", "error", err)
+		return nil, err
+	}
+	return venues, nil
+}
+
+func (r *gormVenueRepository) CountEvents(id uint) (int64, error) {
+	var events, schedules int64
+	if err := r.db.Model(&models.Event{}).Where("venue_id = ?", id).Count(&events).Error; err != nil {
+		r.logger.Error("failed to count venue events", "error", err, "id", id)
+		return 0, err
+	}
+	if err := r.db.Model(&models.EventSchedule{}).Where("venue_id = ?", id).Count(&schedules).Error; err != nil {
+		r.logger.Error("failed to count venue schedules", "error", err, "id", id)
+		return 0, err
+	}
+	return events + schedules, nil
+}
+
+func (r *gormVenueRepository) MaxEventSeats(id uint) (int, error) {
+	var seats int
+	if err := r.db.Model(&models.Event{}).
+		Select("COALESCE(MAX(seats), 0)").
+		Where("venue_id = ?", id).
+		Scan(&seats).Error; err != nil {
+		r.logger.Error("failed to get venue max seats", "error", err, "id", id)
+		return 0, err
+	}
+	return seats, nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	e "event-service/internal/errors"
+	"event-service/internal/models"
+
+	"user-service/userclient"
+)
+
+const (
+	RoleAdmin     = "admin"
+	RoleOrganizer = "organizer"
+)
+
+// Actor — пользователь, от имени которого пришёл запрос (X-User-Id/X-User-Role от gateway).
+type Actor struct {
+	UserID uint
+	Role   string
+}
+
+func (a Actor) IsAdmin() bool {
+	return a.Role == RoleAdmin
+}
+
+// MembershipResolver возвращает роль пользователя в организации.
+// Реализуется userclient.Client.
+type MembershipResolver interface {
+	GetMembership(ctx context.Context, organizationID, userID uint) (*userclient.Membership, error)
+}
+
+// CanCreateEvents — создавать мероприятия могут организаторы и администраторы.
+func (a Actor) CanCreateEvents() bool {
+	return a.Role == RoleOrganizer || a.IsAdmin()
+}
+
+// authorizeManage проверяет, что actor может менять мероприятие: администратор,
+// владелец или менеджер организации-владельца, либо автор личного мероприятия.
+func authorizeManage(ctx context.Context, members MembershipResolver, actor Actor, event *models.Event) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	if event.OrganizationID == nil {
+		if event.UserID != actor.UserID {
+			return e.ErrForbidden
+		}
+		return nil
+	}
+	return authorizeOrganization(ctx, members, actor, *event.OrganizationID)
+}
+
+// authorizeOrganization — actor может вести мероприятия организации.
+func authorizeOrganization(ctx context.Context, members MembershipResolver, actor Actor, organizationID uint) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+
+	m, err := members.GetMembership(ctx, organizationID, actor.UserID)
+	if errors.Is(err, userclient.ErrNotMember) {
+		return e.ErrForbidden
+	}
+	if err != nil {
+		return err
+	}
+	if !m.Role.CanManageEvents() {
+		return e.ErrForbidden
+	}
+	return nil
+}
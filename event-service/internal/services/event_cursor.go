@@ -0,0 +1,84 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"event-service/internal/dto"
+	"event-service/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// normalizeListQuery приводит сортировку и лимит к допустимым значениям.
+// Сортировки по релевантности и расстоянию без запроса и координат не имеют смысла.
+func normalizeListQuery(query dto.EventListQuery) dto.EventListQuery {
+	query.Q = strings.TrimSpace(query.Q)
+	query.SortBy = strings.ToLower(strings.TrimSpace(query.SortBy))
+	query.SortOrder = strings.ToLower(strings.TrimSpace(query.SortOrder))
+	nearby := query.Lat != nil && query.Lng != nil
+
+	if nearby && query.RadiusKm == nil {
+		radius := float64(dto.DefaultRadiusKm)
+		query.RadiusKm = &radius
+	}
+
+	switch {
+	case query.SortBy == dto.SortByCreatedAt, query.SortBy == dto.SortByTitle:
+	case query.SortBy == dto.SortByRelevance && query.Q != "":
+	case query.SortBy == dto.SortByDistance && nearby:
+	case query.SortBy == "" && query.Q != "":
+		query.SortBy = dto.SortByRelevance
+	case query.SortBy == "" && nearby:
+		query.SortBy = dto.SortByDistance
+	default:
+		query.SortBy = dto.SortByCreatedAt
+	}
+
+	if query.SortOrder != "asc" && query.SortOrder != "desc" {
+		query.SortOrder = "desc"
+		if query.SortBy == dto.SortByTitle || query.SortBy == dto.SortByDistance {
+			query.SortOrder = "asc"
+		}
+	}
+
+	if query.Limit < 1 {
+		query.Limit = dto.DefaultLimit
+	}
+	if query.Limit > dto.MaxLimit {
+		query.Limit = dto.MaxLimit
+	}
+	return query
+}
+
+// encodeCursor запоминает ключ сортировки последнего мероприятия страницы.
+func encodeCursor(query dto.EventListQuery, last models.Event) string {
+	cursor := dto.EventCursor{SortBy: query.SortBy, SortOrder: query.SortOrder, ID: last.ID}
+	switch query.SortBy {
+	case dto.SortByRelevance:
+		cursor.Value = strconv.FormatFloat(last.Rank, 'g', -1, 64)
+	case dto.SortByDistance:
+		if last.DistanceKm != nil {
+			cursor.Value = strconv.FormatFloat(*last.DistanceKm, 'g', -1, 64)
+		}
+	case dto.SortByTitle:
+		cursor.Value = last.Title
+	default:
+		cursor.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(raw string) (*dto.EventCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+	var cursor dto.EventCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
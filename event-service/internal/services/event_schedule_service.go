@@ -1,8 +1,11 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"event-service/internal/dto"
 	e "event-service/internal/errors"
+	"event-service/internal/kafka"
 	"event-service/internal/models"
 	"event-service/internal/repository"
 	"log/slog"
@@ -10,23 +13,34 @@ import (
 
 type EventScheduleService interface {
 	GetScheduleByEventID(eventID uint) ([]models.EventSchedule, error)
-	CreateScheduleForEvent(eventID uint, req dto.CreateScheduleRequest) (*models.EventSchedule, error)
+	CreateScheduleForEvent(ctx context.Context, actor Actor, eventID uint, req dto.CreateScheduleRequest) (*dto.ScheduleResponse, error)
+	UpdateSchedule(ctx context.Context, actor Actor, eventID, scheduleID uint, req dto.UpdateScheduleRequest) (*dto.ScheduleResponse, error)
+	DeleteSchedule(ctx context.Context, actor Actor, eventID, scheduleID uint) error
 }
 
 type eventScheduleService struct {
 	eventScheduleRepo repository.EventScheduleRepository
 	eventRepo         repository.EventRepository
+	venueRepo         repository.VenueRepository
+	kafkaProducer     kafka.EventProducer
+	members           MembershipResolver
 	logger            *slog.Logger
 }
 
 func NewEventScheduleService(
 	eventScheduleRepo repository.EventScheduleRepository,
 	eventRepo repository.EventRepository,
+	venueRepo repository.VenueRepository,
+	kafkaProducer kafka.EventProducer,
+	members MembershipResolver,
 	logger *slog.Logger,
 ) EventScheduleService {
 	return &eventScheduleService{
 		eventScheduleRepo: eventScheduleRepo,
 		eventRepo:         eventRepo,
+		venueRepo:         venueRepo,
+		kafkaProducer:     kafkaProducer,
+		members:           members,
 		logger:            logger,
 	}
 }
@@ -47,32 +61,171 @@ func (s *eventScheduleService) GetScheduleByEventID(eventID uint) ([]models.Even
 }
 
 func (s *eventScheduleService) CreateScheduleForEvent(
+	ctx context.Context,
+	actor Actor,
 	eventID uint,
 	req dto.CreateScheduleRequest,
-) (*models.EventSchedule, error) {
+) (*dto.ScheduleResponse, error) {
 	s.logger.Debug("CreateScheduleForEvent called", slog.Int("event_id", int(eventID)), slog.String("activity", req.ActivityName))
-	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
 		s.logger.Warn("event not found when creating schedule", "event_id", eventID)
 		return nil, e.ErrEventNotFound
 	}
+	if err := authorizeManage(ctx, s.members, actor, event); err != nil {
+		return nil, err
+	}
 
 	if !req.StartAt.Before(req.EndAt) {
 		s.logger.Warn("invalid schedule time", "event_id", eventID)
 		return nil, e.ErrNotCorrectScheduleTime
 	}
 
+	if req.VenueID != nil {
+		if _, err := s.venueRepo.GetByID(*req.VenueID); err != nil {
+			return nil, err
+		}
+	}
+
 	schedule := &models.EventSchedule{
 		EventID:      eventID,
 		ActivityName: req.ActivityName,
 		Speaker:      req.Speaker,
 		StartAt:      req.StartAt,
 		EndAt:        req.EndAt,
+		VenueID:      req.VenueID,
 	}
 
-	if err := s.eventScheduleRepo.Create(schedule); err != nil {
-		s.logger.Error("failed to create schedule", "error", err, "event_id", eventID)
+	var warnings []dto.ScheduleConflict
+	err = s.eventScheduleRepo.Transaction(func(repo repository.EventScheduleRepository) error {
+		if warnings, err = checkConflicts(repo, s.logger, schedule, event, req.AllowConflicts); err != nil {
+			return err
+		}
+		return repo.Create(schedule)
+	})
+	if err != nil {
+		if !errors.As(err, new(*ConflictError)) {
+			s.logger.Error("failed to create schedule", "error", err, "event_id", eventID)
+		}
+		return nil, err
+	}
+	publishScheduleChanged(ctx, s.kafkaProducer, s.logger,
+		scheduleChanged(event, kafka.ScheduleCreated, kafka.ScheduleScopeThis, schedule, nil))
+
+	return &dto.ScheduleResponse{EventSchedule: *schedule, Warnings: warnings}, nil
+}
+
+func (s *eventScheduleService) UpdateSchedule(
+	ctx context.Context,
+	actor Actor,
+	eventID, scheduleID uint,
+	req dto.UpdateScheduleRequest,
+) (*dto.ScheduleResponse, error) {
+	s.logger.Debug("UpdateSchedule called", slog.Int("event_id", int(eventID)), slog.Int("schedule_id", int(scheduleID)))
+	event, schedule, err := s.manageSchedule(ctx, actor, eventID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	previous := *schedule
+
+	if req.ActivityName != nil {
+		schedule.ActivityName = *req.ActivityName
+	}
+	if req.Speaker != nil {
+		schedule.Speaker = *req.Speaker
+	}
+	if req.StartAt != nil {
+		schedule.StartAt = *req.StartAt
+	}
+	if req.EndAt != nil {
+		schedule.EndAt = *req.EndAt
+	}
+	if !schedule.StartAt.Before(schedule.EndAt) {
+		return nil, e.ErrNotCorrectScheduleTime
+	}
+	if req.VenueID != nil {
+		if _, err := s.venueRepo.GetByID(*req.VenueID); err != nil {
+			return nil, err
+		}
+		schedule.VenueID = req.VenueID
+		schedule.Venue = nil
+	}
+
+	var warnings []dto.ScheduleConflict
+	err = s.eventScheduleRepo.Transaction(func(repo repository.EventScheduleRepository) error {
+		if warnings, err = checkConflicts(repo, s.logger, schedule, event, req.AllowConflicts); err != nil {
+			return err
+		}
+		return repo.Update(schedule)
+	})
+	if err != nil {
+		if !errors.As(err, new(*ConflictError)) {
+			s.logger.Error("failed to update schedule", "error", err, "id", scheduleID)
+		}
 		return nil, err
 	}
+	publishScheduleChanged(ctx, s.kafkaProducer, s.logger,
+		scheduleChanged(event, kafka.ScheduleUpdated, kafka.ScheduleScopeThis, schedule, &previous))
 
-	return schedule, nil
+	return &dto.ScheduleResponse{EventSchedule: *schedule, Warnings: warnings}, nil
+}
+
+func (s *eventScheduleService) DeleteSchedule(ctx context.Context, actor Actor, eventID, scheduleID uint) error {
+	s.logger.Debug("DeleteSchedule called", slog.Int("event_id", int(eventID)), slog.Int("schedule_id", int(scheduleID)))
+	event, schedule, err := s.manageSchedule(ctx, actor, eventID, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.eventScheduleRepo.Delete(schedule.ID); err != nil {
+		s.logger.Error("failed to delete schedule", "error", err, "id", scheduleID)
+		return err
+	}
+	publishScheduleChanged(ctx, s.kafkaProducer, s.logger,
+		scheduleChanged(event, kafka.ScheduleDeleted, kafka.ScheduleScopeThis, schedule, nil))
+
+	return nil
+}
+
+// manageSchedule загружает активность мероприятия и проверяет право её менять.
+// Повторения серий меняются через серию, иначе материализация их перезапишет.
+func (s *eventScheduleService) manageSchedule(ctx context.Context, actor Actor, eventID, scheduleID uint) (*models.Event, *models.EventSchedule, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, nil, e.ErrEventNotFound
+	}
+	if err := authorizeManage(ctx, s.members, actor, event); err != nil {
+		return nil, nil, err
+	}
+
+	schedule, err := s.eventScheduleRepo.GetByID(scheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if schedule.EventID != eventID {
+		return nil, nil, e.ErrEventScheduleNotFound
+	}
+	if schedule.SeriesID != nil {
+		return nil, nil, e.ErrScheduleInSeries
+	}
+	return event, schedule, nil
+}
+
+// checkConflicts отклоняет пересечения по спикеру и залу, если их не
+// разрешили явно; разрешённые возвращаются как предупреждения. Вызывается
+// в транзакции записи: спикер и зал блокируются до её конца, иначе две
+// параллельные записи не увидят друг друга.
+func checkConflicts(repo conflictRepository, logger *slog.Logger, schedule *models.EventSchedule, event *models.Event, allow bool) ([]dto.ScheduleConflict, error) {
+	if err := repo.LockResources(schedule.Speaker, scheduleVenue(schedule, event)); err != nil {
+		return nil, err
+	}
+	conflicts, err := findConflicts(repo, schedule, event)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 && !allow {
+		logger.Warn("schedule conflicts", "event_id", event.ID, "count", len(conflicts))
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+	return conflicts, nil
 }
@@ -1,10 +1,13 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"event-service/internal/dto"
 	e "event-service/internal/errors"
+	"event-service/internal/kafka"
 	"event-service/internal/models"
+	"event-service/internal/repository"
 	"testing"
 	"time"
 )
@@ -13,6 +16,11 @@ type mockEventScheduleRepo struct {
 	CreateFunc       func(*models.EventSchedule) error
 	GetByIDFunc      func(uint) (*models.EventSchedule, error)
 	GetByEventIDFunc func(uint) ([]models.EventSchedule, error)
+	UpdateFunc       func(*models.EventSchedule) error
+	DeleteFunc       func(uint) error
+	OverlappingFunc  func(uint, string, *uint, time.Time, time.Time) ([]models.EventSchedule, error)
+	// LockResourcesFunc — блокировка спикера и зала перед проверкой пересечений
+	LockResourcesFunc func(string, *uint) error
 }
 
 func (m *mockEventScheduleRepo) Create(s *models.EventSchedule) error {
@@ -36,6 +44,38 @@ func (m *mockEventScheduleRepo) GetByEventID(eid uint) ([]models.EventSchedule,
 	return nil, nil
 }
 
+func (m *mockEventScheduleRepo) Update(s *models.EventSchedule) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(s)
+	}
+	return nil
+}
+
+func (m *mockEventScheduleRepo) Delete(id uint) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id)
+	}
+	return nil
+}
+
+func (m *mockEventScheduleRepo) LockResources(speaker string, venueID *uint) error {
+	if m.LockResourcesFunc != nil {
+		return m.LockResourcesFunc(speaker, venueID)
+	}
+	return nil
+}
+
+func (m *mockEventScheduleRepo) Transaction(fn func(repo repository.EventScheduleRepository) error) error {
+	return fn(m)
+}
+
+func (m *mockEventScheduleRepo) Overlapping(excludeID uint, speaker string, venueID *uint, start, end time.Time) ([]models.EventSchedule, error) {
+	if m.OverlappingFunc != nil {
+		return m.OverlappingFunc(excludeID, speaker, venueID, start, end)
+	}
+	return nil, nil
+}
+
 func TestSchedule_GetByEventID_Success(t *testing.T) {
 	repo := &mockEventScheduleRepo{
 		GetByEventIDFunc: func(eid uint) ([]models.EventSchedule, error) {
@@ -49,7 +89,7 @@ func TestSchedule_GetByEventID_Success(t *testing.T) {
 		},
 	}
 
-	svc := NewEventScheduleService(repo, evtRepo, logger())
+	svc := NewEventScheduleService(repo, evtRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 
 	got, err := svc.GetScheduleByEventID(1)
 
@@ -70,7 +110,7 @@ func TestSchedule_GetByEventID_EventNotFound(t *testing.T) {
 		},
 	}
 
-	svc := NewEventScheduleService(repo, evtRepo, logger())
+	svc := NewEventScheduleService(repo, evtRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 
 	_, err := svc.GetScheduleByEventID(1)
 	if err == nil || !errors.Is(err, e.ErrEventNotFound) {
@@ -90,9 +130,9 @@ func TestSchedule_Create_Success(t *testing.T) {
 		},
 	}
 
-	svc := NewEventScheduleService(repo, evtRepo, logger())
+	svc := NewEventScheduleService(repo, evtRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 
-	got, err := svc.CreateScheduleForEvent(2, dto.CreateScheduleRequest{ActivityName: "Talk", Speaker: "Alice", StartAt: now, EndAt: now.Add(time.Hour)})
+	got, err := svc.CreateScheduleForEvent(context.Background(), testAdmin, 2, dto.CreateScheduleRequest{ActivityName: "Talk", Speaker: "Alice", StartAt: now, EndAt: now.Add(time.Hour)})
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -123,8 +163,8 @@ func TestSchedule_Create_EventNotFound(t *testing.T) {
 		},
 	}
 
-	svc := NewEventScheduleService(repo, evtRepo, logger())
-	_, err := svc.CreateScheduleForEvent(2, dto.CreateScheduleRequest{ActivityName: "Talk", Speaker: "Alice", StartAt: now, EndAt: now.Add(time.Hour)})
+	svc := NewEventScheduleService(repo, evtRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	_, err := svc.CreateScheduleForEvent(context.Background(), testAdmin, 2, dto.CreateScheduleRequest{ActivityName: "Talk", Speaker: "Alice", StartAt: now, EndAt: now.Add(time.Hour)})
 	if err == nil || !errors.Is(err, e.ErrEventNotFound) {
 		t.Fatalf("expected ErrEventNotFound, got %v", err)
 	}
@@ -139,9 +179,156 @@ func TestSchedule_Create_BadTime(t *testing.T) {
 		},
 	}
 
-	svc := NewEventScheduleService(repo, evtRepo, logger())
-	_, err := svc.CreateScheduleForEvent(2, dto.CreateScheduleRequest{ActivityName: "Talk", Speaker: "Alice", StartAt: now, EndAt: now.Add(-time.Hour)})
+	svc := NewEventScheduleService(repo, evtRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	_, err := svc.CreateScheduleForEvent(context.Background(), testAdmin, 2, dto.CreateScheduleRequest{ActivityName: "Talk", Speaker: "Alice", StartAt: now, EndAt: now.Add(-time.Hour)})
 	if err == nil || !errors.Is(err, e.ErrNotCorrectScheduleTime) {
 		t.Fatalf("expected ErrNotCorrectScheduleTime, got %v", err)
 	}
 }
+
+func TestSchedule_Create_Forbidden(t *testing.T) {
+	repo := &mockEventScheduleRepo{CreateFunc: func(*models.EventSchedule) error {
+		t.Fatal("schedule must not be created")
+		return nil
+	}}
+	evtRepo := &mockEventRepo{
+		GetByIDFunc: func(id uint) (*models.Event, error) {
+			return &models.Event{Base: models.Base{ID: id}, UserID: 42}, nil
+		},
+	}
+
+	svc := NewEventScheduleService(repo, evtRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	now := time.Now()
+	_, err := svc.CreateScheduleForEvent(context.Background(), Actor{UserID: 7}, 2, dto.CreateScheduleRequest{ActivityName: "Talk", StartAt: now, EndAt: now.Add(time.Hour)})
+	if !errors.Is(err, e.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestSchedule_Create_Conflicts(t *testing.T) {
+	now := time.Now()
+	venueID := uint(3)
+	created := false
+	locked := false
+	repo := &mockEventScheduleRepo{
+		CreateFunc: func(*models.EventSchedule) error {
+			created = true
+			return nil
+		},
+		LockResourcesFunc: func(speaker string, venue *uint) error {
+			if speaker != "Alice" || venue == nil || *venue != venueID {
+				t.Fatalf("expected speaker and event venue to be locked, got %q %v", speaker, venue)
+			}
+			locked = true
+			return nil
+		},
+		OverlappingFunc: func(_ uint, speaker string, venue *uint, _, _ time.Time) ([]models.EventSchedule, error) {
+			if !locked {
+				t.Fatalf("conflicts must be checked under the speaker and venue lock")
+			}
+			if venue == nil || *venue != venueID {
+				t.Fatalf("expected event venue to be checked, got %v", venue)
+			}
+			return []models.EventSchedule{
+				{Base: models.Base{ID: 5}, EventID: 9, Speaker: " alice ", StartAt: now, EndAt: now.Add(time.Hour)},
+				{Base: models.Base{ID: 6}, EventID: 9, Speaker: "Bob", StartAt: now, EndAt: now.Add(time.Hour)},
+			}, nil
+		},
+	}
+	evtRepo := &mockEventRepo{GetByIDFunc: func(id uint) (*models.Event, error) {
+		return &models.Event{Base: models.Base{ID: id}, VenueID: &venueID}, nil
+	}}
+	svc := NewEventScheduleService(repo, evtRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	req := dto.CreateScheduleRequest{ActivityName: "Talk", Speaker: "Alice", StartAt: now, EndAt: now.Add(time.Hour)}
+
+	_, err := svc.CreateScheduleForEvent(context.Background(), testAdmin, 2, req)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, e.ErrScheduleConflict) {
+		t.Fatalf("expected ConflictError, got %v", err)
+	}
+	if created {
+		t.Fatalf("conflicting schedule must not be created")
+	}
+	if len(conflict.Conflicts) != 2 || conflict.Conflicts[0].Reason != dto.ConflictSpeaker || conflict.Conflicts[1].Reason != dto.ConflictVenue {
+		t.Fatalf("unexpected conflicts: %+v", conflict.Conflicts)
+	}
+
+	// с allow_conflicts активность сохраняется, пересечения — предупреждения
+	req.AllowConflicts = true
+	got, err := svc.CreateScheduleForEvent(context.Background(), testAdmin, 2, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !created || len(got.Warnings) != 2 {
+		t.Fatalf("expected created schedule with 2 warnings, got %+v", got.Warnings)
+	}
+}
+
+func TestSchedule_Update_PublishesChange(t *testing.T) {
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	var saved *models.EventSchedule
+	repo := &mockEventScheduleRepo{
+		GetByIDFunc: func(id uint) (*models.EventSchedule, error) {
+			return &models.EventSchedule{Base: models.Base{ID: id}, EventID: 2, ActivityName: "Talk", Speaker: "Alice", StartAt: start, EndAt: start.Add(time.Hour)}, nil
+		},
+		UpdateFunc: func(s *models.EventSchedule) error {
+			saved = s
+			return nil
+		},
+	}
+	evtRepo := &mockEventRepo{GetByIDFunc: func(id uint) (*models.Event, error) {
+		return &models.Event{Base: models.Base{ID: id}, Title: "Conf"}, nil
+	}}
+	var sent []kafka.ScheduleChangedMessage
+	producer := &mockProducer{SendScheduleFunc: func(_ context.Context, m kafka.ScheduleChangedMessage) error {
+		sent = append(sent, m)
+		return nil
+	}}
+	svc := NewEventScheduleService(repo, evtRepo, &mockVenueRepo{}, producer, &mockMembers{}, logger())
+
+	moved := start.Add(2 * time.Hour)
+	end := moved.Add(time.Hour)
+	if _, err := svc.UpdateSchedule(context.Background(), testAdmin, 2, 7, dto.UpdateScheduleRequest{StartAt: &moved, EndAt: &end}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved == nil || !saved.StartAt.Equal(moved) {
+		t.Fatalf("schedule was not updated: %+v", saved)
+	}
+	if len(sent) != 1 || sent[0].Action != kafka.ScheduleUpdated || sent[0].ScheduleID != 7 || sent[0].EventTitle != "Conf" ||
+		sent[0].PreviousStartAt == nil || !sent[0].PreviousStartAt.Equal(start) || !sent[0].StartAt.Equal(moved) {
+		t.Fatalf("unexpected messages: %+v", sent)
+	}
+
+	if err := svc.DeleteSchedule(context.Background(), testAdmin, 2, 7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sent) != 2 || sent[1].Action != kafka.ScheduleDeleted {
+		t.Fatalf("expected deleted message, got %+v", sent)
+	}
+
+	// активность другого мероприятия не найдена
+	if err := svc.DeleteSchedule(context.Background(), testAdmin, 3, 7); !errors.Is(err, e.ErrEventScheduleNotFound) {
+		t.Fatalf("expected ErrEventScheduleNotFound, got %v", err)
+	}
+}
+
+func TestSchedule_Update_SeriesOccurrenceRejected(t *testing.T) {
+	seriesID := uint(4)
+	repo := &mockEventScheduleRepo{
+		GetByIDFunc: func(id uint) (*models.EventSchedule, error) {
+			return &models.EventSchedule{Base: models.Base{ID: id}, EventID: 2, SeriesID: &seriesID}, nil
+		},
+		DeleteFunc: func(uint) error {
+			t.Fatal("series occurrence must not be deleted directly")
+			return nil
+		},
+	}
+	evtRepo := &mockEventRepo{GetByIDFunc: func(id uint) (*models.Event, error) {
+		return &models.Event{Base: models.Base{ID: id}}, nil
+	}}
+	svc := NewEventScheduleService(repo, evtRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+
+	if err := svc.DeleteSchedule(context.Background(), testAdmin, 2, 7); !errors.Is(err, e.ErrScheduleInSeries) {
+		t.Fatalf("expected ErrScheduleInSeries, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"event-service/internal/dto"
 	e "event-service/internal/errors"
 	"event-service/internal/kafka"
@@ -12,13 +13,13 @@ import (
 )
 
 type EventService interface {
-	CreateEvent(req dto.CreateEventRequest) (*models.Event, error)
+	CreateEvent(ctx context.Context, actor Actor, req dto.CreateEventRequest) (*models.Event, error)
 	GetEvent(id uint) (*models.Event, error)
-	DeleteEvent(id uint) error
-	UpdateEvent(req dto.UpdateEventRequest, id uint) (*models.Event, error)
-	ListEvents(query dto.EventListQuery) ([]models.Event, error)
-	PublishEvent(id uint) error
-	CancelEvent(id uint) error
+	DeleteEvent(ctx context.Context, actor Actor, id uint) error
+	UpdateEvent(ctx context.Context, actor Actor, req dto.UpdateEventRequest, id uint) (*models.Event, error)
+	ListEvents(query dto.EventListQuery) (*dto.EventListResponse, error)
+	PublishEvent(ctx context.Context, actor Actor, id uint) error
+	CancelEvent(ctx context.Context, actor Actor, id uint) error
 	GetEventsByUserID(userID uint) ([]models.Event, error)
 	SendEventReminders(ctx context.Context) error
 }
@@ -26,29 +27,39 @@ type EventService interface {
 type eventService struct {
 	eventRepo     repository.EventRepository
 	categoryRepo  repository.CategoryRepository
+	venueRepo     repository.VenueRepository
 	kafkaProducer kafka.EventProducer
+	members       MembershipResolver
 	logger        *slog.Logger
 }
 
 func NewEventService(
 	eventRepo repository.EventRepository,
 	categoryRepo repository.CategoryRepository,
+	venueRepo repository.VenueRepository,
 	kafkaProducer kafka.EventProducer,
+	members MembershipResolver,
 	logger *slog.Logger,
 ) EventService {
 	return &eventService{
 		eventRepo:     eventRepo,
 		categoryRepo:  categoryRepo,
+		venueRepo:     venueRepo,
 		kafkaProducer: kafkaProducer,
+		members:       members,
 		logger:        logger,
 	}
 }
 
-func (s *eventService) CreateEvent(req dto.CreateEventRequest) (*models.Event, error) {
+// CreateEvent создаёт черновик; автором становится actor.
+func (s *eventService) CreateEvent(ctx context.Context, actor Actor, req dto.CreateEventRequest) (*models.Event, error) {
 	s.logger.Debug("CreateEvent called",
 		slog.String("title", req.Title),
-		slog.Int("user_id", int(req.UserID)),
+		slog.Int("user_id", int(actor.UserID)),
 	)
+	if !actor.CanCreateEvents() {
+		return nil, e.ErrOrganizerRequired
+	}
 	if req.CategoryID != nil {
 		s.logger.Debug("CreateEvent has category", slog.Int("category_id", int(*req.CategoryID)))
 	}
@@ -59,13 +70,37 @@ func (s *eventService) CreateEvent(req dto.CreateEventRequest) (*models.Event, e
 			return nil, e.ErrCategoryNotFound
 		}
 	}
+	if req.OrganizationID != nil {
+		if err := authorizeOrganization(ctx, s.members, actor, *req.OrganizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	seats := req.Seats
+	if req.VenueID != nil {
+		venue, err := s.venueRepo.GetByID(*req.VenueID)
+		if err != nil {
+			return nil, err
+		}
+		// без явного числа мест мероприятие занимает всю площадку
+		if seats == nil {
+			capacity := venue.Capacity
+			seats = &capacity
+		}
+		if err := checkVenueSeats(venue, seats); err != nil {
+			return nil, err
+		}
+	}
 
 	event := &models.Event{
-		Title:      strings.TrimSpace(req.Title),
-		Status:     string(dto.Draft),
-		UserID:     req.UserID,
-		Seats:      req.Seats,
-		CategoryID: req.CategoryID,
+		Title:          strings.TrimSpace(req.Title),
+		Description:    strings.TrimSpace(req.Description),
+		Status:         string(dto.Draft),
+		UserID:         actor.UserID,
+		OrganizationID: req.OrganizationID,
+		Seats:          seats,
+		CategoryID:     req.CategoryID,
+		VenueID:        req.VenueID,
 	}
 
 	if err := s.eventRepo.Create(event); err != nil {
@@ -87,13 +122,16 @@ func (s *eventService) GetEvent(id uint) (*models.Event, error) {
 	return event, nil
 }
 
-func (s *eventService) DeleteEvent(id uint) error {
+func (s *eventService) DeleteEvent(ctx context.Context, actor Actor, id uint) error {
 	s.logger.Debug("DeleteEvent called", slog.Int("id", int(id)))
 	event, err := s.eventRepo.GetByID(id)
 	if err != nil {
 		s.logger.Warn("event not found for delete", "id", id)
 		return e.ErrEventNotFound
 	}
+	if err := authorizeManage(ctx, s.members, actor, event); err != nil {
+		return err
+	}
 
 	if event.Status != string(dto.Draft) {
 		s.logger.Warn("attempt to delete non-draft event", "id", id, "status", event.Status)
@@ -108,13 +146,16 @@ func (s *eventService) DeleteEvent(id uint) error {
 	return nil
 }
 
-func (s *eventService) UpdateEvent(req dto.UpdateEventRequest, id uint) (*models.Event, error) {
+func (s *eventService) UpdateEvent(ctx context.Context, actor Actor, req dto.UpdateEventRequest, id uint) (*models.Event, error) {
 	s.logger.Debug("UpdateEvent called", slog.Int("id", int(id)))
 	event, err := s.eventRepo.GetByID(id)
 	if err != nil {
 		s.logger.Warn("event not found for update", "id", id)
 		return nil, e.ErrEventNotFound
 	}
+	if err := authorizeManage(ctx, s.members, actor, event); err != nil {
+		return nil, err
+	}
 
 	if req.Title != nil {
 		trimmed := strings.TrimSpace(*req.Title)
@@ -124,6 +165,10 @@ func (s *eventService) UpdateEvent(req dto.UpdateEventRequest, id uint) (*models
 		event.Title = trimmed
 	}
 
+	if req.Description != nil {
+		event.Description = strings.TrimSpace(*req.Description)
+	}
+
 	if req.CategoryID != nil {
 		if _, err := s.categoryRepo.GetByID(*req.CategoryID); err != nil {
 			return nil, e.ErrCategoryNotFound
@@ -138,8 +183,24 @@ func (s *eventService) UpdateEvent(req dto.UpdateEventRequest, id uint) (*models
 		event.Seats = req.Seats
 	}
 
-	if req.UserID != nil {
-		event.UserID = *req.UserID
+	if req.VenueID != nil {
+		venue, err := s.venueRepo.GetByID(*req.VenueID)
+		if err != nil {
+			return nil, err
+		}
+		event.VenueID = req.VenueID
+		event.Venue = venue
+	}
+	if event.VenueID != nil && (req.Seats != nil || req.VenueID != nil) {
+		venue := event.Venue
+		if venue == nil {
+			if venue, err = s.venueRepo.GetByID(*event.VenueID); err != nil {
+				return nil, err
+			}
+		}
+		if err := checkVenueSeats(venue, event.Seats); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := s.eventRepo.Update(event); err != nil {
@@ -150,24 +211,81 @@ func (s *eventService) UpdateEvent(req dto.UpdateEventRequest, id uint) (*models
 	return event, nil
 }
 
-func (s *eventService) ListEvents(query dto.EventListQuery) ([]models.Event, error) {
-	s.logger.Debug("ListEvents called", slog.String("title", query.Title), slog.String("status", query.Status))
-	events, err := s.eventRepo.List(query)
+// ListEvents ищет мероприятия с постраничным выводом по курсору.
+func (s *eventService) ListEvents(query dto.EventListQuery) (*dto.EventListResponse, error) {
+	s.logger.Debug("ListEvents called", slog.String("q", query.Q), slog.String("status", query.Status))
+	query = normalizeListQuery(query)
+
+	if query.DateFrom != nil && query.DateTo != nil && !query.DateFrom.Before(*query.DateTo) {
+		return nil, e.ErrInvalidDateRange
+	}
+	if query.PriceMin != nil && query.PriceMax != nil && *query.PriceMin > *query.PriceMax {
+		return nil, e.ErrInvalidPriceRange
+	}
+	if !validLocation(query) {
+		return nil, e.ErrInvalidLocation
+	}
+
+	var after *dto.EventCursor
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil || cursor.SortBy != query.SortBy || cursor.SortOrder != query.SortOrder {
+			return nil, e.ErrInvalidCursor
+		}
+		after = cursor
+	}
+
+	page, err := s.eventRepo.List(query, after)
 	if err != nil {
-		s.logger.Error("failed to list events", "error", err)
+		if !errors.Is(err, e.ErrInvalidCursor) {
+			s.logger.Error("failed to list events", "error", err)
+		}
 		return nil, err
 	}
-	s.logger.Debug("ListEvents result", slog.Int("count", len(events)))
-	return events, nil
+
+	resp := &dto.EventListResponse{
+		Items:  page.Events,
+		Total:  page.Total,
+		Facets: page.Facets,
+	}
+	if resp.Items == nil {
+		resp.Items = []models.Event{}
+	}
+	if resp.Facets == nil {
+		resp.Facets = []dto.CategoryFacet{}
+	}
+	if page.HasMore && len(page.Events) > 0 {
+		resp.NextCursor = encodeCursor(query, page.Events[len(page.Events)-1])
+	}
+	s.logger.Debug("ListEvents result", slog.Int("count", len(page.Events)), slog.Int64("total", page.Total))
+	return resp, nil
 }
 
-func (s *eventService) PublishEvent(id uint) error {
+// validLocation — координаты заданы парой и в допустимых пределах.
+func validLocation(query dto.EventListQuery) bool {
+	if query.Lat == nil && query.Lng == nil {
+		return query.RadiusKm == nil
+	}
+	if query.Lat == nil || query.Lng == nil {
+		return false
+	}
+	// сравнения записаны так, чтобы NaN не проходил проверку
+	if !(*query.Lat >= -90 && *query.Lat <= 90 && *query.Lng >= -180 && *query.Lng <= 180) {
+		return false
+	}
+	return *query.RadiusKm > 0 && *query.RadiusKm <= dto.MaxRadiusKm
+}
+
+func (s *eventService) PublishEvent(ctx context.Context, actor Actor, id uint) error {
 	s.logger.Debug("PublishEvent called", slog.Int("id", int(id)))
 	event, err := s.eventRepo.GetByID(id)
 	if err != nil {
 		s.logger.Warn("event not found for publish", "id", id)
 		return e.ErrEventNotFound
 	}
+	if err := authorizeManage(ctx, s.members, actor, event); err != nil {
+		return err
+	}
 
 	if event.Status != string(dto.Draft) {
 		s.logger.Warn("attempt to publish non-draft event", "id", id, "status", event.Status)
@@ -184,11 +302,14 @@ func (s *eventService) PublishEvent(id uint) error {
 	return nil
 }
 
-func (s *eventService) CancelEvent(id uint) error {
+func (s *eventService) CancelEvent(ctx context.Context, actor Actor, id uint) error {
 	event, err := s.eventRepo.GetByID(id)
 	if err != nil {
 		return e.ErrEventNotFound
 	}
+	if err := authorizeManage(ctx, s.members, actor, event); err != nil {
+		return err
+	}
 
 	if event.Status != string(dto.Published) {
 		return e.ErrEventIsNotPublished
@@ -200,9 +321,8 @@ func (s *eventService) CancelEvent(id uint) error {
 		return err
 	}
 
-	ctx := context.Background()
 	if err := s.kafkaProducer.SendEventCancelled(ctx, id); err != nil {
-		s.logger.Error("failed to send event cancelled to kafka",
+		s.logger.ErrorContext(ctx, "failed to send event cancelled to kafka",
 			"error", err,
 			"event_id", id)
 	}
@@ -7,11 +7,14 @@ import (
 	e "event-service/internal/errors"
 	"event-service/internal/kafka"
 	"event-service/internal/models"
+	"event-service/internal/repository"
 	"io"
 	"log/slog"
 	"reflect"
 	"testing"
 	"time"
+
+	"user-service/userclient"
 )
 
 type mockEventRepo struct {
@@ -19,9 +22,10 @@ type mockEventRepo struct {
 	GetByIDFunc                  func(uint) (*models.Event, error)
 	UpdateFunc                   func(*models.Event) error
 	DeleteFunc                   func(uint) error
-	ListFunc                     func(dto.EventListQuery) ([]models.Event, error)
+	ListFunc                     func(dto.EventListQuery, *dto.EventCursor) (*repository.EventPage, error)
 	GetByUserIDFunc              func(uint) ([]models.Event, error)
 	GetEventStartingTomorrowFunc func() ([]models.Event, error)
+	AnonymizeUserFunc            func(uint) (int64, error)
 }
 
 func (m *mockEventRepo) Create(e *models.Event) error {
@@ -52,11 +56,11 @@ func (m *mockEventRepo) Delete(id uint) error {
 	return nil
 }
 
-func (m *mockEventRepo) List(q dto.EventListQuery) ([]models.Event, error) {
+func (m *mockEventRepo) List(q dto.EventListQuery, after *dto.EventCursor) (*repository.EventPage, error) {
 	if m.ListFunc != nil {
-		return m.ListFunc(q)
+		return m.ListFunc(q, after)
 	}
-	return nil, nil
+	return &repository.EventPage{}, nil
 }
 
 func (m *mockEventRepo) GetByUserID(uid uint) ([]models.Event, error) {
@@ -73,9 +77,17 @@ func (m *mockEventRepo) GetEventStartingTomorrow() ([]models.Event, error) {
 	return nil, nil
 }
 
+func (m *mockEventRepo) AnonymizeUser(uid uint) (int64, error) {
+	if m.AnonymizeUserFunc != nil {
+		return m.AnonymizeUserFunc(uid)
+	}
+	return 0, nil
+}
+
 type mockProducer struct {
 	SendCancelledFunc func(context.Context, uint) error
 	SendReminderFunc  func(context.Context, uint, string, time.Time) error
+	SendScheduleFunc  func(context.Context, kafka.ScheduleChangedMessage) error
 	CloseFunc         func() error
 }
 
@@ -93,6 +105,13 @@ func (m *mockProducer) SendEventReminder(ctx context.Context, eventID uint, titl
 	return nil
 }
 
+func (m *mockProducer) SendScheduleChanged(ctx context.Context, message kafka.ScheduleChangedMessage) error {
+	if m.SendScheduleFunc != nil {
+		return m.SendScheduleFunc(ctx, message)
+	}
+	return nil
+}
+
 func (m *mockProducer) Close() error {
 	if m.CloseFunc != nil {
 		return m.CloseFunc()
@@ -100,6 +119,21 @@ func (m *mockProducer) Close() error {
 	return nil
 }
 
+type mockMembers struct {
+	roles map[uint]userclient.OrgRole // userID -> роль в организации 1
+}
+
+func (m *mockMembers) GetMembership(_ context.Context, organizationID, userID uint) (*userclient.Membership, error) {
+	role, ok := m.roles[userID]
+	if !ok || organizationID != 1 {
+		return nil, userclient.ErrNotMember
+	}
+	return &userclient.Membership{OrganizationID: organizationID, UserID: userID, Role: role}, nil
+}
+
+// testAdmin проходит любые проверки доступа.
+var testAdmin = Actor{UserID: 100, Role: RoleAdmin}
+
 func logger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}))
 }
@@ -110,10 +144,11 @@ func TestEvent_Create_Success_NoCategory(t *testing.T) {
 		return nil
 	}}
 
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 
 	seats := 100
-	got, err := svc.CreateEvent(dto.CreateEventRequest{Title: " My Event ", UserID: 42, Seats: &seats})
+	organizer := Actor{UserID: 42, Role: RoleOrganizer}
+	got, err := svc.CreateEvent(context.Background(), organizer, dto.CreateEventRequest{Title: " My Event ", Seats: &seats})
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -149,9 +184,9 @@ func TestEvent_Create_CategoryNotFound(t *testing.T) {
 			return nil, errors.New("missing")
 		},
 	}
-	svc := NewEventService(repo, catRepo, &mockProducer{}, logger())
+	svc := NewEventService(repo, catRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 
-	_, err := svc.CreateEvent(dto.CreateEventRequest{Title: "Event", UserID: 1, CategoryID: &catID})
+	_, err := svc.CreateEvent(context.Background(), testAdmin, dto.CreateEventRequest{Title: "Event", CategoryID: &catID})
 	if err == nil || !errors.Is(err, e.ErrCategoryNotFound) {
 		t.Fatalf("expected ErrCategoryNotFound, got %v", err)
 	}
@@ -164,9 +199,9 @@ func TestEvent_Create_CreateError(t *testing.T) {
 			return boom
 		},
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 
-	_, err := svc.CreateEvent(dto.CreateEventRequest{Title: "Event", UserID: 1})
+	_, err := svc.CreateEvent(context.Background(), testAdmin, dto.CreateEventRequest{Title: "Event"})
 	if err == nil || !errors.Is(err, boom) {
 		t.Fatalf("expected create error, got %v", err)
 	}
@@ -177,7 +212,7 @@ func TestEvent_Get_Success(t *testing.T) {
 		return &models.Event{Base: models.Base{ID: id}, Title: "E"}, nil
 	}}
 
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 
 	got, err := svc.GetEvent(7)
 
@@ -198,7 +233,7 @@ func TestEvent_Get_NotFound(t *testing.T) {
 			return nil, errors.New("missing")
 		},
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 	got, err := svc.GetEvent(7)
 	if err == nil || !errors.Is(err, e.ErrEventNotFound) || got != nil {
 		t.Fatalf("expected ErrEventNotFound, got=%v", err)
@@ -213,8 +248,8 @@ func TestEvent_Delete_Success(t *testing.T) {
 		},
 		DeleteFunc: func(id uint) error { return nil },
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
-	if err := svc.DeleteEvent(3); err != nil {
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	if err := svc.DeleteEvent(context.Background(), testAdmin, 3); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
@@ -225,8 +260,8 @@ func TestEvent_Delete_NotFound(t *testing.T) {
 			return nil, errors.New("missing")
 		},
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
-	if err := svc.DeleteEvent(3); err == nil || !errors.Is(err, e.ErrEventNotFound) {
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	if err := svc.DeleteEvent(context.Background(), testAdmin, 3); err == nil || !errors.Is(err, e.ErrEventNotFound) {
 		t.Fatalf("expected ErrEventNotFound, got %v", err)
 	}
 }
@@ -235,8 +270,8 @@ func TestEvent_Delete_NotDraft(t *testing.T) {
 	repo := &mockEventRepo{GetByIDFunc: func(id uint) (*models.Event, error) {
 		return &models.Event{Base: models.Base{ID: id}, Status: string(dto.Published)}, nil
 	}}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
-	if err := svc.DeleteEvent(3); err == nil || !errors.Is(err, e.ErrEventIsNotDraft) {
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	if err := svc.DeleteEvent(context.Background(), testAdmin, 3); err == nil || !errors.Is(err, e.ErrEventIsNotDraft) {
 		t.Fatalf("expected ErrEventIsNotDraft, got %v", err)
 	}
 }
@@ -244,7 +279,6 @@ func TestEvent_Delete_NotDraft(t *testing.T) {
 func TestEvent_Update_Success(t *testing.T) {
 	name := " New Title "
 	seats := 55
-	uid := uint(9)
 	repo := &mockEventRepo{
 		GetByIDFunc: func(id uint) (*models.Event, error) {
 			return &models.Event{Base: models.Base{ID: id}, Title: "old", Seats: nil, UserID: 1}, nil
@@ -257,9 +291,9 @@ func TestEvent_Update_Success(t *testing.T) {
 			return &models.Category{Base: models.Base{ID: id}}, nil
 		},
 	}
-	svc := NewEventService(repo, catRepo, &mockProducer{}, logger())
+	svc := NewEventService(repo, catRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 
-	got, err := svc.UpdateEvent(dto.UpdateEventRequest{Title: &name, Seats: &seats, UserID: &uid, CategoryID: &catID}, 1)
+	got, err := svc.UpdateEvent(context.Background(), testAdmin, dto.UpdateEventRequest{Title: &name, Seats: &seats, CategoryID: &catID}, 1)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -272,8 +306,8 @@ func TestEvent_Update_Success(t *testing.T) {
 	if *got.Seats != 55 {
 		t.Fatalf("expected Seats=55, got %d", *got.Seats)
 	}
-	if got.UserID != 9 {
-		t.Fatalf("expected UserID=9, got %d", got.UserID)
+	if got.UserID != 1 {
+		t.Fatalf("owner must not change, got %d", got.UserID)
 	}
 	if got.CategoryID == nil {
 		t.Fatalf("expected CategoryID to be set")
@@ -289,8 +323,8 @@ func TestEvent_Update_NotFound(t *testing.T) {
 			return nil, errors.New("missing")
 		},
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
-	_, err := svc.UpdateEvent(dto.UpdateEventRequest{}, 1)
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	_, err := svc.UpdateEvent(context.Background(), testAdmin, dto.UpdateEventRequest{}, 1)
 	if err == nil || !errors.Is(err, e.ErrEventNotFound) {
 		t.Fatalf("expected ErrEventNotFound, got %v", err)
 	}
@@ -302,9 +336,9 @@ func TestEvent_Update_EmptyTitle(t *testing.T) {
 			return &models.Event{Base: models.Base{ID: id}, Title: "t"}, nil
 		},
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 	empty := "  "
-	_, err := svc.UpdateEvent(dto.UpdateEventRequest{Title: &empty}, 1)
+	_, err := svc.UpdateEvent(context.Background(), testAdmin, dto.UpdateEventRequest{Title: &empty}, 1)
 	if err == nil || !errors.Is(err, e.ErrEmptyTitle) {
 		t.Fatalf("expected ErrEmptyTitle, got %v", err)
 	}
@@ -316,9 +350,9 @@ func TestEvent_Update_BadSeats(t *testing.T) {
 			return &models.Event{Base: models.Base{ID: id}}, nil
 		},
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 	seats := -1
-	_, err := svc.UpdateEvent(dto.UpdateEventRequest{Seats: &seats}, 1)
+	_, err := svc.UpdateEvent(context.Background(), testAdmin, dto.UpdateEventRequest{Seats: &seats}, 1)
 	if err == nil || !errors.Is(err, e.ErrNotCorrectNum) {
 		t.Fatalf("expected ErrNotCorrectNum, got %v", err)
 	}
@@ -335,9 +369,9 @@ func TestEvent_Update_BadCategory(t *testing.T) {
 			return nil, errors.New("missing")
 		},
 	}
-	svc := NewEventService(repo, catRepo, &mockProducer{}, logger())
+	svc := NewEventService(repo, catRepo, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 	catID := uint(77)
-	_, err := svc.UpdateEvent(dto.UpdateEventRequest{CategoryID: &catID}, 1)
+	_, err := svc.UpdateEvent(context.Background(), testAdmin, dto.UpdateEventRequest{CategoryID: &catID}, 1)
 	if err == nil || !errors.Is(err, e.ErrCategoryNotFound) {
 		t.Fatalf("expected ErrCategoryNotFound, got %v", err)
 	}
@@ -345,17 +379,108 @@ func TestEvent_Update_BadCategory(t *testing.T) {
 
 func TestEvent_List_Success(t *testing.T) {
 	want := []models.Event{{Base: models.Base{ID: 1}}, {Base: models.Base{ID: 2}}}
-	repo := &mockEventRepo{ListFunc: func(q dto.EventListQuery) ([]models.Event, error) { return want, nil }}
+	repo := &mockEventRepo{ListFunc: func(q dto.EventListQuery, after *dto.EventCursor) (*repository.EventPage, error) {
+		if after != nil {
+			t.Fatalf("expected first page, got cursor %#v", after)
+		}
+		if q.SortBy != dto.SortByCreatedAt || q.SortOrder != "desc" || q.Limit != dto.DefaultLimit {
+			t.Fatalf("query not normalized: %#v", q)
+		}
+		return &repository.EventPage{Events: want, Total: 2}, nil
+	}}
 
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 
 	got, err := svc.ListEvents(dto.EventListQuery{})
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if !reflect.DeepEqual(got, want) {
-		t.Fatalf("unexpected list result: got=%#v want=%#v", got, want)
+	if !reflect.DeepEqual(got.Items, want) || got.Total != 2 {
+		t.Fatalf("unexpected list result: got=%#v want=%#v", got.Items, want)
+	}
+	if got.NextCursor != "" {
+		t.Fatalf("expected no next cursor on last page, got %q", got.NextCursor)
+	}
+	if got.Facets == nil {
+		t.Fatalf("expected empty facets, got nil")
+	}
+}
+
+func TestEvent_List_SearchDefaultsToRelevance(t *testing.T) {
+	var gotQuery dto.EventListQuery
+	repo := &mockEventRepo{ListFunc: func(q dto.EventListQuery, _ *dto.EventCursor) (*repository.EventPage, error) {
+		gotQuery = q
+		return &repository.EventPage{}, nil
+	}}
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+
+	if _, err := svc.ListEvents(dto.EventListQuery{Q: " go ", Limit: 1000}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery.Q != "go" || gotQuery.SortBy != dto.SortByRelevance || gotQuery.SortOrder != "desc" {
+		t.Fatalf("unexpected search query: %#v", gotQuery)
+	}
+	if gotQuery.Limit != dto.MaxLimit {
+		t.Fatalf("expected limit capped at %d, got %d", dto.MaxLimit, gotQuery.Limit)
+	}
+}
+
+func TestEvent_List_CursorRoundTrip(t *testing.T) {
+	created := time.Date(2026, 3, 1, 10, 0, 0, 123456000, time.UTC)
+	calls := 0
+	repo := &mockEventRepo{ListFunc: func(q dto.EventListQuery, after *dto.EventCursor) (*repository.EventPage, error) {
+		calls++
+		if calls == 1 {
+			return &repository.EventPage{
+				Events:  []models.Event{{Base: models.Base{ID: 7, CreatedAt: created}}},
+				Total:   2,
+				HasMore: true,
+			}, nil
+		}
+		want := &dto.EventCursor{SortBy: dto.SortByCreatedAt, SortOrder: "desc", Value: created.Format(time.RFC3339Nano), ID: 7}
+		if !reflect.DeepEqual(after, want) {
+			t.Fatalf("unexpected cursor: got=%#v want=%#v", after, want)
+		}
+		return &repository.EventPage{Total: 2}, nil
+	}}
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+
+	first, err := svc.ListEvents(dto.EventListQuery{Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.NextCursor == "" {
+		t.Fatalf("expected next cursor")
+	}
+
+	if _, err := svc.ListEvents(dto.EventListQuery{Limit: 1, Cursor: first.NextCursor}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// курсор от другой сортировки не принимается
+	_, err = svc.ListEvents(dto.EventListQuery{Limit: 1, Cursor: first.NextCursor, SortBy: dto.SortByTitle})
+	if !errors.Is(err, e.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestEvent_List_InvalidFilters(t *testing.T) {
+	svc := NewEventService(&mockEventRepo{}, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+
+	if _, err := svc.ListEvents(dto.EventListQuery{Cursor: "%%%"}); !errors.Is(err, e.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	if _, err := svc.ListEvents(dto.EventListQuery{DateFrom: &from, DateTo: &to}); !errors.Is(err, e.ErrInvalidDateRange) {
+		t.Fatalf("expected ErrInvalidDateRange, got %v", err)
+	}
+
+	minPrice, maxPrice := int64(500), int64(100)
+	if _, err := svc.ListEvents(dto.EventListQuery{PriceMin: &minPrice, PriceMax: &maxPrice}); !errors.Is(err, e.ErrInvalidPriceRange) {
+		t.Fatalf("expected ErrInvalidPriceRange, got %v", err)
 	}
 }
 
@@ -373,8 +498,8 @@ func TestEvent_Publish_Success(t *testing.T) {
 			return nil
 		},
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
-	if err := svc.PublishEvent(1); err != nil {
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	if err := svc.PublishEvent(context.Background(), testAdmin, 1); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if !updated {
@@ -388,8 +513,8 @@ func TestEvent_Publish_NotFound(t *testing.T) {
 			return nil, errors.New("missing")
 		},
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
-	if err := svc.PublishEvent(1); err == nil || !errors.Is(err, e.ErrEventNotFound) {
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	if err := svc.PublishEvent(context.Background(), testAdmin, 1); err == nil || !errors.Is(err, e.ErrEventNotFound) {
 		t.Fatalf("expected ErrEventNotFound, got %v", err)
 	}
 }
@@ -398,8 +523,8 @@ func TestEvent_Publish_NotDraft(t *testing.T) {
 	repo := &mockEventRepo{GetByIDFunc: func(id uint) (*models.Event, error) {
 		return &models.Event{Base: models.Base{ID: id}, Status: string(dto.Published)}, nil
 	}}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
-	if err := svc.PublishEvent(1); err == nil || !errors.Is(err, e.ErrEventIsNotDraft) {
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	if err := svc.PublishEvent(context.Background(), testAdmin, 1); err == nil || !errors.Is(err, e.ErrEventIsNotDraft) {
 		t.Fatalf("expected ErrEventIsNotDraft, got %v", err)
 	}
 }
@@ -421,8 +546,8 @@ func TestEvent_Cancel_Success_ProducerErrorIgnored(t *testing.T) {
 	prod := &mockProducer{SendCancelledFunc: func(ctx context.Context, id uint) error {
 		return errors.New("kafka down")
 	}}
-	svc := NewEventService(repo, &mockCategoryRepo{}, prod, logger())
-	if err := svc.CancelEvent(1); err != nil {
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, prod, &mockMembers{}, logger())
+	if err := svc.CancelEvent(context.Background(), testAdmin, 1); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if !updated {
@@ -436,8 +561,8 @@ func TestEvent_Cancel_NotFound(t *testing.T) {
 			return nil, errors.New("missing")
 		},
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
-	if err := svc.CancelEvent(1); err == nil || !errors.Is(err, e.ErrEventNotFound) {
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	if err := svc.CancelEvent(context.Background(), testAdmin, 1); err == nil || !errors.Is(err, e.ErrEventNotFound) {
 		t.Fatalf("expected ErrEventNotFound, got %v", err)
 	}
 }
@@ -446,8 +571,8 @@ func TestEvent_Cancel_NotPublished(t *testing.T) {
 	repo := &mockEventRepo{GetByIDFunc: func(id uint) (*models.Event, error) {
 		return &models.Event{Base: models.Base{ID: id}, Status: string(dto.Draft)}, nil
 	}}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
-	if err := svc.CancelEvent(1); err == nil || !errors.Is(err, e.ErrEventIsNotPublished) {
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	if err := svc.CancelEvent(context.Background(), testAdmin, 1); err == nil || !errors.Is(err, e.ErrEventIsNotPublished) {
 		t.Fatalf("expected ErrEventIsNotPublished, got %v", err)
 	}
 }
@@ -460,7 +585,7 @@ func TestEvent_GetByUserID_Success(t *testing.T) {
 		},
 	}
 
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 
 	got, err := svc.GetEventsByUserID(42)
 
@@ -486,7 +611,7 @@ func TestEvent_SendReminders(t *testing.T) {
 		return nil
 	}}
 
-	svc := NewEventService(repo, &mockCategoryRepo{}, prod, logger())
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, prod, &mockMembers{}, logger())
 
 	if err := svc.SendEventReminders(context.Background()); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -502,7 +627,7 @@ func TestEvent_SendReminders_RepoError(t *testing.T) {
 			return nil, errors.New("db")
 		},
 	}
-	svc := NewEventService(repo, &mockCategoryRepo{}, &mockProducer{}, logger())
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
 	if err := svc.SendEventReminders(context.Background()); err == nil {
 		t.Fatalf("expected error")
 	}
@@ -510,3 +635,69 @@ func TestEvent_SendReminders_RepoError(t *testing.T) {
 
 // Ensure mockProducer satisfies interface
 var _ kafka.EventProducer = (*mockProducer)(nil)
+
+func TestEvent_Access_PersonalEventOwnerOnly(t *testing.T) {
+	repo := &mockEventRepo{
+		GetByIDFunc: func(id uint) (*models.Event, error) {
+			return &models.Event{Base: models.Base{ID: id}, Status: string(dto.Draft), UserID: 7}, nil
+		},
+	}
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+	ctx := context.Background()
+
+	if err := svc.PublishEvent(ctx, Actor{UserID: 8, Role: "organizer"}, 1); !errors.Is(err, e.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+	if err := svc.PublishEvent(ctx, Actor{UserID: 7, Role: "organizer"}, 1); err != nil {
+		t.Fatalf("owner must publish: %v", err)
+	}
+}
+
+func TestEvent_Access_OrganizationRoles(t *testing.T) {
+	orgID := uint(1)
+	repo := &mockEventRepo{
+		GetByIDFunc: func(id uint) (*models.Event, error) {
+			return &models.Event{Base: models.Base{ID: id}, Status: string(dto.Published), UserID: 7, OrganizationID: &orgID}, nil
+		},
+	}
+	members := &mockMembers{roles: map[uint]userclient.OrgRole{
+		7: userclient.OrgRoleOwner,
+		8: userclient.OrgRoleManager,
+		9: userclient.OrgRoleCheckin,
+	}}
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, members, logger())
+	ctx := context.Background()
+
+	if err := svc.CancelEvent(ctx, Actor{UserID: 8}, 1); err != nil {
+		t.Fatalf("manager must cancel: %v", err)
+	}
+	if err := svc.CancelEvent(ctx, Actor{UserID: 9}, 1); !errors.Is(err, e.ErrForbidden) {
+		t.Fatalf("checkin staff must not cancel, got %v", err)
+	}
+	if err := svc.CancelEvent(ctx, Actor{UserID: 10}, 1); !errors.Is(err, e.ErrForbidden) {
+		t.Fatalf("outsider must not cancel, got %v", err)
+	}
+
+	// создавать от имени организации может только её менеджер или владелец
+	req := dto.CreateEventRequest{Title: "Org event", OrganizationID: &orgID}
+	if _, err := svc.CreateEvent(ctx, Actor{UserID: 9, Role: RoleOrganizer}, req); !errors.Is(err, e.ErrForbidden) {
+		t.Fatalf("checkin staff must not create, got %v", err)
+	}
+	got, err := svc.CreateEvent(ctx, Actor{UserID: 8, Role: RoleOrganizer}, req)
+	if err != nil {
+		t.Fatalf("manager must create: %v", err)
+	}
+	if got.OrganizationID == nil || *got.OrganizationID != orgID {
+		t.Fatalf("organization must be set: %+v", got)
+	}
+}
+
+func TestEvent_Create_RequiresOrganizer(t *testing.T) {
+	repo := &mockEventRepo{}
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+
+	_, err := svc.CreateEvent(context.Background(), Actor{UserID: 5, Role: "user"}, dto.CreateEventRequest{Title: "Event"})
+	if !errors.Is(err, e.ErrOrganizerRequired) {
+		t.Fatalf("expected ErrOrganizerRequired, got %v", err)
+	}
+}
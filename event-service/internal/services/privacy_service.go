@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"event-service/internal/dto"
+	"event-service/internal/repository"
+	"log/slog"
+)
+
+// PrivacyService выгружает и обезличивает мероприятия пользователя по запросу
+// из user-service (топики user.export_requested и user.deletion_requested).
+type PrivacyService interface {
+	Export(ctx context.Context, userID uint) (*dto.UserEventsExport, error)
+	Erase(ctx context.Context, userID uint) (*dto.UserEventsErasure, error)
+}
+
+type privacyService struct {
+	eventRepo repository.EventRepository
+	logger    *slog.Logger
+}
+
+func NewPrivacyService(eventRepo repository.EventRepository, logger *slog.Logger) PrivacyService {
+	return &privacyService{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+func (s *privacyService) Export(ctx context.Context, userID uint) (*dto.UserEventsExport, error) {
+	events, err := s.eventRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dto.UserEventsExport{Events: make([]dto.UserEventExport, 0, len(events))}
+	for _, ev := range events {
+		item := dto.UserEventExport{
+			ID:             ev.ID,
+			Title:          ev.Title,
+			Description:    ev.Description,
+			Status:         ev.Status,
+			Seats:          ev.Seats,
+			OrganizationID: ev.OrganizationID,
+			CategoryID:     ev.CategoryID,
+			CreatedAt:      ev.CreatedAt,
+			Schedule:       make([]dto.UserScheduleEntry, 0, len(ev.Schedule)),
+		}
+		for _, sc := range ev.Schedule {
+			item.Schedule = append(item.Schedule, dto.UserScheduleEntry{
+				ActivityName: sc.ActivityName,
+				Speaker:      sc.Speaker,
+				StartAt:      sc.StartAt,
+				EndAt:        sc.EndAt,
+			})
+		}
+		out.Events = append(out.Events, item)
+	}
+
+	s.logger.InfoContext(ctx, "user events exported", "user_id", userID, "events", len(out.Events))
+	return out, nil
+}
+
+// Erase отвязывает мероприятия от пользователя. Сами мероприятия остаются:
+// на них могут быть проданы билеты, а организационные продолжают жить
+// в организации. Повторный вызов ничего не меняет.
+func (s *privacyService) Erase(ctx context.Context, userID uint) (*dto.UserEventsErasure, error) {
+	n, err := s.eventRepo.AnonymizeUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "user events anonymized", "user_id", userID, "events", n)
+	return &dto.UserEventsErasure{EventsAnonymized: n}, nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"event-service/internal/models"
+	"testing"
+	"time"
+)
+
+func TestPrivacy_ExportAndErase(t *testing.T) {
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	var anonymized uint
+	repo := &mockEventRepo{
+		GetByUserIDFunc: func(uid uint) ([]models.Event, error) {
+			if uid != 42 {
+				return nil, nil
+			}
+			return []models.Event{{
+				Base:     models.Base{ID: 7},
+				Title:    "Meetup",
+				UserID:   42,
+				Schedule: []models.EventSchedule{{ActivityName: "Talk", StartAt: start, EndAt: start.Add(time.Hour)}},
+			}}, nil
+		},
+		AnonymizeUserFunc: func(uid uint) (int64, error) {
+			anonymized = uid
+			return 1, nil
+		},
+	}
+	svc := NewPrivacyService(repo, logger())
+
+	export, err := svc.Export(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(export.Events) != 1 || export.Events[0].ID != 7 {
+		t.Fatalf("unexpected export %+v", export)
+	}
+	if len(export.Events[0].Schedule) != 1 || !export.Events[0].Schedule[0].StartAt.Equal(start) {
+		t.Fatalf("expected schedule in export, got %+v", export.Events[0].Schedule)
+	}
+
+	erased, err := svc.Erase(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if anonymized != 42 || erased.EventsAnonymized != 1 {
+		t.Fatalf("expected events of user 42 anonymized, got %d / %+v", anonymized, erased)
+	}
+}
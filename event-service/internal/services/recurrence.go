@@ -0,0 +1,117 @@
+package services
+
+import (
+	e "event-service/internal/errors"
+	"event-service/internal/models"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// maxOccurrencesPerSync ограничивает число повторений за одну материализацию,
+// чтобы ошибочное правило не создало тысячи строк расписания.
+const maxOccurrencesPerSync = 500
+
+// parseRRule разбирает правило без DTSTART: начало серии хранится отдельно.
+// Чаще раза в день активности не повторяются: списки BYHOUR/BYMINUTE/BYSECOND
+// и BYSETPOS дали бы несколько повторений за день через DAILY.
+func parseRRule(raw string) (*rrule.ROption, error) {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "RRULE:")
+	if raw == "" || strings.ContainsAny(raw, "\r\n") {
+		return nil, e.ErrInvalidRRule
+	}
+
+	opt, err := rrule.StrToROption(raw)
+	if err != nil || !opt.Dtstart.IsZero() || opt.Freq > rrule.DAILY || opt.Count < 0 || opt.Interval < 0 {
+		return nil, e.ErrInvalidRRule
+	}
+	if len(opt.Byhour) > 1 || len(opt.Byminute) > 1 || len(opt.Bysecond) > 1 || len(opt.Bysetpos) > 0 {
+		return nil, e.ErrInvalidRRule
+	}
+	return opt, nil
+}
+
+// seriesRule строит правило серии в её часовом поясе: еженедельная встреча
+// в 19:00 остаётся в 19:00 по местному времени и после перехода на летнее.
+func seriesRule(series *models.ScheduleSeries) (*rrule.RRule, error) {
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, e.ErrInvalidTimezone
+	}
+	opt, err := parseRRule(series.RRule)
+	if err != nil {
+		return nil, err
+	}
+	opt.Dtstart = series.StartAt.In(loc)
+
+	r, err := rrule.NewRRule(*opt)
+	if err != nil {
+		return nil, e.ErrInvalidRRule
+	}
+	return r, nil
+}
+
+// occurrences возвращает начала повторений серии в [from, to] без EXDATE.
+func occurrences(series *models.ScheduleSeries, from, to time.Time) ([]time.Time, error) {
+	r, err := seriesRule(series)
+	if err != nil {
+		return nil, err
+	}
+
+	set := rrule.Set{}
+	set.RRule(r)
+	set.SetExDates(series.ExDates)
+
+	// итератор останавливается на лимите, не перебирая весь интервал
+	var starts []time.Time
+	next := set.Iterator()
+	for len(starts) < maxOccurrencesPerSync {
+		at, ok := next()
+		if !ok || at.After(to) {
+			break
+		}
+		if !at.Before(from) {
+			starts = append(starts, at)
+		}
+	}
+	return starts, nil
+}
+
+// splitRRule делит правило на две части по повторению at: первая
+// заканчивается перед ним, вторая начинается с него. COUNT переносится во
+// вторую часть за вычетом уже прошедших повторений.
+func splitRRule(series *models.ScheduleSeries, at time.Time) (before, after string, err error) {
+	r, err := seriesRule(series)
+	if err != nil {
+		return "", "", err
+	}
+	opt, err := parseRRule(series.RRule)
+	if err != nil {
+		return "", "", err
+	}
+
+	tail := *opt
+	if opt.Count > 0 {
+		passed := len(r.Between(series.StartAt, at, true)) - 1
+		tail.Count = opt.Count - passed
+	}
+
+	head := *opt
+	head.Count = 0
+	head.Until = at.Add(-time.Second).UTC()
+	return head.RRuleString(), tail.RRuleString(), nil
+}
+
+// isFirstOccurrence — до at у серии нет других повторений.
+func isFirstOccurrence(series *models.ScheduleSeries, at time.Time) (bool, error) {
+	r, err := seriesRule(series)
+	if err != nil {
+		return false, err
+	}
+
+	set := rrule.Set{}
+	set.RRule(r)
+	set.SetExDates(series.ExDates)
+	return set.Before(at, false).IsZero(), nil
+}
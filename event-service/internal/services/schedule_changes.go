@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"event-service/internal/dto"
+	e "event-service/internal/errors"
+	"event-service/internal/kafka"
+	"event-service/internal/models"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// ConflictError — активность пересекается с другими по спикеру или залу.
+// Conflicts отдаются клиенту вместе с 409.
+type ConflictError struct {
+	Conflicts []dto.ScheduleConflict
+}
+
+func (c *ConflictError) Error() string {
+	return e.ErrScheduleConflict.Error()
+}
+
+func (c *ConflictError) Unwrap() error {
+	return e.ErrScheduleConflict
+}
+
+// scheduleVenue — зал активности: без venue_id у строки — площадка мероприятия.
+func scheduleVenue(schedule *models.EventSchedule, event *models.Event) *uint {
+	if schedule.VenueID != nil {
+		return schedule.VenueID
+	}
+	return event.VenueID
+}
+
+// conflictRepository — поиск пересечений и блокировка спикера и зала; есть
+// и у репозитория активностей, и у репозитория серий.
+type conflictRepository interface {
+	Overlapping(excludeID uint, speaker string, venueID *uint, start, end time.Time) ([]models.EventSchedule, error)
+	LockResources(speaker string, venueID *uint) error
+}
+
+// findConflicts ищет пересечения активности с другими. Повторения одной
+// серии, пока их не правили по отдельности, друг другу не мешают: при
+// пересчёте серии строки временно стоят на старых местах.
+func findConflicts(repo conflictRepository, schedule *models.EventSchedule, event *models.Event) ([]dto.ScheduleConflict, error) {
+	rows, err := repo.Overlapping(schedule.ID, schedule.Speaker, scheduleVenue(schedule, event), schedule.StartAt, schedule.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]dto.ScheduleConflict, 0, len(rows))
+	for _, row := range rows {
+		if !schedule.Detached && !row.Detached && row.SeriesID != nil && schedule.SeriesID != nil &&
+			*row.SeriesID == *schedule.SeriesID {
+			continue
+		}
+		reason := dto.ConflictVenue
+		if strings.EqualFold(strings.TrimSpace(row.Speaker), strings.TrimSpace(schedule.Speaker)) {
+			reason = dto.ConflictSpeaker
+		}
+		conflicts = append(conflicts, dto.ScheduleConflict{
+			ScheduleID:   row.ID,
+			EventID:      row.EventID,
+			ActivityName: row.ActivityName,
+			Speaker:      row.Speaker,
+			StartAt:      row.StartAt,
+			EndAt:        row.EndAt,
+			Reason:       reason,
+		})
+	}
+	return conflicts, nil
+}
+
+// scheduleChanged собирает сообщение об изменении активности. previous —
+// строка до изменения, nil при создании и удалении.
+func scheduleChanged(event *models.Event, action, scope string, schedule, previous *models.EventSchedule) kafka.ScheduleChangedMessage {
+	message := kafka.ScheduleChangedMessage{
+		EventID:      event.ID,
+		EventTitle:   event.Title,
+		Action:       action,
+		Scope:        scope,
+		ScheduleID:   schedule.ID,
+		SeriesID:     schedule.SeriesID,
+		ActivityName: schedule.ActivityName,
+		Speaker:      schedule.Speaker,
+		VenueID:      schedule.VenueID,
+		StartAt:      schedule.StartAt,
+		EndAt:        schedule.EndAt,
+		OccurredAt:   time.Now(),
+	}
+	if schedule.ID != 0 && scope != kafka.ScheduleScopeSeries {
+		message.ScheduleIDs = []uint{schedule.ID}
+	}
+	if previous != nil {
+		message.PreviousStartAt = &previous.StartAt
+		message.PreviousEndAt = &previous.EndAt
+	}
+	return message
+}
+
+// publishScheduleChanged отправляет изменение расписания. Ошибка Kafka
+// только логируется: изменение уже сохранено.
+func publishScheduleChanged(ctx context.Context, producer kafka.EventProducer, logger *slog.Logger, message kafka.ScheduleChangedMessage) {
+	if err := producer.SendScheduleChanged(ctx, message); err != nil {
+		logger.ErrorContext(ctx, "failed to send schedule changed to kafka",
+			"error", err,
+			"event_id", message.EventID,
+			"schedule_id", message.ScheduleID)
+	}
+}
@@ -0,0 +1,645 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-service/internal/dto"
+	e "event-service/internal/errors"
+	"event-service/internal/kafka"
+	"event-service/internal/models"
+	"event-service/internal/repository"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultScheduleHorizon — на сколько вперёд создаются повторения серий.
+const DefaultScheduleHorizon = 90 * 24 * time.Hour
+
+type ScheduleSeriesService interface {
+	CreateSeries(ctx context.Context, actor Actor, eventID uint, req dto.CreateSeriesRequest) (*models.ScheduleSeries, error)
+	GetSeriesByEventID(eventID uint) ([]models.ScheduleSeries, error)
+	// DeleteSeries удаляет серию и её будущие повторения; прошедшие остаются в расписании.
+	DeleteSeries(ctx context.Context, actor Actor, eventID, seriesID uint) error
+	UpdateOccurrence(ctx context.Context, actor Actor, eventID, seriesID, scheduleID uint, scope string, req dto.UpdateOccurrenceRequest) (*models.EventSchedule, error)
+	DeleteOccurrence(ctx context.Context, actor Actor, eventID, seriesID, scheduleID uint, scope string) error
+	// MaterializeDue продлевает повторения всех серий до конца горизонта.
+	MaterializeDue(ctx context.Context) error
+}
+
+type scheduleSeriesService struct {
+	seriesRepo repository.ScheduleSeriesRepository
+	eventRepo  repository.EventRepository
+	venueRepo  repository.VenueRepository
+	producer   kafka.EventProducer
+	members    MembershipResolver
+	horizon    time.Duration
+	now        func() time.Time
+	logger     *slog.Logger
+}
+
+func NewScheduleSeriesService(
+	seriesRepo repository.ScheduleSeriesRepository,
+	eventRepo repository.EventRepository,
+	venueRepo repository.VenueRepository,
+	producer kafka.EventProducer,
+	members MembershipResolver,
+	horizon time.Duration,
+	logger *slog.Logger,
+) ScheduleSeriesService {
+	if horizon <= 0 {
+		horizon = DefaultScheduleHorizon
+	}
+	return &scheduleSeriesService{
+		seriesRepo: seriesRepo,
+		eventRepo:  eventRepo,
+		venueRepo:  venueRepo,
+		producer:   producer,
+		members:    members,
+		horizon:    horizon,
+		now:        time.Now,
+		logger:     logger,
+	}
+}
+
+func (s *scheduleSeriesService) CreateSeries(
+	ctx context.Context,
+	actor Actor,
+	eventID uint,
+	req dto.CreateSeriesRequest,
+) (*models.ScheduleSeries, error) {
+	s.logger.Debug("CreateSeries called", slog.Int("event_id", int(eventID)), slog.String("rrule", req.RRule))
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		s.logger.Warn("event not found when creating schedule series", "event_id", eventID)
+		return nil, e.ErrEventNotFound
+	}
+	if err := authorizeManage(ctx, s.members, actor, event); err != nil {
+		return nil, err
+	}
+
+	// часовой пояс серии: явный, затем площадки активности, затем мероприятия
+	timezone := strings.TrimSpace(req.Timezone)
+	if req.VenueID != nil {
+		venue, err := s.venueRepo.GetByID(*req.VenueID)
+		if err != nil {
+			return nil, err
+		}
+		if timezone == "" {
+			timezone = venue.Timezone
+		}
+	} else if timezone == "" && event.Venue != nil {
+		timezone = event.Venue.Timezone
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	opt, err := parseRRule(req.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	exdates := make([]time.Time, 0, len(req.ExDates))
+	for _, d := range req.ExDates {
+		exdates = append(exdates, d.UTC().Truncate(time.Second))
+	}
+
+	series := &models.ScheduleSeries{
+		EventID:         eventID,
+		ActivityName:    req.ActivityName,
+		Speaker:         req.Speaker,
+		VenueID:         req.VenueID,
+		StartAt:         req.StartAt,
+		DurationMinutes: req.DurationMinutes,
+		Timezone:        timezone,
+		RRule:           opt.RRuleString(),
+		ExDates:         exdates,
+		AllowConflicts:  req.AllowConflicts,
+	}
+
+	// правило без единого повторения (UNTIL раньше начала) не принимаем
+	r, err := seriesRule(series)
+	if err != nil {
+		return nil, err
+	}
+	if r.After(series.StartAt, true).IsZero() {
+		return nil, e.ErrInvalidRRule
+	}
+
+	err = s.seriesRepo.Transaction(func(repo repository.ScheduleSeriesRepository) error {
+		if err := repo.Create(series); err != nil {
+			return err
+		}
+		return s.sync(repo, event, series, series.StartAt, true)
+	})
+	if err != nil {
+		if !errors.As(err, new(*ConflictError)) {
+			s.logger.Error("failed to create schedule series", "error", err, "event_id", eventID)
+		}
+		return nil, err
+	}
+	publishScheduleChanged(ctx, s.producer, s.logger,
+		seriesChanged(scheduleChanged(event, kafka.ScheduleCreated, kafka.ScheduleScopeSeries, seriesSchedule(series), nil), series, nil))
+	return series, nil
+}
+
+func (s *scheduleSeriesService) GetSeriesByEventID(eventID uint) ([]models.ScheduleSeries, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, e.ErrEventNotFound
+	}
+	return s.seriesRepo.GetByEventID(eventID)
+}
+
+func (s *scheduleSeriesService) DeleteSeries(ctx context.Context, actor Actor, eventID, seriesID uint) error {
+	event, series, err := s.manageSeries(ctx, actor, eventID, seriesID)
+	if err != nil {
+		return err
+	}
+
+	err = s.seriesRepo.Transaction(func(repo repository.ScheduleSeriesRepository) error {
+		rows, err := repo.Occurrences(series.ID, s.now())
+		if err != nil {
+			return err
+		}
+		if err := repo.DeleteOccurrences(scheduleIDs(rows)); err != nil {
+			return err
+		}
+		return repo.Delete(series.ID)
+	})
+	if err != nil {
+		return err
+	}
+	publishScheduleChanged(ctx, s.producer, s.logger,
+		seriesChanged(scheduleChanged(event, kafka.ScheduleDeleted, kafka.ScheduleScopeSeries, seriesSchedule(series), nil), series, nil))
+	return nil
+}
+
+func (s *scheduleSeriesService) UpdateOccurrence(
+	ctx context.Context,
+	actor Actor,
+	eventID, seriesID, scheduleID uint,
+	scope string,
+	req dto.UpdateOccurrenceRequest,
+) (*models.EventSchedule, error) {
+	s.logger.Debug("UpdateOccurrence called", slog.Int("series_id", int(seriesID)), slog.Int("schedule_id", int(scheduleID)), slog.String("scope", scope))
+	event, series, err := s.manageSeries(ctx, actor, eventID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if req.RRule != nil && scope != dto.ScopeFollowing {
+		return nil, e.ErrRRuleChangeNeedsScope
+	}
+	if req.VenueID != nil {
+		if _, err := s.venueRepo.GetByID(*req.VenueID); err != nil {
+			return nil, err
+		}
+	}
+
+	var schedule, previous *models.EventSchedule
+	var affected []uint
+	err = s.seriesRepo.Transaction(func(repo repository.ScheduleSeriesRepository) error {
+		row, err := repo.GetOccurrence(series.ID, scheduleID)
+		if err != nil {
+			return err
+		}
+		before := *row
+		previous = &before
+		if scope == dto.ScopeFollowing {
+			// на новые повторения билетов ещё нет: хватает тех, что переезжают
+			rows, err := repo.Occurrences(series.ID, *row.OccurrenceAt)
+			if err != nil {
+				return err
+			}
+			affected = scheduleIDs(rows)
+			schedule, err = s.updateFollowing(repo, event, series, row, req)
+			return err
+		}
+		schedule, err = s.updateThis(repo, event, row, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	publishScheduleChanged(ctx, s.producer, s.logger,
+		seriesChanged(scheduleChanged(event, kafka.ScheduleUpdated, changeScope(scope), schedule, previous), series, affected))
+	return schedule, nil
+}
+
+func (s *scheduleSeriesService) DeleteOccurrence(
+	ctx context.Context,
+	actor Actor,
+	eventID, seriesID, scheduleID uint,
+	scope string,
+) error {
+	s.logger.Debug("DeleteOccurrence called", slog.Int("series_id", int(seriesID)), slog.Int("schedule_id", int(scheduleID)), slog.String("scope", scope))
+	event, series, err := s.manageSeries(ctx, actor, eventID, seriesID)
+	if err != nil {
+		return err
+	}
+
+	var deleted *models.EventSchedule
+	var affected []uint
+	err = s.seriesRepo.Transaction(func(repo repository.ScheduleSeriesRepository) error {
+		row, err := repo.GetOccurrence(series.ID, scheduleID)
+		if err != nil {
+			return err
+		}
+		deleted = row
+		at := *row.OccurrenceAt
+
+		if scope != dto.ScopeFollowing {
+			// EXDATE, чтобы материализация не вернула повторение
+			series.ExDates = append(series.ExDates, at.UTC().Truncate(time.Second))
+			if err := repo.Update(series); err != nil {
+				return err
+			}
+			return repo.DeleteOccurrences([]uint{row.ID})
+		}
+
+		first, err := isFirstOccurrence(series, at)
+		if err != nil {
+			return err
+		}
+		rows, err := repo.Occurrences(series.ID, at)
+		if err != nil {
+			return err
+		}
+		affected = scheduleIDs(rows)
+		if err := repo.DeleteOccurrences(affected); err != nil {
+			return err
+		}
+		if first {
+			return repo.Delete(series.ID)
+		}
+
+		head, _, err := splitRRule(series, at)
+		if err != nil {
+			return err
+		}
+		series.RRule = head
+		series.ExDates = exdatesBefore(series.ExDates, at)
+		return repo.Update(series)
+	})
+	if err != nil {
+		return err
+	}
+	publishScheduleChanged(ctx, s.producer, s.logger,
+		seriesChanged(scheduleChanged(event, kafka.ScheduleDeleted, changeScope(scope), deleted, nil), series, affected))
+	return nil
+}
+
+func (s *scheduleSeriesService) MaterializeDue(ctx context.Context) error {
+	now := s.now()
+	due, err := s.seriesRepo.GetDue(now.Add(s.horizon))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range due {
+		series := &due[i]
+		err := s.seriesRepo.Transaction(func(repo repository.ScheduleSeriesRepository) error {
+			event, err := s.eventRepo.GetByID(series.EventID)
+			if err != nil {
+				return err
+			}
+			return s.sync(repo, event, series, now, false)
+		})
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to materialize schedule series", "error", err, "series_id", series.ID)
+			errs = append(errs, err)
+		}
+	}
+	s.logger.InfoContext(ctx, "schedule series materialized", "count", len(due), "failed", len(errs))
+	return errors.Join(errs...)
+}
+
+// manageSeries загружает серию мероприятия и проверяет право её менять.
+func (s *scheduleSeriesService) manageSeries(ctx context.Context, actor Actor, eventID, seriesID uint) (*models.Event, *models.ScheduleSeries, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, nil, e.ErrEventNotFound
+	}
+	if err := authorizeManage(ctx, s.members, actor, event); err != nil {
+		return nil, nil, err
+	}
+
+	series, err := s.seriesRepo.GetByID(seriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if series.EventID != eventID {
+		return nil, nil, e.ErrScheduleSeriesNotFound
+	}
+	return event, series, nil
+}
+
+// sync приводит строки расписания серии начиная с from к правилу: создаёт
+// недостающие повторения до конца горизонта, обновляет не изменённые
+// отдельно и удаляет те, которых в правиле больше нет.
+//
+// Каждое записываемое повторение проверяется на пересечения по спикеру и
+// залу, как разовая активность. Без allow_conflicts в strict-режиме (правка
+// организатором) пересечение отклоняет всю запись; при продлении серии
+// по расписанию пересекающаяся дата пропускается и попадает в EXDATE.
+func (s *scheduleSeriesService) sync(
+	repo repository.ScheduleSeriesRepository,
+	event *models.Event,
+	series *models.ScheduleSeries,
+	from time.Time,
+	strict bool,
+) error {
+	until := s.now().Add(s.horizon)
+	if series.GeneratedUntil.After(until) {
+		until = series.GeneratedUntil
+	}
+	starts, err := occurrences(series, from, until)
+	if err != nil {
+		return err
+	}
+	if len(starts) == maxOccurrencesPerSync {
+		until = starts[len(starts)-1]
+	}
+
+	rows, err := repo.Occurrences(series.ID, from)
+	if err != nil {
+		return err
+	}
+	existing := make(map[int64]*models.EventSchedule, len(rows))
+	for i := range rows {
+		existing[rows[i].OccurrenceAt.Unix()] = &rows[i]
+	}
+
+	if err := repo.LockResources(series.Speaker, scheduleVenue(&models.EventSchedule{VenueID: series.VenueID}, event)); err != nil {
+		return err
+	}
+	var conflicts []dto.ScheduleConflict
+	for _, start := range starts {
+		row, ok := existing[start.Unix()]
+		delete(existing, start.Unix())
+		if ok && row.Detached {
+			continue
+		}
+		if !ok {
+			seriesID, at := series.ID, start
+			row = &models.EventSchedule{EventID: series.EventID, SeriesID: &seriesID, OccurrenceAt: &at}
+		}
+		if !applySeries(row, series, start) {
+			continue
+		}
+
+		found, err := findConflicts(repo, row, event)
+		if err != nil {
+			return err
+		}
+		if len(found) > 0 && !series.AllowConflicts {
+			if strict {
+				conflicts = append(conflicts, found...)
+				continue
+			}
+			if row.ID == 0 {
+				series.ExDates = append(series.ExDates, start.UTC().Truncate(time.Second))
+			}
+			s.logger.Warn("schedule series occurrence skipped on conflict",
+				"series_id", series.ID, "occurrence_at", start, "count", len(found))
+			continue
+		}
+		if err := repo.SaveOccurrence(row); err != nil {
+			return err
+		}
+	}
+	if len(conflicts) > 0 {
+		s.logger.Warn("schedule conflicts", "event_id", event.ID, "series_id", series.ID, "count", len(conflicts))
+		return &ConflictError{Conflicts: conflicts}
+	}
+
+	var orphans []uint
+	for _, row := range existing {
+		if !row.OccurrenceAt.After(until) {
+			orphans = append(orphans, row.ID)
+		}
+	}
+	slices.Sort(orphans)
+	if err := repo.DeleteOccurrences(orphans); err != nil {
+		return err
+	}
+
+	series.GeneratedUntil = until
+	return repo.Update(series)
+}
+
+// updateFollowing меняет повторение и все следующие. Если оно первое, правится
+// сама серия; иначе серия обрезается перед ним и дальше идёт новая. Строки
+// расписания переезжают в новую серию, сохраняя ID: на них ссылаются билеты;
+// билеты на прежнюю серию находятся через SplitFrom новой.
+func (s *scheduleSeriesService) updateFollowing(
+	repo repository.ScheduleSeriesRepository,
+	event *models.Event,
+	series *models.ScheduleSeries,
+	row *models.EventSchedule,
+	req dto.UpdateOccurrenceRequest,
+) (*models.EventSchedule, error) {
+	at := *row.OccurrenceAt
+	first, err := isFirstOccurrence(series, at)
+	if err != nil {
+		return nil, err
+	}
+
+	target := *series
+	target.StartAt = at
+	if req.StartAt != nil {
+		target.StartAt = *req.StartAt
+	}
+	delta := target.StartAt.Sub(at)
+	if req.ActivityName != nil {
+		target.ActivityName = *req.ActivityName
+	}
+	if req.Speaker != nil {
+		target.Speaker = *req.Speaker
+	}
+	if req.VenueID != nil {
+		target.VenueID = req.VenueID
+	}
+	if req.DurationMinutes != nil {
+		target.DurationMinutes = *req.DurationMinutes
+	}
+	target.ExDates = exdatesFrom(series.ExDates, at, delta)
+	target.AllowConflicts = req.AllowConflicts
+
+	if !first {
+		head, tail, err := splitRRule(series, at)
+		if err != nil {
+			return nil, err
+		}
+		target.Base = models.Base{}
+		target.SplitFrom = append(slices.Clone(series.SplitFrom), series.ID)
+		target.RRule = tail
+		target.GeneratedUntil = time.Time{}
+
+		series.RRule = head
+		series.ExDates = exdatesBefore(series.ExDates, at)
+		if err := repo.Update(series); err != nil {
+			return nil, err
+		}
+	}
+	if req.RRule != nil {
+		opt, err := parseRRule(*req.RRule)
+		if err != nil {
+			return nil, err
+		}
+		target.RRule = opt.RRuleString()
+	}
+	if !first {
+		if err := repo.Create(&target); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := repo.Occurrences(series.ID, at)
+	if err != nil {
+		return nil, err
+	}
+	// при сдвиге вперёд идём с конца, чтобы не столкнуться на уникальном индексе
+	if delta > 0 {
+		slices.Reverse(rows)
+	}
+	for i := range rows {
+		seriesID, shifted := target.ID, rows[i].OccurrenceAt.Add(delta)
+		rows[i].SeriesID = &seriesID
+		rows[i].OccurrenceAt = &shifted
+		rows[i].Detached = false
+		if err := repo.SaveOccurrence(&rows[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.sync(repo, event, &target, target.StartAt, true); err != nil {
+		return nil, err
+	}
+
+	// изменённое повторение должно остаться в серии: начало не по правилу — ошибка
+	schedule, err := repo.GetOccurrence(target.ID, row.ID)
+	if errors.Is(err, e.ErrOccurrenceNotFound) {
+		return nil, e.ErrInvalidRRule
+	}
+	return schedule, err
+}
+
+// updateThis меняет одно повторение; серия его больше не перезаписывает.
+func (s *scheduleSeriesService) updateThis(
+	repo repository.ScheduleSeriesRepository,
+	event *models.Event,
+	row *models.EventSchedule,
+	req dto.UpdateOccurrenceRequest,
+) (*models.EventSchedule, error) {
+	duration := row.EndAt.Sub(row.StartAt)
+	if req.DurationMinutes != nil {
+		duration = time.Duration(*req.DurationMinutes) * time.Minute
+	}
+	if req.ActivityName != nil {
+		row.ActivityName = *req.ActivityName
+	}
+	if req.Speaker != nil {
+		row.Speaker = *req.Speaker
+	}
+	if req.VenueID != nil {
+		row.VenueID = req.VenueID
+	}
+	if req.StartAt != nil {
+		row.StartAt = *req.StartAt
+	}
+	row.EndAt = row.StartAt.Add(duration)
+	row.Detached = true
+
+	if _, err := checkConflicts(repo, s.logger, row, event, req.AllowConflicts); err != nil {
+		return nil, err
+	}
+	if err := repo.SaveOccurrence(row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// applySeries переносит поля серии в строку повторения и сообщает, изменилось ли что-то.
+func applySeries(row *models.EventSchedule, series *models.ScheduleSeries, start time.Time) bool {
+	end := start.Add(series.Duration())
+	sameVenue := (row.VenueID == nil && series.VenueID == nil) ||
+		(row.VenueID != nil && series.VenueID != nil && *row.VenueID == *series.VenueID)
+	if row.ID != 0 && sameVenue && row.ActivityName == series.ActivityName && row.Speaker == series.Speaker &&
+		row.StartAt.Equal(start) && row.EndAt.Equal(end) {
+		return false
+	}
+
+	row.ActivityName = series.ActivityName
+	row.Speaker = series.Speaker
+	row.VenueID = series.VenueID
+	row.StartAt = start
+	row.EndAt = end
+	return true
+}
+
+func scheduleIDs(rows []models.EventSchedule) []uint {
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids
+}
+
+func exdatesBefore(dates []time.Time, at time.Time) []time.Time {
+	var before []time.Time
+	for _, d := range dates {
+		if d.Before(at) {
+			before = append(before, d)
+		}
+	}
+	return before
+}
+
+// exdatesFrom — исключения не раньше at, сдвинутые вместе с повторениями.
+func exdatesFrom(dates []time.Time, at time.Time, delta time.Duration) []time.Time {
+	var from []time.Time
+	for _, d := range dates {
+		if !d.Before(at) {
+			from = append(from, d.Add(delta))
+		}
+	}
+	return from
+}
+
+// seriesSchedule — первое повторение серии для сообщения об изменении.
+func seriesSchedule(series *models.ScheduleSeries) *models.EventSchedule {
+	return &models.EventSchedule{
+		EventID:      series.EventID,
+		SeriesID:     &series.ID,
+		ActivityName: series.ActivityName,
+		Speaker:      series.Speaker,
+		VenueID:      series.VenueID,
+		StartAt:      series.StartAt,
+		EndAt:        series.StartAt.Add(series.Duration()),
+	}
+}
+
+// seriesChanged добавляет в сообщение серии, билеты на которые действуют на
+// изменённые повторения: серию, те, от которых она отделена, и новую серию,
+// если правка её отделила. affected — изменённые повторения при правке
+// «это и следующие».
+func seriesChanged(message kafka.ScheduleChangedMessage, series *models.ScheduleSeries, affected []uint) kafka.ScheduleChangedMessage {
+	if len(affected) > 0 {
+		message.ScheduleIDs = affected
+	}
+	message.SeriesIDs = append(slices.Clone(series.SplitFrom), series.ID)
+	if message.SeriesID != nil && *message.SeriesID != series.ID {
+		message.SeriesIDs = append(message.SeriesIDs, *message.SeriesID)
+	}
+	return message
+}
+
+func changeScope(scope string) string {
+	if scope == dto.ScopeFollowing {
+		return kafka.ScheduleScopeFollowing
+	}
+	return kafka.ScheduleScopeThis
+}
@@ -0,0 +1,496 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-service/internal/dto"
+	e "event-service/internal/errors"
+	"event-service/internal/kafka"
+	"event-service/internal/models"
+	"event-service/internal/repository"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockSeriesRepo хранит серии и повторения в памяти: логика материализации
+// проверяется по итоговому расписанию, а не по последовательности вызовов.
+type mockSeriesRepo struct {
+	series map[uint]*models.ScheduleSeries
+	rows   map[uint]*models.EventSchedule
+	// booked — разовые активности, с которыми серия может пересечься
+	booked []models.EventSchedule
+	nextID uint
+}
+
+func newMockSeriesRepo() *mockSeriesRepo {
+	return &mockSeriesRepo{series: map[uint]*models.ScheduleSeries{}, rows: map[uint]*models.EventSchedule{}, nextID: 1}
+}
+
+func (m *mockSeriesRepo) Create(s *models.ScheduleSeries) error {
+	s.ID = m.nextID
+	m.nextID++
+	cp := *s
+	m.series[s.ID] = &cp
+	return nil
+}
+
+func (m *mockSeriesRepo) GetByID(id uint) (*models.ScheduleSeries, error) {
+	s, ok := m.series[id]
+	if !ok {
+		return nil, e.ErrScheduleSeriesNotFound
+	}
+	cp := *s
+	return &cp, nil
+}
+
+func (m *mockSeriesRepo) Update(s *models.ScheduleSeries) error {
+	cp := *s
+	m.series[s.ID] = &cp
+	return nil
+}
+
+func (m *mockSeriesRepo) Delete(id uint) error {
+	delete(m.series, id)
+	return nil
+}
+
+func (m *mockSeriesRepo) GetByEventID(eventID uint) ([]models.ScheduleSeries, error) {
+	var out []models.ScheduleSeries
+	for _, s := range m.series {
+		if s.EventID == eventID {
+			out = append(out, *s)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockSeriesRepo) GetDue(horizon time.Time) ([]models.ScheduleSeries, error) {
+	var out []models.ScheduleSeries
+	for _, s := range m.series {
+		if s.GeneratedUntil.Before(horizon) {
+			out = append(out, *s)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockSeriesRepo) Occurrences(seriesID uint, from time.Time) ([]models.EventSchedule, error) {
+	var out []models.EventSchedule
+	for _, row := range m.rows {
+		if *row.SeriesID == seriesID && !row.OccurrenceAt.Before(from) {
+			out = append(out, *row)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OccurrenceAt.Before(*out[j].OccurrenceAt) })
+	return out, nil
+}
+
+func (m *mockSeriesRepo) GetOccurrence(seriesID, scheduleID uint) (*models.EventSchedule, error) {
+	row, ok := m.rows[scheduleID]
+	if !ok || *row.SeriesID != seriesID {
+		return nil, e.ErrOccurrenceNotFound
+	}
+	cp := *row
+	return &cp, nil
+}
+
+func (m *mockSeriesRepo) SaveOccurrence(row *models.EventSchedule) error {
+	if row.ID == 0 {
+		row.ID = m.nextID
+		m.nextID++
+	}
+	// тот же уникальный индекс, что и в базе
+	for id, other := range m.rows {
+		if id != row.ID && *other.SeriesID == *row.SeriesID && other.OccurrenceAt.Equal(*row.OccurrenceAt) {
+			return errors.New("duplicate occurrence")
+		}
+	}
+	cp := *row
+	m.rows[row.ID] = &cp
+	return nil
+}
+
+func (m *mockSeriesRepo) DeleteOccurrences(ids []uint) error {
+	for _, id := range ids {
+		delete(m.rows, id)
+	}
+	return nil
+}
+
+func (m *mockSeriesRepo) Overlapping(excludeID uint, speaker string, venueID *uint, start, end time.Time) ([]models.EventSchedule, error) {
+	candidates := append([]models.EventSchedule(nil), m.booked...)
+	for _, row := range m.rows {
+		candidates = append(candidates, *row)
+	}
+
+	var out []models.EventSchedule
+	for _, row := range candidates {
+		if row.ID == excludeID || !row.StartAt.Before(end) || !row.EndAt.After(start) {
+			continue
+		}
+		sameSpeaker := speaker != "" && strings.EqualFold(strings.TrimSpace(row.Speaker), strings.TrimSpace(speaker))
+		sameVenue := venueID != nil && row.VenueID != nil && *row.VenueID == *venueID
+		if sameSpeaker || sameVenue {
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockSeriesRepo) LockResources(speaker string, venueID *uint) error {
+	return nil
+}
+
+func (m *mockSeriesRepo) Transaction(fn func(repo repository.ScheduleSeriesRepository) error) error {
+	return fn(m)
+}
+
+// starts — начала повторений серии по возрастанию в часовом поясе loc.
+func (m *mockSeriesRepo) starts(seriesID uint, loc *time.Location) []string {
+	rows, _ := m.Occurrences(seriesID, time.Time{})
+	out := make([]string, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, row.StartAt.In(loc).Format("2006-01-02 15:04"))
+	}
+	return out
+}
+
+func newSeriesService(repo *mockSeriesRepo, now time.Time, horizon time.Duration) *scheduleSeriesService {
+	events := &mockEventRepo{GetByIDFunc: func(id uint) (*models.Event, error) {
+		return &models.Event{Base: models.Base{ID: id}, UserID: 42}, nil
+	}}
+	svc := NewScheduleSeriesService(repo, events, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, horizon, testLogger()).(*scheduleSeriesService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func equalStarts(t *testing.T, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSeries_Create_WeeklyKeepsLocalTimeAcrossDST(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	repo := newMockSeriesRepo()
+	start := time.Date(2026, 3, 17, 19, 0, 0, 0, berlin)
+	svc := newSeriesService(repo, start, 30*24*time.Hour)
+
+	series, err := svc.CreateSeries(context.Background(), testAdmin, 1, dto.CreateSeriesRequest{
+		ActivityName:    "Meetup",
+		Speaker:         "Alice",
+		StartAt:         start,
+		DurationMinutes: 90,
+		Timezone:        "Europe/Berlin",
+		RRule:           "RRULE:FREQ=WEEKLY;BYDAY=TU",
+		ExDates:         []time.Time{time.Date(2026, 3, 31, 19, 0, 0, 0, berlin)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 29 марта Берлин переходит на летнее время, встреча остаётся в 19:00
+	equalStarts(t, repo.starts(series.ID, berlin), []string{
+		"2026-03-17 19:00", "2026-03-24 19:00", "2026-04-07 19:00", "2026-04-14 19:00",
+	})
+	if !series.GeneratedUntil.Equal(start.Add(30 * 24 * time.Hour)) {
+		t.Fatalf("unexpected generated_until: %v", series.GeneratedUntil)
+	}
+}
+
+func TestSeries_Create_InvalidRule(t *testing.T) {
+	svc := newSeriesService(newMockSeriesRepo(), time.Now(), 0)
+	start := time.Date(2026, 3, 17, 19, 0, 0, 0, time.UTC)
+
+	for _, rule := range []string{"", "FREQ=HOURLY", "DTSTART:20260101T000000Z\nFREQ=DAILY", "FREQ=DAILY;UNTIL=20250101T000000Z", "garbage",
+		"FREQ=DAILY;BYHOUR=9,10,11", "FREQ=DAILY;BYMINUTE=0,30", "FREQ=DAILY;BYSECOND=0,1", "FREQ=WEEKLY;BYDAY=MO,TU;BYSETPOS=1"} {
+		_, err := svc.CreateSeries(context.Background(), testAdmin, 1, dto.CreateSeriesRequest{
+			ActivityName: "Meetup", Speaker: "Alice", StartAt: start, DurationMinutes: 60, RRule: rule,
+		})
+		if !errors.Is(err, e.ErrInvalidRRule) {
+			t.Fatalf("expected ErrInvalidRRule for %q, got %v", rule, err)
+		}
+	}
+}
+
+func TestSeries_MaterializeDue_RollsHorizon(t *testing.T) {
+	repo := newMockSeriesRepo()
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	svc := newSeriesService(repo, start, 3*24*time.Hour)
+
+	series, err := svc.CreateSeries(context.Background(), testAdmin, 1, dto.CreateSeriesRequest{
+		ActivityName: "Standup", Speaker: "Alice", StartAt: start, DurationMinutes: 15, RRule: "FREQ=DAILY",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.rows) != 4 {
+		t.Fatalf("expected 4 occurrences, got %d", len(repo.rows))
+	}
+
+	svc.now = func() time.Time { return start.Add(2 * 24 * time.Hour) }
+	if err := svc.MaterializeDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	equalStarts(t, repo.starts(series.ID, time.UTC), []string{
+		"2026-01-01 10:00", "2026-01-02 10:00", "2026-01-03 10:00", "2026-01-04 10:00", "2026-01-05 10:00", "2026-01-06 10:00",
+	})
+}
+
+func TestSeries_UpdateThis_Detaches(t *testing.T) {
+	repo := newMockSeriesRepo()
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	svc := newSeriesService(repo, start, 2*24*time.Hour)
+	series, _ := svc.CreateSeries(context.Background(), testAdmin, 1, dto.CreateSeriesRequest{
+		ActivityName: "Standup", Speaker: "Alice", StartAt: start, DurationMinutes: 15, RRule: "FREQ=DAILY",
+	})
+	rows, _ := repo.Occurrences(series.ID, time.Time{})
+	second := rows[1]
+
+	moved := second.StartAt.Add(2 * time.Hour)
+	speaker := "Bob"
+	got, err := svc.UpdateOccurrence(context.Background(), testAdmin, 1, series.ID, second.ID, dto.ScopeThis,
+		dto.UpdateOccurrenceRequest{Speaker: &speaker, StartAt: &moved})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Detached || got.Speaker != "Bob" || !got.EndAt.Equal(moved.Add(15*time.Minute)) || !got.OccurrenceAt.Equal(second.StartAt) {
+		t.Fatalf("unexpected occurrence: %#v", got)
+	}
+
+	// материализация не перезаписывает изменённое повторение
+	if err := svc.MaterializeDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.rows[second.ID].Speaker != "Bob" {
+		t.Fatalf("detached occurrence was overwritten")
+	}
+
+	rule := "FREQ=WEEKLY"
+	_, err = svc.UpdateOccurrence(context.Background(), testAdmin, 1, series.ID, second.ID, dto.ScopeThis, dto.UpdateOccurrenceRequest{RRule: &rule})
+	if !errors.Is(err, e.ErrRRuleChangeNeedsScope) {
+		t.Fatalf("expected ErrRRuleChangeNeedsScope, got %v", err)
+	}
+}
+
+func TestSeries_UpdateFollowing_SplitsSeries(t *testing.T) {
+	repo := newMockSeriesRepo()
+	start := time.Date(2026, 1, 5, 18, 0, 0, 0, time.UTC) // понедельник
+	svc := newSeriesService(repo, start, 28*24*time.Hour)
+	series, _ := svc.CreateSeries(context.Background(), testAdmin, 1, dto.CreateSeriesRequest{
+		ActivityName: "Meetup", Speaker: "Alice", StartAt: start, DurationMinutes: 60, RRule: "FREQ=WEEKLY;COUNT=4",
+	})
+	rows, _ := repo.Occurrences(series.ID, time.Time{})
+	third := rows[2]
+
+	// с третьей встречи — по средам в 19:00
+	moved := third.StartAt.Add(48*time.Hour + time.Hour)
+	got, err := svc.UpdateOccurrence(context.Background(), testAdmin, 1, series.ID, third.ID, dto.ScopeFollowing,
+		dto.UpdateOccurrenceRequest{StartAt: &moved})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != third.ID || *got.SeriesID == series.ID {
+		t.Fatalf("occurrence must keep id and move to the new series: %#v", got)
+	}
+
+	equalStarts(t, repo.starts(series.ID, time.UTC), []string{"2026-01-05 18:00", "2026-01-12 18:00"})
+	// COUNT=4 делится: в новой серии остаются две встречи
+	equalStarts(t, repo.starts(*got.SeriesID, time.UTC), []string{"2026-01-21 19:00", "2026-01-28 19:00"})
+	if repo.rows[rows[3].ID] == nil {
+		t.Fatalf("following occurrence must keep its id")
+	}
+}
+
+func TestSeries_UpdateFollowing_KeepsSeriesTickets(t *testing.T) {
+	repo := newMockSeriesRepo()
+	start := time.Date(2026, 1, 5, 18, 0, 0, 0, time.UTC)
+	svc := newSeriesService(repo, start, 28*24*time.Hour)
+	var sent []kafka.ScheduleChangedMessage
+	svc.producer = &mockProducer{SendScheduleFunc: func(_ context.Context, m kafka.ScheduleChangedMessage) error {
+		sent = append(sent, m)
+		return nil
+	}}
+	series, _ := svc.CreateSeries(context.Background(), testAdmin, 1, dto.CreateSeriesRequest{
+		ActivityName: "Meetup", Speaker: "Alice", StartAt: start, DurationMinutes: 60, RRule: "FREQ=WEEKLY;COUNT=4",
+	})
+	rows, _ := repo.Occurrences(series.ID, time.Time{})
+
+	// дважды делим серию: билет на исходную должен действовать и на хвост
+	moved := rows[1].StartAt.Add(time.Hour)
+	split, err := svc.UpdateOccurrence(context.Background(), testAdmin, 1, series.ID, rows[1].ID, dto.ScopeFollowing,
+		dto.UpdateOccurrenceRequest{StartAt: &moved})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := sent[len(sent)-1].ScheduleIDs; len(got) != 3 || got[0] != rows[1].ID || got[2] != rows[3].ID {
+		t.Fatalf("message must list every moved occurrence: %v", got)
+	}
+	moved = rows[2].StartAt.Add(2 * time.Hour)
+	last, err := svc.UpdateOccurrence(context.Background(), testAdmin, 1, *split.SeriesID, rows[2].ID, dto.ScopeFollowing,
+		dto.UpdateOccurrenceRequest{StartAt: &moved})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := repo.series[*last.SeriesID].SplitFrom; len(got) != 2 || got[0] != series.ID || got[1] != *split.SeriesID {
+		t.Fatalf("unexpected split_from: %v", got)
+	}
+
+	speaker := "Bob"
+	if _, err := svc.UpdateOccurrence(context.Background(), testAdmin, 1, *last.SeriesID, rows[3].ID, dto.ScopeThis,
+		dto.UpdateOccurrenceRequest{Speaker: &speaker}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg := sent[len(sent)-1]
+	if len(msg.SeriesIDs) != 3 || msg.SeriesIDs[0] != series.ID || msg.SeriesIDs[2] != *last.SeriesID {
+		t.Fatalf("message must list the original series: %v", msg.SeriesIDs)
+	}
+}
+
+func TestSeries_Create_ConflictsWithBooking(t *testing.T) {
+	repo := newMockSeriesRepo()
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	repo.booked = []models.EventSchedule{{
+		Base: models.Base{ID: 900}, EventID: 2, ActivityName: "Keynote", Speaker: "alice",
+		StartAt: start.Add(24*time.Hour + 5*time.Minute), EndAt: start.Add(25 * time.Hour),
+	}}
+	svc := newSeriesService(repo, start, 3*24*time.Hour)
+	req := dto.CreateSeriesRequest{
+		ActivityName: "Standup", Speaker: "Alice", StartAt: start, DurationMinutes: 15, RRule: "FREQ=DAILY",
+	}
+
+	_, err := svc.CreateSeries(context.Background(), testAdmin, 1, req)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || len(conflict.Conflicts) != 1 || conflict.Conflicts[0].ScheduleID != 900 {
+		t.Fatalf("expected conflict with the booking, got %v", err)
+	}
+
+	repo = newMockSeriesRepo()
+	repo.booked = []models.EventSchedule{{
+		Base: models.Base{ID: 900}, EventID: 2, ActivityName: "Keynote", Speaker: "alice",
+		StartAt: start.Add(24*time.Hour + 5*time.Minute), EndAt: start.Add(25 * time.Hour),
+	}}
+	svc = newSeriesService(repo, start, 3*24*time.Hour)
+	req.AllowConflicts = true
+	series, err := svc.CreateSeries(context.Background(), testAdmin, 1, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	equalStarts(t, repo.starts(series.ID, time.UTC), []string{
+		"2026-01-01 10:00", "2026-01-02 10:00", "2026-01-03 10:00", "2026-01-04 10:00",
+	})
+}
+
+func TestSeries_MaterializeDue_SkipsConflicts(t *testing.T) {
+	repo := newMockSeriesRepo()
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	svc := newSeriesService(repo, start, 2*24*time.Hour)
+	series, _ := svc.CreateSeries(context.Background(), testAdmin, 1, dto.CreateSeriesRequest{
+		ActivityName: "Standup", Speaker: "Alice", StartAt: start, DurationMinutes: 15, RRule: "FREQ=DAILY",
+	})
+
+	// после создания серии спикера заняли на 4 января
+	repo.booked = []models.EventSchedule{{
+		Base: models.Base{ID: 900}, EventID: 2, Speaker: "Alice",
+		StartAt: start.Add(3 * 24 * time.Hour), EndAt: start.Add(3*24*time.Hour + time.Hour),
+	}}
+	svc.now = func() time.Time { return start.Add(3 * 24 * time.Hour) }
+	if err := svc.MaterializeDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	equalStarts(t, repo.starts(series.ID, time.UTC), []string{
+		"2026-01-01 10:00", "2026-01-02 10:00", "2026-01-03 10:00", "2026-01-05 10:00", "2026-01-06 10:00",
+	})
+	if got := repo.series[series.ID].ExDates; len(got) != 1 || !got[0].Equal(start.Add(3*24*time.Hour)) {
+		t.Fatalf("conflicting date must become an exdate: %v", got)
+	}
+}
+
+func TestSeries_UpdateThis_Conflict(t *testing.T) {
+	repo := newMockSeriesRepo()
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	svc := newSeriesService(repo, start, 2*24*time.Hour)
+	series, _ := svc.CreateSeries(context.Background(), testAdmin, 1, dto.CreateSeriesRequest{
+		ActivityName: "Standup", Speaker: "Alice", StartAt: start, DurationMinutes: 15, RRule: "FREQ=DAILY",
+	})
+	repo.booked = []models.EventSchedule{{
+		Base: models.Base{ID: 900}, EventID: 2, Speaker: "Alice",
+		StartAt: start.Add(26 * time.Hour), EndAt: start.Add(27 * time.Hour),
+	}}
+	rows, _ := repo.Occurrences(series.ID, time.Time{})
+	second := rows[1]
+
+	moved := second.StartAt.Add(2*time.Hour + 30*time.Minute)
+	_, err := svc.UpdateOccurrence(context.Background(), testAdmin, 1, series.ID, second.ID, dto.ScopeThis,
+		dto.UpdateOccurrenceRequest{StartAt: &moved})
+	if !errors.Is(err, e.ErrScheduleConflict) {
+		t.Fatalf("expected ErrScheduleConflict, got %v", err)
+	}
+	if !repo.rows[second.ID].StartAt.Equal(second.StartAt) {
+		t.Fatalf("conflicting occurrence must not be saved")
+	}
+
+	// соседнее повторение той же серии после отдельной правки — тоже пересечение
+	moved = rows[2].StartAt.Add(5 * time.Minute)
+	_, err = svc.UpdateOccurrence(context.Background(), testAdmin, 1, series.ID, second.ID, dto.ScopeThis,
+		dto.UpdateOccurrenceRequest{StartAt: &moved})
+	if !errors.Is(err, e.ErrScheduleConflict) {
+		t.Fatalf("expected ErrScheduleConflict for a sibling occurrence, got %v", err)
+	}
+
+	moved = second.StartAt.Add(2*time.Hour + 30*time.Minute)
+	got, err := svc.UpdateOccurrence(context.Background(), testAdmin, 1, series.ID, second.ID, dto.ScopeThis,
+		dto.UpdateOccurrenceRequest{StartAt: &moved, AllowConflicts: true})
+	if err != nil || !got.StartAt.Equal(moved) {
+		t.Fatalf("allow_conflicts must save the occurrence: %v", err)
+	}
+}
+
+func TestSeries_DeleteOccurrence(t *testing.T) {
+	repo := newMockSeriesRepo()
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	svc := newSeriesService(repo, start, 4*24*time.Hour)
+	series, _ := svc.CreateSeries(context.Background(), testAdmin, 1, dto.CreateSeriesRequest{
+		ActivityName: "Standup", Speaker: "Alice", StartAt: start, DurationMinutes: 15, RRule: "FREQ=DAILY",
+	})
+	rows, _ := repo.Occurrences(series.ID, time.Time{})
+
+	if err := svc.DeleteOccurrence(context.Background(), testAdmin, 1, series.ID, rows[1].ID, dto.ScopeThis); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// EXDATE не даёт материализации вернуть удалённое повторение
+	if err := svc.MaterializeDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	equalStarts(t, repo.starts(series.ID, time.UTC), []string{
+		"2026-01-01 10:00", "2026-01-03 10:00", "2026-01-04 10:00", "2026-01-05 10:00",
+	})
+
+	if err := svc.DeleteOccurrence(context.Background(), testAdmin, 1, series.ID, rows[3].ID, dto.ScopeFollowing); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.MaterializeDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	equalStarts(t, repo.starts(series.ID, time.UTC), []string{"2026-01-01 10:00", "2026-01-03 10:00"})
+}
+
+func TestSeries_Forbidden(t *testing.T) {
+	repo := newMockSeriesRepo()
+	svc := newSeriesService(repo, time.Now(), 0)
+
+	_, err := svc.CreateSeries(context.Background(), Actor{UserID: 7, Role: RoleOrganizer}, 1, dto.CreateSeriesRequest{
+		ActivityName: "Meetup", Speaker: "Alice", StartAt: time.Now(), DurationMinutes: 60, RRule: "FREQ=WEEKLY",
+	})
+	if !errors.Is(err, e.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
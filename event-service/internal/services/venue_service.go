@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"event-service/internal/dto"
+	e "event-service/internal/errors"
+	"event-service/internal/models"
+	"event-service/internal/repository"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+type VenueService interface {
+	CreateVenue(ctx context.Context, actor Actor, req dto.CreateVenueRequest) (*models.Venue, error)
+	GetVenue(id uint) (*models.Venue, error)
+	ListVenues() ([]models.Venue, error)
+	UpdateVenue(ctx context.Context, actor Actor, id uint, req dto.UpdateVenueRequest) (*models.Venue, error)
+	DeleteVenue(ctx context.Context, actor Actor, id uint) error
+}
+
+type venueService struct {
+	venueRepo repository.VenueRepository
+	logger    *slog.Logger
+}
+
+func NewVenueService(venueRepo repository.VenueRepository, logger *slog.Logger) VenueService {
+	return &venueService{venueRepo: venueRepo, logger: logger}
+}
+
+// CreateVenue заводит площадку. Доступно тем, кто может создавать мероприятия.
+func (s *venueService) CreateVenue(ctx context.Context, actor Actor, req dto.CreateVenueRequest) (*models.Venue, error) {
+	s.logger.Debug("CreateVenue called", slog.String("name", req.Name), slog.Int("user_id", int(actor.UserID)))
+	if !actor.CanCreateEvents() {
+		return nil, e.ErrOrganizerRequired
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, e.ErrEmptyName
+	}
+	timezone := strings.TrimSpace(req.Timezone)
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		return nil, e.ErrInvalidTimezone
+	}
+
+	venue := &models.Venue{
+		Name:      name,
+		Address:   strings.TrimSpace(req.Address),
+		Latitude:  *req.Latitude,
+		Longitude: *req.Longitude,
+		Capacity:  req.Capacity,
+		Timezone:  timezone,
+		UserID:    actor.UserID,
+	}
+
+	if err := s.venueRepo.Create(venue); err != nil {
+		s.logger.ErrorContext(ctx, "failed to create venue", "error", err, "name", venue.Name)
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "venue created", slog.Int("id", int(venue.ID)), slog.String("name", venue.Name))
+	return venue, nil
+}
+
+func (s *venueService) GetVenue(id uint) (*models.Venue, error) {
+	s.logger.Debug("GetVenue called", slog.Int("id", int(id)))
+	return s.venueRepo.GetByID(id)
+}
+
+func (s *venueService) ListVenues() ([]models.Venue, error) {
+	return s.venueRepo.List()
+}
+
+// UpdateVenue меняет площадку. Вместимость нельзя опустить ниже Seats
+// мероприятий, которые уже на ней проходят.
+func (s *venueService) UpdateVenue(ctx context.Context, actor Actor, id uint, req dto.UpdateVenueRequest) (*models.Venue, error) {
+	s.logger.Debug("UpdateVenue called", slog.Int("id", int(id)))
+	venue, err := s.venueRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.IsAdmin() && venue.UserID != actor.UserID {
+		return nil, e.ErrForbidden
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, e.ErrEmptyName
+		}
+		venue.Name = name
+	}
+	if req.Address != nil {
+		venue.Address = strings.TrimSpace(*req.Address)
+	}
+	if req.Latitude != nil {
+		venue.Latitude = *req.Latitude
+	}
+	if req.Longitude != nil {
+		venue.Longitude = *req.Longitude
+	}
+	if req.Timezone != nil {
+		timezone := strings.TrimSpace(*req.Timezone)
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+			return nil, e.ErrInvalidTimezone
+		}
+		venue.Timezone = timezone
+	}
+	if req.Capacity != nil {
+		if *req.Capacity < 1 {
+			return nil, e.ErrNotCorrectNum
+		}
+		if *req.Capacity < venue.Capacity {
+			maxSeats, err := s.venueRepo.MaxEventSeats(id)
+			if err != nil {
+				return nil, err
+			}
+			if maxSeats > *req.Capacity {
+				return nil, e.ErrVenueCapacityTooLow
+			}
+		}
+		venue.Capacity = *req.Capacity
+	}
+
+	if err := s.venueRepo.Update(venue); err != nil {
+		s.logger.ErrorContext(ctx, "failed to update venue", "error", err, "id", id)
+		return nil, err
+	}
+	return venue, nil
+}
+
+// DeleteVenue удаляет площадку, на которой нет мероприятий и активностей.
+func (s *venueService) DeleteVenue(ctx context.Context, actor Actor, id uint) error {
+	s.logger.Debug("DeleteVenue called", slog.Int("id", int(id)))
+	venue, err := s.venueRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if !actor.IsAdmin() && venue.UserID != actor.UserID {
+		return e.ErrForbidden
+	}
+
+	used, err := s.venueRepo.CountEvents(id)
+	if err != nil {
+		return err
+	}
+	if used > 0 {
+		return e.ErrVenueInUse
+	}
+
+	if err := s.venueRepo.Delete(id); err != nil {
+		s.logger.ErrorContext(ctx, "failed to delete venue", "error", err, "id", id)
+		return err
+	}
+	s.logger.InfoContext(ctx, "venue deleted", slog.Int("id", int(id)))
+	return nil
+}
+
+// checkVenueSeats проверяет, что места мероприятия помещаются на площадке.
+func checkVenueSeats(venue *models.Venue, seats *int) error {
+	if venue != nil && seats != nil && *seats > venue.Capacity {
+		return e.ErrSeatsExceedCapacity
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-service/internal/dto"
+	e "event-service/internal/errors"
+	"event-service/internal/models"
+	"event-service/internal/repository"
+	"testing"
+)
+
+type mockVenueRepo struct {
+	CreateFunc        func(*models.Venue) error
+	GetByIDFunc       func(uint) (*models.Venue, error)
+	UpdateFunc        func(*models.Venue) error
+	DeleteFunc        func(uint) error
+	ListFunc          func() ([]models.Venue, error)
+	CountEventsFunc   func(uint) (int64, error)
+	MaxEventSeatsFunc func(uint) (int, error)
+}
+
+func (m *mockVenueRepo) Create(v *models.Venue) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(v)
+	}
+	return nil
+}
+
+func (m *mockVenueRepo) GetByID(id uint) (*models.Venue, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(id)
+	}
+	return nil, e.ErrVenueNotFound
+}
+
+func (m *mockVenueRepo) Update(v *models.Venue) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(v)
+	}
+	return nil
+}
+
+func (m *mockVenueRepo) Delete(id uint) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(id)
+	}
+	return nil
+}
+
+func (m *mockVenueRepo) List() ([]models.Venue, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	return nil, nil
+}
+
+func (m *mockVenueRepo) CountEvents(id uint) (int64, error) {
+	if m.CountEventsFunc != nil {
+		return m.CountEventsFunc(id)
+	}
+	return 0, nil
+}
+
+func (m *mockVenueRepo) MaxEventSeats(id uint) (int, error) {
+	if m.MaxEventSeatsFunc != nil {
+		return m.MaxEventSeatsFunc(id)
+	}
+	return 0, nil
+}
+
+// venueRepoWith отдаёт одну площадку с заданной вместимостью.
+func venueRepoWith(id uint, capacity int) *mockVenueRepo {
+	return &mockVenueRepo{GetByIDFunc: func(got uint) (*models.Venue, error) {
+		if got != id {
+			return nil, e.ErrVenueNotFound
+		}
+		return &models.Venue{Base: models.Base{ID: id}, Capacity: capacity, UserID: 42}, nil
+	}}
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestVenue_Create_Success(t *testing.T) {
+	var saved *models.Venue
+	repo := &mockVenueRepo{CreateFunc: func(v *models.Venue) error {
+		v.ID = 3
+		saved = v
+		return nil
+	}}
+	svc := NewVenueService(repo, testLogger())
+
+	organizer := Actor{UserID: 42, Role: RoleOrganizer}
+	got, err := svc.CreateVenue(context.Background(), organizer, dto.CreateVenueRequest{
+		Name:      " Loft ",
+		Address:   "Tverskaya 1",
+		Latitude:  floatPtr(55.75),
+		Longitude: floatPtr(37.61),
+		Capacity:  200,
+		Timezone:  "Europe/Moscow",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 3 || saved.Name != "Loft" || saved.UserID != 42 || saved.Capacity != 200 {
+		t.Fatalf("unexpected venue: %#v", saved)
+	}
+}
+
+func TestVenue_Create_Validation(t *testing.T) {
+	svc := NewVenueService(&mockVenueRepo{}, testLogger())
+	req := dto.CreateVenueRequest{
+		Name:      "Loft",
+		Latitude:  floatPtr(0),
+		Longitude: floatPtr(0),
+		Capacity:  10,
+		Timezone:  "Mars/Olympus",
+	}
+
+	_, err := svc.CreateVenue(context.Background(), Actor{UserID: 1, Role: "user"}, req)
+	if !errors.Is(err, e.ErrOrganizerRequired) {
+		t.Fatalf("expected ErrOrganizerRequired, got %v", err)
+	}
+
+	_, err = svc.CreateVenue(context.Background(), testAdmin, req)
+	if !errors.Is(err, e.ErrInvalidTimezone) {
+		t.Fatalf("expected ErrInvalidTimezone, got %v", err)
+	}
+}
+
+func TestVenue_Update_CapacityBelowEventSeats(t *testing.T) {
+	repo := venueRepoWith(3, 200)
+	repo.MaxEventSeatsFunc = func(uint) (int, error) { return 150, nil }
+	repo.UpdateFunc = func(*models.Venue) error {
+		t.Fatalf("update must not be called")
+		return nil
+	}
+	svc := NewVenueService(repo, testLogger())
+
+	capacity := 100
+	_, err := svc.UpdateVenue(context.Background(), Actor{UserID: 42, Role: RoleOrganizer}, 3, dto.UpdateVenueRequest{Capacity: &capacity})
+	if !errors.Is(err, e.ErrVenueCapacityTooLow) {
+		t.Fatalf("expected ErrVenueCapacityTooLow, got %v", err)
+	}
+}
+
+func TestVenue_Update_ForbiddenForOthers(t *testing.T) {
+	svc := NewVenueService(venueRepoWith(3, 200), testLogger())
+
+	name := "Other"
+	_, err := svc.UpdateVenue(context.Background(), Actor{UserID: 7, Role: RoleOrganizer}, 3, dto.UpdateVenueRequest{Name: &name})
+	if !errors.Is(err, e.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestVenue_Delete_InUse(t *testing.T) {
+	repo := venueRepoWith(3, 200)
+	repo.CountEventsFunc = func(uint) (int64, error) { return 2, nil }
+	repo.DeleteFunc = func(uint) error {
+		t.Fatalf("delete must not be called")
+		return nil
+	}
+	svc := NewVenueService(repo, testLogger())
+
+	if err := svc.DeleteVenue(context.Background(), testAdmin, 3); !errors.Is(err, e.ErrVenueInUse) {
+		t.Fatalf("expected ErrVenueInUse, got %v", err)
+	}
+}
+
+func TestEvent_Create_SeatsCappedByVenue(t *testing.T) {
+	var created *models.Event
+	repo := &mockEventRepo{CreateFunc: func(ev *models.Event) error {
+		created = ev
+		return nil
+	}}
+	svc := NewEventService(repo, &mockCategoryRepo{}, venueRepoWith(3, 100), &mockProducer{}, &mockMembers{}, logger())
+	organizer := Actor{UserID: 42, Role: RoleOrganizer}
+	venueID := uint(3)
+
+	seats := 150
+	_, err := svc.CreateEvent(context.Background(), organizer, dto.CreateEventRequest{Title: "Meetup", VenueID: &venueID, Seats: &seats})
+	if !errors.Is(err, e.ErrSeatsExceedCapacity) {
+		t.Fatalf("expected ErrSeatsExceedCapacity, got %v", err)
+	}
+
+	// без Seats мероприятие получает вместимость площадки
+	if _, err := svc.CreateEvent(context.Background(), organizer, dto.CreateEventRequest{Title: "Meetup", VenueID: &venueID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Seats == nil || *created.Seats != 100 || created.VenueID == nil || *created.VenueID != 3 {
+		t.Fatalf("unexpected event: %#v", created)
+	}
+}
+
+func TestEvent_Update_SeatsCappedByVenue(t *testing.T) {
+	venueID := uint(3)
+	repo := &mockEventRepo{
+		GetByIDFunc: func(id uint) (*models.Event, error) {
+			return &models.Event{Base: models.Base{ID: id}, VenueID: &venueID, UserID: 42}, nil
+		},
+		UpdateFunc: func(*models.Event) error {
+			t.Fatalf("update must not be called")
+			return nil
+		},
+	}
+	svc := NewEventService(repo, &mockCategoryRepo{}, venueRepoWith(3, 100), &mockProducer{}, &mockMembers{}, logger())
+
+	seats := 101
+	_, err := svc.UpdateEvent(context.Background(), testAdmin, dto.UpdateEventRequest{Seats: &seats}, 1)
+	if !errors.Is(err, e.ErrSeatsExceedCapacity) {
+		t.Fatalf("expected ErrSeatsExceedCapacity, got %v", err)
+	}
+}
+
+func TestEvent_List_Nearby(t *testing.T) {
+	var gotQuery dto.EventListQuery
+	repo := &mockEventRepo{ListFunc: func(q dto.EventListQuery, _ *dto.EventCursor) (*repository.EventPage, error) {
+		gotQuery = q
+		return &repository.EventPage{}, nil
+	}}
+	svc := NewEventService(repo, &mockCategoryRepo{}, &mockVenueRepo{}, &mockProducer{}, &mockMembers{}, logger())
+
+	if _, err := svc.ListEvents(dto.EventListQuery{Lat: floatPtr(55.75), Lng: floatPtr(37.61)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery.SortBy != dto.SortByDistance || gotQuery.SortOrder != "asc" {
+		t.Fatalf("expected distance asc sort, got %q %q", gotQuery.SortBy, gotQuery.SortOrder)
+	}
+	if gotQuery.RadiusKm == nil || *gotQuery.RadiusKm != dto.DefaultRadiusKm {
+		t.Fatalf("expected default radius, got %v", gotQuery.RadiusKm)
+	}
+
+	for _, q := range []dto.EventListQuery{
+		{Lat: floatPtr(55.75)},
+		{Lat: floatPtr(91), Lng: floatPtr(0)},
+		{Lat: floatPtr(0), Lng: floatPtr(0), RadiusKm: floatPtr(dto.MaxRadiusKm + 1)},
+		{RadiusKm: floatPtr(5)},
+	} {
+		if _, err := svc.ListEvents(q); !errors.Is(err, e.ErrInvalidLocation) {
+			t.Fatalf("expected ErrInvalidLocation for %+v, got %v", q, err)
+		}
+	}
+}
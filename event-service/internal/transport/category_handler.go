@@ -34,7 +34,7 @@ func (h *CategoryHandler) RegisterRoutes(r *gin.Engine) {
 func (h *CategoryHandler) Create(ctx *gin.Context) {
 	var req dto.CreateCategoryRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("invalid json for create category", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid json for create category", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
 		return
 	}
@@ -42,11 +42,11 @@ func (h *CategoryHandler) Create(ctx *gin.Context) {
 	category, err := h.service.CreateCategory(req)
 	if err != nil {
 		if errors.Is(err, e.ErrCategoryNameExists) {
-			h.logger.Warn("category name exists", "name", req.Name)
+			h.logger.WarnContext(ctx.Request.Context(), "category name exists", "name", req.Name)
 			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("failed to create category", "error", err)
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to create category", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -57,7 +57,7 @@ func (h *CategoryHandler) Create(ctx *gin.Context) {
 func (h *CategoryHandler) GetByID(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		h.logger.Warn("invalid id param", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
 		return
 	}
@@ -65,11 +65,11 @@ func (h *CategoryHandler) GetByID(ctx *gin.Context) {
 	category, err := h.service.GetCategory(uint(id))
 	if err != nil {
 		if errors.Is(err, e.ErrCategoryNotFound) {
-			h.logger.Warn("category not found", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "category not found", "id", id)
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("failed to get category", "error", err)
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to get category", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -80,18 +80,18 @@ func (h *CategoryHandler) GetByID(ctx *gin.Context) {
 func (h *CategoryHandler) Delete(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		h.logger.Warn("invalid id param for delete", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for delete", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
 		return
 	}
 
 	if err := h.service.DeleteCategory(uint(id)); err != nil {
 		if errors.Is(err, e.ErrCategoryNotFound) {
-			h.logger.Warn("category not found for delete", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "category not found for delete", "id", id)
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("failed to delete category", "error", err)
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to delete category", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"user-service/userclient"
 
 	"github.com/gin-gonic/gin"
 )
@@ -46,16 +47,25 @@ func (h *EventHandler) Ping(ctx *gin.Context) {
 }
 
 func (h *EventHandler) Create(ctx *gin.Context) {
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
 	var req dto.CreateEventRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("invalid json for create event", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid json for create event", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный JSON"})
 		return
 	}
 
-	event, err := h.service.CreateEvent(req)
+	event, err := h.service.CreateEvent(ctx.Request.Context(), actor, req)
 	if err != nil {
-		h.logger.Error("failed to create event", "error", err)
+		if errors.Is(err, e.ErrForbidden) || errors.Is(err, e.ErrOrganizerRequired) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to create event", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -66,7 +76,7 @@ func (h *EventHandler) Create(ctx *gin.Context) {
 func (h *EventHandler) GetByID(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		h.logger.Warn("invalid id param", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
 		return
 	}
@@ -74,11 +84,11 @@ func (h *EventHandler) GetByID(ctx *gin.Context) {
 	event, err := h.service.GetEvent(uint(id))
 	if err != nil {
 		if errors.Is(err, e.ErrEventNotFound) {
-			h.logger.Warn("event not found", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "event not found", "id", id)
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("failed to get event", "error", err)
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to get event", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -89,10 +99,14 @@ func (h *EventHandler) GetByID(ctx *gin.Context) {
 func (h *EventHandler) Update(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		h.logger.Warn("invalid id param for update", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for update", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
 		return
 	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
 
 	var req dto.UpdateEventRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -100,14 +114,22 @@ func (h *EventHandler) Update(ctx *gin.Context) {
 		return
 	}
 
-	event, err := h.service.UpdateEvent(req, uint(id))
+	event, err := h.service.UpdateEvent(ctx.Request.Context(), actor, req, uint(id))
 	if err != nil {
 		if errors.Is(err, e.ErrEventNotFound) {
-			h.logger.Warn("event not found for update", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "event not found for update", "id", id)
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("failed to update event", "error", err)
+		if errors.Is(err, e.ErrForbidden) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, e.ErrVenueNotFound) || errors.Is(err, e.ErrSeatsExceedCapacity) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to update event", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -118,23 +140,31 @@ func (h *EventHandler) Update(ctx *gin.Context) {
 func (h *EventHandler) Delete(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		h.logger.Warn("invalid id param for delete", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for delete", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
 		return
 	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
 
-	if err := h.service.DeleteEvent(uint(id)); err != nil {
+	if err := h.service.DeleteEvent(ctx.Request.Context(), actor, uint(id)); err != nil {
 		if errors.Is(err, e.ErrEventNotFound) {
-			h.logger.Warn("event not found for delete", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "event not found for delete", "id", id)
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, e.ErrForbidden) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		if errors.Is(err, e.ErrEventIsNotDraft) {
-			h.logger.Warn("attempt to delete non-draft event", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "attempt to delete non-draft event", "id", id)
 			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("failed to delete event", "error", err)
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to delete event", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -145,12 +175,6 @@ func (h *EventHandler) Delete(ctx *gin.Context) {
 func (h *EventHandler) List(ctx *gin.Context) {
 	var query dto.EventListQuery
 
-	if pageStr := ctx.Query("page"); pageStr != "" {
-		if page, err := strconv.Atoi(strings.TrimSpace(pageStr)); err == nil {
-			query.Page = page
-		}
-	}
-
 	if limitStr := ctx.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(strings.TrimSpace(limitStr)); err == nil {
 			query.Limit = limit
@@ -166,10 +190,18 @@ func (h *EventHandler) List(ctx *gin.Context) {
 	query.Status = strings.TrimSpace(query.Status)
 	query.SortBy = strings.TrimSpace(query.SortBy)
 	query.SortOrder = strings.TrimSpace(query.SortOrder)
+	query.Cursor = strings.TrimSpace(query.Cursor)
 
 	events, err := h.service.ListEvents(query)
 	if err != nil {
-		h.logger.Error("failed to list events", "error", err)
+		if errors.Is(err, e.ErrInvalidCursor) ||
+			errors.Is(err, e.ErrInvalidDateRange) ||
+			errors.Is(err, e.ErrInvalidPriceRange) ||
+			errors.Is(err, e.ErrInvalidLocation) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to list events", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -180,24 +212,32 @@ func (h *EventHandler) List(ctx *gin.Context) {
 func (h *EventHandler) Publish(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		h.logger.Warn("invalid id param for publish", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for publish", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
 		return
 	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
 
-	err = h.service.PublishEvent(uint(id))
+	err = h.service.PublishEvent(ctx.Request.Context(), actor, uint(id))
 	if err != nil {
 		if errors.Is(err, e.ErrEventNotFound) {
-			h.logger.Warn("event not found for publish", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "event not found for publish", "id", id)
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, e.ErrForbidden) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		if errors.Is(err, e.ErrEventIsNotDraft) {
-			h.logger.Warn("attempt to publish non-draft event", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "attempt to publish non-draft event", "id", id)
 			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("failed to publish event", "error", err)
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to publish event", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -208,24 +248,32 @@ func (h *EventHandler) Publish(ctx *gin.Context) {
 func (h *EventHandler) Cancel(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		h.logger.Warn("invalid id param for cancel", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for cancel", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
 		return
 	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
 
-	err = h.service.CancelEvent(uint(id))
+	err = h.service.CancelEvent(ctx.Request.Context(), actor, uint(id))
 	if err != nil {
 		if errors.Is(err, e.ErrEventNotFound) {
-			h.logger.Warn("event not found for cancel", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "event not found for cancel", "id", id)
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, e.ErrForbidden) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		if errors.Is(err, e.ErrEventIsNotPublished) {
-			h.logger.Warn("attempt to cancel non-published event", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "attempt to cancel non-published event", "id", id)
 			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("failed to cancel event", "error", err)
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to cancel event", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -236,17 +284,30 @@ func (h *EventHandler) Cancel(ctx *gin.Context) {
 func (h *EventHandler) GetByUserID(ctx *gin.Context) {
 	userID, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		h.logger.Warn("invalid user id param", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid user id param", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	events, err := h.service.GetEventsByUserID(uint(userID))
 	if err != nil {
-		h.logger.Error("failed to get events by user", "error", err, "user_id", userID)
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to get events by user", "error", err, "user_id", userID)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, events)
 }
+
+// requestActor — пользователь из заголовков, проверенных userclient.RequireIdentity.
+func requestActor(ctx *gin.Context) (services.Actor, bool) {
+	id, err := strconv.ParseUint(ctx.GetHeader(userclient.HeaderUserID), 10, 64)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return services.Actor{}, false
+	}
+	return services.Actor{
+		UserID: uint(id),
+		Role:   ctx.GetHeader(userclient.HeaderUserRole),
+	}, true
+}
@@ -26,31 +26,47 @@ func (h *EventScheduleHandler) RegisterRoutes(r *gin.Engine) {
 	{
 		schedules.POST("", h.Create)
 		schedules.GET("", h.GetByEventID)
+		schedules.PUT("/:scheduleId", h.Update)
+		schedules.DELETE("/:scheduleId", h.Delete)
 	}
 }
 
 func (h *EventScheduleHandler) Create(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		h.logger.Warn("invalid id param for create schedule", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for create schedule", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
 		return
 	}
 
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
 	var req dto.CreateScheduleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
 		return
 	}
 
-	schedule, err := h.service.CreateScheduleForEvent(uint(id), req)
+	schedule, err := h.service.CreateScheduleForEvent(ctx.Request.Context(), actor, uint(id), req)
 	if err != nil {
+		var conflict *services.ConflictError
+		if errors.As(err, &conflict) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflicts": conflict.Conflicts})
+			return
+		}
+		if errors.Is(err, e.ErrForbidden) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		if errors.Is(err, e.ErrEventNotFound) {
-			h.logger.Warn("event not found when creating schedule", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "event not found when creating schedule", "id", id)
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("failed to create schedule", "error", err)
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to create schedule", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -61,7 +77,7 @@ func (h *EventScheduleHandler) Create(ctx *gin.Context) {
 func (h *EventScheduleHandler) GetByEventID(ctx *gin.Context) {
 	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		h.logger.Warn("invalid id param for get schedules", "error", err)
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for get schedules", "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
 		return
 	}
@@ -69,14 +85,91 @@ func (h *EventScheduleHandler) GetByEventID(ctx *gin.Context) {
 	schedules, err := h.service.GetScheduleByEventID(uint(id))
 	if err != nil {
 		if errors.Is(err, e.ErrEventNotFound) {
-			h.logger.Warn("event not found when getting schedules", "id", id)
+			h.logger.WarnContext(ctx.Request.Context(), "event not found when getting schedules", "id", id)
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("failed to get schedules", "error", err)
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to get schedules", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, schedules)
 }
+
+func (h *EventScheduleHandler) Update(ctx *gin.Context) {
+	id, scheduleID, ok := h.scheduleParams(ctx)
+	if !ok {
+		return
+	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	schedule, err := h.service.UpdateSchedule(ctx.Request.Context(), actor, id, scheduleID, req)
+	if err != nil {
+		h.writeError(ctx, err, "failed to update schedule")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+func (h *EventScheduleHandler) Delete(ctx *gin.Context) {
+	id, scheduleID, ok := h.scheduleParams(ctx)
+	if !ok {
+		return
+	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSchedule(ctx.Request.Context(), actor, id, scheduleID); err != nil {
+		h.writeError(ctx, err, "failed to delete schedule")
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (h *EventScheduleHandler) scheduleParams(ctx *gin.Context) (uint, uint, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for schedule", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return 0, 0, false
+	}
+	scheduleID, err := strconv.Atoi(ctx.Param("scheduleId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID активности"})
+		return 0, 0, false
+	}
+	return uint(id), uint(scheduleID), true
+}
+
+func (h *EventScheduleHandler) writeError(ctx *gin.Context, err error, msg string) {
+	var conflict *services.ConflictError
+	switch {
+	case errors.As(err, &conflict):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflicts": conflict.Conflicts})
+	case errors.Is(err, e.ErrEventNotFound), errors.Is(err, e.ErrEventScheduleNotFound), errors.Is(err, e.ErrVenueNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrScheduleInSeries):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrNotCorrectScheduleTime):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.ErrorContext(ctx.Request.Context(), msg, "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -13,12 +13,18 @@ func RegisterRoutes(
 	eventService services.EventService,
 	scheduleService services.EventScheduleService,
 	categoryService services.CategoryService,
+	venueService services.VenueService,
+	seriesService services.ScheduleSeriesService,
 ) {
 	eventHandler := NewEventHandler(eventService, log)
 	scheduleHandler := NewEventScheduleHandler(scheduleService, log)
 	categoryHandler := NewCategoryHandler(categoryService, log)
+	venueHandler := NewVenueHandler(venueService, log)
+	seriesHandler := NewScheduleSeriesHandler(seriesService, log)
 
 	eventHandler.RegisterRoutes(router)
 	scheduleHandler.RegisterRoutes(router)
 	categoryHandler.RegisterRoutes(router)
+	venueHandler.RegisterRoutes(router)
+	seriesHandler.RegisterRoutes(router)
 }
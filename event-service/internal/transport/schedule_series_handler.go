@@ -0,0 +1,191 @@
+package transport
+
+import (
+	"errors"
+	"event-service/internal/dto"
+	e "event-service/internal/errors"
+	"event-service/internal/services"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleSeriesHandler struct {
+	service services.ScheduleSeriesService
+	logger  *slog.Logger
+}
+
+func NewScheduleSeriesHandler(service services.ScheduleSeriesService, logger *slog.Logger) *ScheduleSeriesHandler {
+	return &ScheduleSeriesHandler{service: service, logger: logger}
+}
+
+func (h *ScheduleSeriesHandler) RegisterRoutes(r *gin.Engine) {
+	series := r.Group("/events/:id/series")
+	{
+		series.POST("", h.Create)
+		series.GET("", h.GetByEventID)
+		series.DELETE("/:seriesId", h.Delete)
+		series.PUT("/:seriesId/occurrences/:scheduleId", h.UpdateOccurrence)
+		series.DELETE("/:seriesId/occurrences/:scheduleId", h.DeleteOccurrence)
+	}
+}
+
+func (h *ScheduleSeriesHandler) Create(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for create series", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return
+	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateSeriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	series, err := h.service.CreateSeries(ctx.Request.Context(), actor, uint(id), req)
+	if err != nil {
+		h.writeError(ctx, err, "failed to create schedule series")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, series)
+}
+
+func (h *ScheduleSeriesHandler) GetByEventID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for get series", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return
+	}
+
+	series, err := h.service.GetSeriesByEventID(uint(id))
+	if err != nil {
+		h.writeError(ctx, err, "failed to get schedule series")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, series)
+}
+
+func (h *ScheduleSeriesHandler) Delete(ctx *gin.Context) {
+	id, seriesID, ok := h.seriesParams(ctx)
+	if !ok {
+		return
+	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSeries(ctx.Request.Context(), actor, id, seriesID); err != nil {
+		h.writeError(ctx, err, "failed to delete schedule series")
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (h *ScheduleSeriesHandler) UpdateOccurrence(ctx *gin.Context) {
+	id, seriesID, ok := h.seriesParams(ctx)
+	if !ok {
+		return
+	}
+	scheduleID, err := strconv.Atoi(ctx.Param("scheduleId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return
+	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	var query dto.OccurrenceScopeQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный scope"})
+		return
+	}
+	var req dto.UpdateOccurrenceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	schedule, err := h.service.UpdateOccurrence(ctx.Request.Context(), actor, id, seriesID, uint(scheduleID), query.Scope, req)
+	if err != nil {
+		h.writeError(ctx, err, "failed to update occurrence")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+func (h *ScheduleSeriesHandler) DeleteOccurrence(ctx *gin.Context) {
+	id, seriesID, ok := h.seriesParams(ctx)
+	if !ok {
+		return
+	}
+	scheduleID, err := strconv.Atoi(ctx.Param("scheduleId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return
+	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	var query dto.OccurrenceScopeQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный scope"})
+		return
+	}
+
+	if err := h.service.DeleteOccurrence(ctx.Request.Context(), actor, id, seriesID, uint(scheduleID), query.Scope); err != nil {
+		h.writeError(ctx, err, "failed to delete occurrence")
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (h *ScheduleSeriesHandler) seriesParams(ctx *gin.Context) (uint, uint, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return 0, 0, false
+	}
+	seriesID, err := strconv.Atoi(ctx.Param("seriesId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID серии"})
+		return 0, 0, false
+	}
+	return uint(id), uint(seriesID), true
+}
+
+func (h *ScheduleSeriesHandler) writeError(ctx *gin.Context, err error, msg string) {
+	var conflict *services.ConflictError
+	switch {
+	case errors.As(err, &conflict):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflicts": conflict.Conflicts})
+	case errors.Is(err, e.ErrEventNotFound), errors.Is(err, e.ErrScheduleSeriesNotFound),
+		errors.Is(err, e.ErrOccurrenceNotFound), errors.Is(err, e.ErrVenueNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrInvalidRRule), errors.Is(err, e.ErrInvalidTimezone), errors.Is(err, e.ErrRRuleChangeNeedsScope):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.ErrorContext(ctx.Request.Context(), msg, "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
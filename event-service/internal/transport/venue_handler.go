@@ -0,0 +1,145 @@
+package transport
+
+import (
+	"errors"
+	"event-service/internal/dto"
+	e "event-service/internal/errors"
+	"event-service/internal/services"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VenueHandler struct {
+	service services.VenueService
+	logger  *slog.Logger
+}
+
+func NewVenueHandler(service services.VenueService, logger *slog.Logger) *VenueHandler {
+	return &VenueHandler{service: service, logger: logger}
+}
+
+func (h *VenueHandler) RegisterRoutes(r *gin.Engine) {
+	venues := r.Group("/venues")
+	{
+		venues.GET("", h.List)
+		venues.POST("", h.Create)
+		venues.GET("/:id", h.GetByID)
+		venues.PUT("/:id", h.Update)
+		venues.DELETE("/:id", h.Delete)
+	}
+}
+
+func (h *VenueHandler) Create(ctx *gin.Context) {
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateVenueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.WarnContext(ctx.Request.Context(), "invalid json for create venue", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	venue, err := h.service.CreateVenue(ctx.Request.Context(), actor, req)
+	if err != nil {
+		h.writeError(ctx, err, "failed to create venue")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, venue)
+}
+
+func (h *VenueHandler) GetByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return
+	}
+
+	venue, err := h.service.GetVenue(uint(id))
+	if err != nil {
+		h.writeError(ctx, err, "failed to get venue")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, venue)
+}
+
+func (h *VenueHandler) List(ctx *gin.Context) {
+	venues, err := h.service.ListVenues()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, venues)
+}
+
+func (h *VenueHandler) Update(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for update venue", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return
+	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateVenueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	venue, err := h.service.UpdateVenue(ctx.Request.Context(), actor, uint(id), req)
+	if err != nil {
+		h.writeError(ctx, err, "failed to update venue")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, venue)
+}
+
+func (h *VenueHandler) Delete(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		h.logger.WarnContext(ctx.Request.Context(), "invalid id param for delete venue", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return
+	}
+	actor, ok := requestActor(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteVenue(ctx.Request.Context(), actor, uint(id)); err != nil {
+		h.writeError(ctx, err, "failed to delete venue")
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (h *VenueHandler) writeError(ctx *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, e.ErrVenueNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrForbidden), errors.Is(err, e.ErrOrganizerRequired):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrVenueInUse), errors.Is(err, e.ErrVenueCapacityTooLow):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrEmptyName), errors.Is(err, e.ErrInvalidTimezone), errors.Is(err, e.ErrNotCorrectNum):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.ErrorContext(ctx.Request.Context(), msg, "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
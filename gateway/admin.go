@@ -0,0 +1,62 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"gateway/resilience"
+	"gateway/routing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type upstreamStatus struct {
+	Name    string                     `json:"name"`
+	URL     string                     `json:"url"`
+	Timeout string                     `json:"timeout"`
+	Retries int                        `json:"retries"`
+	Breaker resilience.BreakerSnapshot `json:"breaker"`
+}
+
+// runAdminServer поднимает служебный HTTP-сервер. Порт не
+// публикуется наружу и доступен только внутри сети сервисов.
+func runAdminServer(addr string, routes *routing.Holder, breakers *resilience.Registry) {
+	r := gin.New()
+	r.Use(gin.Recovery())
+
+	r.GET("/admin/upstreams", func(c *gin.Context) {
+		table := routes.Table()
+
+		snapshots := make(map[string]resilience.BreakerSnapshot)
+		for _, s := range breakers.Snapshots() {
+			snapshots[s.Name] = s
+		}
+
+		out := make([]upstreamStatus, 0, len(table.Upstreams))
+		for name, u := range table.Upstreams {
+			out = append(out, upstreamStatus{
+				Name:    name,
+				URL:     u.URL.String(),
+				Timeout: u.Timeout.String(),
+				Retries: u.Retries,
+				Breaker: snapshots[name],
+			})
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+		c.JSON(http.StatusOK, gin.H{"upstreams": out})
+	})
+
+	r.POST("/admin/routes/reload", func(c *gin.Context) {
+		if err := routes.Reload(); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "reloaded"})
+	})
+
+	if err := r.Run(addr); err != nil {
+		slog.Error("admin server stopped", "error", err)
+	}
+}
@@ -0,0 +1,46 @@
+package identity
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSigner_Sign(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := NewSigner("secret", time.Minute)
+	s.now = func() time.Time { return now }
+
+	h := http.Header{}
+	s.Sign(h, 42, "organizer")
+
+	if h.Get(HeaderUserID) != "42" || h.Get(HeaderUserRole) != "organizer" {
+		t.Fatalf("unexpected identity headers: %v", h)
+	}
+	if h.Get(HeaderExpires) != "1700000060" {
+		t.Fatalf("unexpected expiry: %q", h.Get(HeaderExpires))
+	}
+
+	want := Signature([]byte("secret"), "42", "organizer", "1700000060")
+	if h.Get(HeaderSignature) != want {
+		t.Fatalf("unexpected signature: %q", h.Get(HeaderSignature))
+	}
+	if Signature([]byte("secret"), "42", "user", "1700000060") == want {
+		t.Fatalf("signature must depend on role")
+	}
+}
+
+func TestStrip(t *testing.T) {
+	h := http.Header{}
+	h.Set(HeaderUserID, "1")
+	h.Set(HeaderUserRole, "organizer")
+	h.Set(HeaderExpires, "9999999999")
+	h.Set(HeaderSignature, "forged")
+	h.Set("Authorization", "Bearer x")
+
+	Strip(h)
+
+	if len(h) != 1 || h.Get("Authorization") == "" {
+		t.Fatalf("expected only non-identity headers to remain, got %v", h)
+	}
+}
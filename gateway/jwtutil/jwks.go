@@ -0,0 +1,174 @@
+package jwtutil
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// minRefreshInterval ограничивает внеплановые запросы JWKS, чтобы поток
+// токенов с мусорным kid не превратился в нагрузку на user-service.
+const minRefreshInterval = 10 * time.Second
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKSCache держит публичные ключи user-service. Ключи обновляются
+// по таймеру, а при неизвестном kid — сразу, чтобы новый ключ после
+// ротации начинал приниматься без ожидания следующего обновления.
+type JWKSCache struct {
+	url     string
+	client  *http.Client
+	refresh time.Duration
+	logger  *slog.Logger
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	lastAttempt time.Time
+
+	fetchMu sync.Mutex
+}
+
+func NewJWKSCache(url string, refresh time.Duration, logger *slog.Logger) *JWKSCache {
+	return &JWKSCache{
+		url:     url,
+		client:  &http.Client{Timeout: 5 * time.Second},
+		refresh: refresh,
+		logger:  logger,
+		keys:    make(map[string]*rsa.PublicKey),
+	}
+}
+
+func (c *JWKSCache) Key(kid string) (*rsa.PublicKey, error) {
+	if key := c.lookup(kid); key != nil {
+		return key, nil
+	}
+
+	c.mu.RLock()
+	recent := time.Since(c.lastAttempt) < minRefreshInterval
+	c.mu.RUnlock()
+	if !recent {
+		if err := c.Refresh(context.Background()); err != nil {
+			c.logger.Warn("failed to refresh jwks", "error", err)
+		}
+	}
+
+	if key := c.lookup(kid); key != nil {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func (c *JWKSCache) lookup(kid string) *rsa.PublicKey {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.keys[kid]
+}
+
+// Refresh перечитывает JWKS. При ошибке прежние ключи остаются в силе.
+func (c *JWKSCache) Refresh(ctx context.Context) error {
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+
+	c.mu.Lock()
+	c.lastAttempt = time.Now()
+	c.mu.Unlock()
+
+	keys, err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *JWKSCache) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || k.Kid == "" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			c.logger.Warn("skipping invalid jwk", "kid", k.Kid, "error", err)
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks: no usable keys")
+	}
+	return keys, nil
+}
+
+// Run обновляет ключи по таймеру до отмены контекста.
+func (c *JWKSCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil {
+				c.logger.Warn("failed to refresh jwks", "error", err)
+			}
+		}
+	}
+}
+
+func (k jwk) publicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+	exp := new(big.Int).SetBytes(e)
+	if !exp.IsInt64() || exp.Int64() < 3 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+}
@@ -1,9 +1,9 @@
 package jwtutil
 
 import (
+	"crypto/rsa"
 	"errors"
 	"fmt"
-	"os"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -18,32 +18,47 @@ const (
 	AccessToken TokenType = "access"
 )
 
-func getSecret() []byte {
-	return []byte(os.Getenv("JWT_SECRET"))
-}
-
 type Claims struct {
-	UserID uint      `json:"user_id"`
-	Role   string    `json:"role"`
-	Type   TokenType `json:"type"`
+	UserID    uint      `json:"user_id"`
+	Role      string    `json:"role"`
+	Type      TokenType `json:"type"`
+	SessionID uint      `json:"sid,omitempty"`
+	// EmailVerified — пользователь подтвердил адрес почты.
+	EmailVerified bool `json:"email_verified"`
+	// MFA — вход подтверждён вторым фактором.
+	MFA bool `json:"mfa"`
 	jwt.RegisteredClaims
 }
 
-func ParseToken(tokenStr string) (*Claims, error) {
+// KeySource отдаёт публичный ключ user-service по kid из заголовка токена.
+type KeySource interface {
+	Key(kid string) (*rsa.PublicKey, error)
+}
+
+type Parser struct {
+	keys KeySource
+}
+
+func NewParser(keys KeySource) *Parser {
+	return &Parser{keys: keys}
+}
+
+func (p *Parser) ParseToken(tokenStr string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(
 		tokenStr,
 		&Claims{},
 		func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
-			return getSecret(), nil
+			kid, _ := token.Header["kid"].(string)
+			if kid == "" {
+				return nil, errors.New("missing kid")
+			}
+			return p.keys.Key(kid)
 		},
 	)
-
 	if err != nil {
-		fmt.Println(err.Error())
-		fmt.Printf("JWT_SECRET=%q\n", os.Getenv("JWT_SECRET"))
 		return nil, err
 	}
 
@@ -0,0 +1,135 @@
+package jwtutil
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type testKey struct {
+	kid string
+	key *rsa.PrivateKey
+}
+
+func newTestKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testKey{kid: kid, key: key}
+}
+
+func (k testKey) jwk() jwk {
+	return jwk{
+		Kty: "RSA",
+		Kid: k.kid,
+		N:   base64.RawURLEncoding.EncodeToString(k.key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.key.E)).Bytes()),
+	}
+}
+
+func (k testKey) sign(t *testing.T, claims Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = k.kid
+	s, err := token.SignedString(k.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func accessClaims() Claims {
+	return Claims{
+		UserID: 7,
+		Role:   "organizer",
+		Type:   AccessToken,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+}
+
+func TestParser_PicksUpRotatedKey(t *testing.T) {
+	oldKey := newTestKey(t, "old")
+	newKey := newTestKey(t, "new")
+
+	var published atomic.Value
+	published.Store([]jwk{oldKey.jwk()})
+	var fetches atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": published.Load()})
+	}))
+	defer srv.Close()
+
+	cache := NewJWKSCache(srv.URL, time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	parser := NewParser(cache)
+
+	claims, err := parser.ParseToken(oldKey.sign(t, accessClaims()))
+	if err != nil {
+		t.Fatalf("parse with old key: %v", err)
+	}
+	if claims.UserID != 7 || claims.Role != "organizer" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	// ротация: user-service начал публиковать новый ключ
+	published.Store([]jwk{oldKey.jwk(), newKey.jwk()})
+	cache.mu.Lock()
+	cache.lastAttempt = time.Time{}
+	cache.mu.Unlock()
+
+	if _, err := parser.ParseToken(newKey.sign(t, accessClaims())); err != nil {
+		t.Fatalf("parse with rotated key: %v", err)
+	}
+	if fetches.Load() != 2 {
+		t.Fatalf("expected refresh on unknown kid, got %d fetches", fetches.Load())
+	}
+
+	// повторный неизвестный kid не должен сразу дёргать user-service
+	stranger := newTestKey(t, "stranger")
+	if _, err := parser.ParseToken(stranger.sign(t, accessClaims())); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("expected ErrUnknownKey, got %v", err)
+	}
+	if fetches.Load() != 2 {
+		t.Fatalf("refresh must be throttled, got %d fetches", fetches.Load())
+	}
+}
+
+func TestParser_RejectsHMACAndRefreshTokens(t *testing.T) {
+	key := newTestKey(t, "k1")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []jwk{key.jwk()}})
+	}))
+	defer srv.Close()
+
+	parser := NewParser(NewJWKSCache(srv.URL, time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil))))
+
+	hs := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims())
+	hs.Header["kid"] = "k1"
+	hsToken, _ := hs.SignedString([]byte("secret"))
+	if _, err := parser.ParseToken(hsToken); err == nil {
+		t.Fatalf("HS256 token must be rejected")
+	}
+
+	refresh := accessClaims()
+	refresh.Type = "refresh"
+	if _, err := parser.ParseToken(key.sign(t, refresh)); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken for refresh token, got %v", err)
+	}
+}
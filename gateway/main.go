@@ -1,12 +1,25 @@
 package main
 
 import (
+	"context"
+	"gateway/identity"
+	"gateway/jwtutil"
 	"gateway/middleware"
+	"gateway/ratelimit"
+	"gateway/resilience"
+	"gateway/revocation"
+	"gateway/routing"
+	"log/slog"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/bytedance/gopkg/util/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -17,31 +30,73 @@ func main() {
 		logger.Warn("no .env file found, using system env", "error", err)
 	}
 
-	r := gin.Default()
+	routesFile := os.Getenv("GATEWAY_ROUTES_FILE")
+	if routesFile == "" {
+		routesFile = "routes.yaml"
+	}
 
-	userURL := os.Getenv("USER_SERVICE_URL")
-	if userURL == "" {
-		userURL = "http://localhost:8081"
+	identitySecret := os.Getenv("INTERNAL_IDENTITY_SECRET")
+	if identitySecret == "" {
+		slog.Error("INTERNAL_IDENTITY_SECRET is not set")
+		os.Exit(1)
 	}
-	ticketURL := os.Getenv("TICKET_SERVICE_URL")
-	if ticketURL == "" {
-		ticketURL = "http://localhost:8082"
+	signer := identity.NewSigner(identitySecret, identity.DefaultTTL)
+
+	jwks := jwtutil.NewJWKSCache(jwksURL(), 5*time.Minute, slog.Default())
+	if err := jwks.Refresh(context.Background()); err != nil {
+		// ключи подтянутся при первом токене или следующем обновлении
+		slog.Warn("failed to load jwks at startup", "error", err)
 	}
-	eventURL := os.Getenv("EVENT_SERVICE_URL")
-	if eventURL == "" {
-		eventURL = "http://localhost:8083"
+	go jwks.Run(context.Background())
+
+	redisClient := newRedisClient(slog.Default())
+
+	var revoked revocation.Store = revocation.NopStore{}
+	if redisClient != nil {
+		revoked = revocation.NewCachedStore(revocation.NewRedisStore(redisClient), 5*time.Second)
+	} else {
+		slog.Warn("REDIS_ADDR is not set, token revocation is not checked")
 	}
-	notifURL := os.Getenv("NOTIFICATION_SERVICE_URL")
-	if notifURL == "" {
-		notifURL = "http://localhost:8084"
+
+	breakers := resilience.NewRegistry()
+
+	routes, err := routing.NewHolder(routesFile, func(upstream *routing.Upstream) http.Handler {
+		return newReverseProxy(upstream, breakers)
+	})
+	if err != nil {
+		slog.Error("failed to load routes", "error", err, "path", routesFile)
+		os.Exit(1)
 	}
+	go routes.ReloadOnSIGHUP(context.Background(), slog.Default())
 
-	r.Any("/api/auth/*any", proxyToService(userURL))
-	r.Use(middleware.JWTAuth())
-	r.Any("/api/users/*any", proxyToService(userURL))
-	r.Any("/api/ticket/*any", proxyToService(ticketURL))
-	r.Any("/api/events/*any", proxyToService(eventURL))
-	r.Any("/api/notifications/*any", proxyToService(notifURL))
+	rateLimit := middleware.RateLimit(
+		newRateLimiter(redisClient, slog.Default()),
+		func() []ratelimit.Policy { return routes.Table().RateLimits },
+		slog.Default(),
+	)
+
+	r, err := newEngine()
+	if err != nil {
+		slog.Error("invalid TRUSTED_PROXIES", "error", err)
+		os.Exit(1)
+	}
+	r.Use(middleware.RequestID())
+
+	r.Any("/api/*path",
+		middleware.ResolveRoute(routes),
+		middleware.JWTAuth(jwtutil.NewParser(jwks), revoked, signer),
+		middleware.RequireRouteRoles(),
+		middleware.RequireVerifiedEmail(),
+		middleware.RequireMFA(),
+		rateLimit,
+		proxyToRoute,
+	)
+
+	adminPort := os.Getenv("ADMIN_PORT")
+	if adminPort == "" {
+		adminPort = "8001"
+	}
+	go runAdminServer(":"+adminPort, routes, breakers)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -49,3 +104,83 @@ func main() {
 	}
 	r.Run(":" + port)
 }
+
+// newEngine создаёт gin.Engine, который берёт адрес клиента из
+// X-Forwarded-For только от доверенных прокси (TRUSTED_PROXIES, CIDR через
+// запятую). По умолчанию gateway — край сети и заголовку не верит, иначе
+// лимиты по IP обходятся подменой X-Forwarded-For.
+func newEngine() (*gin.Engine, error) {
+	r := gin.Default()
+
+	var proxies []string
+	for _, p := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	if err := r.SetTrustedProxies(proxies); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// jwksURL — откуда брать публичные ключи для проверки access-токенов.
+func jwksURL() string {
+	if url := os.Getenv("JWKS_URL"); url != "" {
+		return url
+	}
+	base := os.Getenv("USER_SERVICE_URL")
+	if base == "" {
+		base = "http://localhost:8081"
+	}
+	return strings.TrimSuffix(base, "/") + "/.well-known/jwks.json"
+}
+
+// newRedisClient подключает общий Redis для лимитов и отзыва токенов.
+// Без REDIS_ADDR возвращает nil.
+func newRedisClient(log *slog.Logger) *redis.Client {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		return nil
+	}
+
+	dbNum := 0
+	if v := os.Getenv("REDIS_DB"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			log.Warn("invalid REDIS_DB, using 0", "value", v)
+		} else {
+			dbNum = n
+		}
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:         addr,
+		Password:     os.Getenv("REDIS_PASSWORD"),
+		DB:           dbNum,
+		DialTimeout:  2 * time.Second,
+		ReadTimeout:  200 * time.Millisecond,
+		WriteTimeout: 200 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		log.Warn("redis is unavailable at startup", "error", err)
+	}
+
+	return client
+}
+
+// newRateLimiter делит лимиты между репликами через Redis,
+// а при его отсутствии или недоступности считает их в памяти.
+func newRateLimiter(client *redis.Client, log *slog.Logger) ratelimit.Limiter {
+	memory := ratelimit.NewMemoryLimiter()
+
+	if client == nil {
+		log.Warn("REDIS_ADDR is not set, rate limits are per gateway instance")
+		return memory
+	}
+
+	return ratelimit.NewFallbackLimiter(ratelimit.NewRedisLimiter(client), memory, log)
+}
@@ -1,19 +1,38 @@
 package middleware
 
 import (
+	"log/slog"
 	"net/http"
-	"strconv"
 	"strings"
 
+	"gateway/identity"
 	"gateway/jwtutil"
+	"gateway/revocation"
 
 	"github.com/gin-gonic/gin"
 )
 
-func JWTAuth() gin.HandlerFunc {
+const ClaimsKey = "jwt_claims"
+
+// JWTAuth проверяет access-токен. На публичных маршрутах токен
+// необязателен: если он валиден, личность всё равно передаётся сервису.
+// Заголовки личности от клиента отбрасываются, сервисам уходит
+// только подписанная gateway личность.
+func JWTAuth(parser *jwtutil.Parser, revoked revocation.Store, signer *identity.Signer) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		identity.Strip(c.Request.Header)
+
+		public := false
+		if route, ok := RouteFromContext(c); ok {
+			public = route.Public
+		}
+
 		auth := c.GetHeader("Authorization")
 		if auth == "" {
+			if public {
+				c.Next()
+				return
+			}
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error": "missing authorization header",
 			})
@@ -22,29 +41,63 @@ func JWTAuth() gin.HandlerFunc {
 
 		parts := strings.SplitN(auth, " ", 2)
 		if len(parts) != 2 || parts[0] != "Bearer" {
+			if public {
+				c.Next()
+				return
+			}
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error": "invalid authorization format",
 			})
 			return
 		}
 
-		claims, err := jwtutil.ParseToken(parts[1])
+		claims, err := parser.ParseToken(parts[1])
 		if err != nil {
+			if public {
+				c.Next()
+				return
+			}
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error": "invalid token",
 			})
 			return
 		}
 
-		c.Request.Header.Set(
-			"X-User-Id",
-			strconv.FormatUint(uint64(claims.UserID), 10),
-		)
-		c.Request.Header.Set(
-			"X-User-Role",
-			claims.Role,
-		)
+		if isRevoked(c, revoked, claims) {
+			if public {
+				c.Next()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "token revoked",
+			})
+			return
+		}
+
+		c.Set(ClaimsKey, claims)
+
+		signer.Sign(c.Request.Header, claims.UserID, claims.Role)
 
 		c.Next()
 	}
 }
+
+// isRevoked при недоступности хранилища пропускает токен: отказ Redis
+// не должен класть весь API, а срок жизни access-токена и так короткий.
+func isRevoked(c *gin.Context, store revocation.Store, claims *jwtutil.Claims) bool {
+	token := revocation.Token{
+		ID:        claims.ID,
+		SessionID: claims.SessionID,
+		UserID:    claims.UserID,
+	}
+	if claims.IssuedAt != nil {
+		token.IssuedAt = claims.IssuedAt.Time
+	}
+
+	revoked, err := store.IsRevoked(c.Request.Context(), token)
+	if err != nil {
+		slog.Warn("revocation check failed, allowing token", "error", err, "user_id", claims.UserID)
+		return false
+	}
+	return revoked
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gateway/jwtutil"
+	"gateway/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit применяет все подходящие к запросу политики.
+// Запрос отклоняется, если хотя бы одна из них исчерпана.
+// policies вызывается на каждый запрос, чтобы подхватывать перезагруженный конфиг.
+func RateLimit(limiter ratelimit.Limiter, policies func() []ratelimit.Policy, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			tightest *ratelimit.Result
+			denied   *ratelimit.Result
+		)
+
+		for _, policy := range policies() {
+			if !policy.Matches(c.Request.Method, c.Request.URL.Path) {
+				continue
+			}
+
+			key := policy.Name + ":" + rateLimitSubject(c, policy.KeyBy)
+
+			res, err := limiter.Allow(c.Request.Context(), key, policy.Limit)
+			if err != nil {
+				// лимитер не должен ронять запросы
+				logger.Error("rate limiter failed", "error", err, "policy", policy.Name)
+				continue
+			}
+
+			if tightest == nil || res.Remaining < tightest.Remaining {
+				r := res
+				tightest = &r
+			}
+			if !res.Allowed && denied == nil {
+				r := res
+				denied = &r
+			}
+		}
+
+		if denied != nil {
+			setRateLimitHeaders(c, *denied)
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(denied.ResetAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": "too many requests",
+			})
+			return
+		}
+
+		if tightest != nil {
+			setRateLimitHeaders(c, *tightest)
+		}
+
+		c.Next()
+	}
+}
+
+func rateLimitSubject(c *gin.Context, keyBy ratelimit.KeyBy) string {
+	if keyBy == ratelimit.KeyByUser {
+		if claims, ok := ClaimsFromContext(c); ok {
+			return "user:" + strconv.FormatUint(uint64(claims.UserID), 10)
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func setRateLimitHeaders(c *gin.Context, res ratelimit.Result) {
+	c.Header("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// ClaimsFromContext возвращает claims, сохранённые JWTAuth.
+func ClaimsFromContext(c *gin.Context) (*jwtutil.Claims, bool) {
+	v, ok := c.Get(ClaimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := v.(*jwtutil.Claims)
+	return claims, ok
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"gateway/routing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RouteKey = "gateway_route"
+
+// ResolveRoute находит маршрут в текущей таблице и кладёт его в контекст.
+func ResolveRoute(holder *routing.Holder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, allowed, err := holder.Table().Match(c.Request.Method, c.Request.URL.Path)
+		if err != nil {
+			if errors.Is(err, routing.ErrMethodNotAllowed) {
+				c.Header("Allow", strings.Join(allowed, ", "))
+				c.AbortWithStatusJSON(http.StatusMethodNotAllowed, gin.H{
+					"error": "method not allowed",
+				})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+				"error": "route not found",
+			})
+			return
+		}
+
+		c.Set(RouteKey, route)
+		c.Next()
+	}
+}
+
+func RouteFromContext(c *gin.Context) (*routing.Route, bool) {
+	v, ok := c.Get(RouteKey)
+	if !ok {
+		return nil, false
+	}
+	route, ok := v.(*routing.Route)
+	return route, ok
+}
+
+// RequireRouteRoles проверяет роли, которые требует маршрут.
+func RequireRouteRoles() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, ok := RouteFromContext(c)
+		if !ok || route.Public || len(route.Roles) == 0 {
+			c.Next()
+			return
+		}
+
+		claims, ok := ClaimsFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "unauthorized",
+			})
+			return
+		}
+
+		if !route.AllowsRole(claims.Role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "insufficient permissions",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireMFA не пускает пользователей с ролями из mfa_required_roles,
+// если их сессия не подтверждена вторым фактором.
+func RequireMFA() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, ok := RouteFromContext(c)
+		if !ok || len(route.MFARoles) == 0 {
+			c.Next()
+			return
+		}
+
+		claims, ok := ClaimsFromContext(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		if route.RequiresMFA(claims.Role) && !claims.MFA {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "two-factor authentication required",
+				"code":  "mfa_required",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireVerifiedEmail не пускает на маршрут пользователей
+// с неподтверждённой почтой.
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route, ok := RouteFromContext(c)
+		if !ok || !route.RequireVerifiedEmail {
+			c.Next()
+			return
+		}
+
+		claims, ok := ClaimsFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "unauthorized",
+			})
+			return
+		}
+
+		if !claims.EmailVerified {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "email is not verified",
+				"code":  "email_not_verified",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,52 +1,130 @@
 package main
 
 import (
-	"bytes"
-	"io"
+	"context"
+	"encoding/json"
+	"errors"
+	"gateway/middleware"
+	"gateway/resilience"
+	"gateway/routing"
+	"log/slog"
+	"math"
+	"net"
 	"net/http"
+	"net/http/httputil"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func proxyToService(baseURL string) gin.HandlerFunc {
-	return func(c *gin.Context) {
+// upstreamTransport общий пул соединений для всех апстримов,
+// чтобы не открывать новое TCP-соединение на каждый запрос.
+var upstreamTransport = &http.Transport{
+	DialContext: (&net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          200,
+	MaxIdleConnsPerHost:   50,
+	IdleConnTimeout:       90 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
 
-		targetPath := strings.TrimPrefix(c.Request.URL.Path, "/api")
+func newReverseProxy(upstream *routing.Upstream, breakers *resilience.Registry) *httputil.ReverseProxy {
+	target := upstream.URL
+	breaker := breakers.Get(upstream.Name, upstream.Breaker)
 
-		targetURL := baseURL + targetPath
-		if c.Request.URL.RawQuery != "" {
-			targetURL += "?" + c.Request.URL.RawQuery
-		}
+	return &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.Out.URL.Scheme = target.Scheme
+			r.Out.URL.Host = target.Host
+			r.Out.URL.Path = joinPath(target.Path, strings.TrimPrefix(r.In.URL.Path, "/api"))
+			r.Out.URL.RawPath = ""
+			r.Out.URL.RawQuery = r.In.URL.RawQuery
+			r.Out.Host = target.Host
 
-		body, _ := io.ReadAll(c.Request.Body)
+			// X-Forwarded-For / X-Forwarded-Host / X-Forwarded-Proto
+			r.SetXForwarded()
+			// за доверенным балансировщиком адрес клиента — не RemoteAddr
+			if ip, ok := r.In.Context().Value(clientIPKey{}).(string); ok {
+				r.Out.Header.Set("X-Forwarded-For", ip)
+			}
+		},
+		Transport: resilience.NewTransport(
+			upstreamTransport,
+			breaker,
+			upstream.Timeout,
+			resilience.RetryPolicy{Retries: upstream.Retries},
+		),
+		// X-Request-Id клиенту уже выставил middleware.RequestID,
+		// эхо от сервиса задублировало бы заголовок
+		ModifyResponse: func(resp *http.Response) error {
+			resp.Header.Del(middleware.RequestIDHeader)
+			return nil
+		},
+		// -1 — сбрасываем буфер после каждой записи, чтобы
+		// chunked-ответы и выгрузки уходили клиенту сразу
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			switch {
+			case errors.Is(err, resilience.ErrCircuitOpen):
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(breaker.RetryAfter().Seconds()))))
+				writeProxyError(w, http.StatusServiceUnavailable, "service temporarily unavailable")
+				return
+			case errors.Is(err, resilience.ErrUpstreamTimeout), errors.Is(err, context.DeadlineExceeded):
+				writeProxyError(w, http.StatusGatewayTimeout, "upstream timeout")
+				return
+			}
+			slog.Warn("upstream request failed",
+				"error", err,
+				"upstream", upstream.Name,
+				"path", r.URL.Path,
+				"request_id", r.Header.Get(middleware.RequestIDHeader),
+			)
+			writeProxyError(w, http.StatusBadGateway, "service unavailable")
+		},
+	}
+}
 
-		req, err := http.NewRequest(
-			c.Request.Method,
-			targetURL,
-			bytes.NewReader(body),
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
+func writeProxyError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
 
-		req.Header = c.Request.Header.Clone()
+// proxyToRoute отправляет запрос в апстрим маршрута, найденного ResolveRoute.
+func proxyToRoute(c *gin.Context) {
+	route, ok := middleware.RouteFromContext(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "route not found"})
+		return
+	}
 
-		client := &http.Client{}
-		resp, err := client.Do(req)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "service unavailable"})
-			return
-		}
-		defer resp.Body.Close()
+	if route.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), route.Timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+	}
 
-		respBody, _ := io.ReadAll(resp.Body)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), clientIPKey{}, c.ClientIP()))
+	route.Upstream.Proxy.ServeHTTP(c.Writer, c.Request)
+}
+
+// clientIPKey — адрес клиента, определённый gin с учётом доверенных прокси.
+type clientIPKey struct{}
 
-		c.Data(
-			resp.StatusCode,
-			resp.Header.Get("Content-Type"),
-			respBody,
-		)
+func joinPath(base, p string) string {
+	switch {
+	case base == "" || base == "/":
+		if p == "" {
+			return "/"
+		}
+		return p
+	case p == "":
+		return base
 	}
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(p, "/")
 }
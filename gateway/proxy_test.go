@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"gateway/middleware"
+	"gateway/ratelimit"
+	"gateway/resilience"
+	"gateway/routing"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(t *testing.T, upstreamURL string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := fmt.Sprintf(`
+upstreams:
+  event:
+    url: %s
+    timeout: 2s
+routes:
+  - prefix: /api/events
+    upstream: event
+    public: true
+`, upstreamURL)
+
+	path := filepath.Join(t.TempDir(), "routes.yaml")
+	if err := os.WriteFile(path, []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	breakers := resilience.NewRegistry()
+	routes, err := routing.NewHolder(path, func(upstream *routing.Upstream) http.Handler {
+		return newReverseProxy(upstream, breakers)
+	})
+	if err != nil {
+		t.Fatalf("failed to load routes: %v", err)
+	}
+
+	r, err := newEngine()
+	if err != nil {
+		t.Fatalf("newEngine: %v", err)
+	}
+	r.Use(middleware.RequestID())
+	r.Any("/api/*path",
+		middleware.ResolveRoute(routes),
+		middleware.RateLimit(ratelimit.NewMemoryLimiter(), func() []ratelimit.Policy {
+			return []ratelimit.Policy{{
+				Name:  "login",
+				Path:  "/api/events/login",
+				KeyBy: ratelimit.KeyByIP,
+				Limit: ratelimit.Limit{Requests: 1, Window: time.Minute},
+			}}
+		}, slog.Default()),
+		proxyToRoute,
+	)
+	return r
+}
+
+func newTestGateway(t *testing.T, upstream http.Handler) *httptest.Server {
+	t.Helper()
+
+	backend := httptest.NewServer(upstream)
+	t.Cleanup(backend.Close)
+
+	gw := httptest.NewServer(newTestRouter(t, backend.URL))
+	t.Cleanup(gw.Close)
+	return gw
+}
+
+func TestProxy_ForwardsPathQueryAndHeaders(t *testing.T) {
+	var gotPath, gotQuery, gotXFF, gotProto, gotConnHeader, gotUser string
+
+	gw := newTestGateway(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		gotXFF = r.Header.Get("X-Forwarded-For")
+		gotProto = r.Header.Get("X-Forwarded-Proto")
+		gotConnHeader = r.Header.Get("X-Hop")
+		gotUser = r.Header.Get("X-User-Id")
+
+		w.Header().Set("Location", "/events/7")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.Header().Set("Connection", "close")
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	req, _ := http.NewRequest(http.MethodPost, gw.URL+"/api/events/7?x=1", strings.NewReader(`{}`))
+	req.Header.Set("Connection", "X-Hop")
+	req.Header.Set("X-Hop", "secret")
+	req.Header.Set("X-User-Id", "42")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+	if gotPath != "/events/7" || gotQuery != "x=1" {
+		t.Fatalf("unexpected upstream url: %s?%s", gotPath, gotQuery)
+	}
+	if gotXFF == "" || gotProto != "http" {
+		t.Fatalf("expected X-Forwarded-For and X-Forwarded-Proto, got %q %q", gotXFF, gotProto)
+	}
+	if gotConnHeader != "" {
+		t.Fatalf("hop-by-hop header leaked to upstream: %q", gotConnHeader)
+	}
+	if gotUser != "42" {
+		t.Fatalf("expected end-to-end header to be forwarded, got %q", gotUser)
+	}
+	if resp.Header.Get("Location") != "/events/7" || resp.Header.Get("ETag") != `"v1"` {
+		t.Fatalf("response headers lost: %v", resp.Header)
+	}
+	if len(resp.Header.Values("Set-Cookie")) != 2 {
+		t.Fatalf("expected 2 Set-Cookie headers, got %v", resp.Header.Values("Set-Cookie"))
+	}
+}
+
+func TestProxy_StreamsChunkedResponse(t *testing.T) {
+	release := make(chan struct{})
+
+	gw := newTestGateway(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = io.WriteString(w, "id,email\n")
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = io.WriteString(w, "1,a@b.c\n")
+	}))
+
+	resp, err := http.Get(gw.URL + "/api/events/1/attendees")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// первая строка должна прийти до того, как апстрим закончит ответ
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	close(release)
+	if err != nil {
+		t.Fatalf("failed to read first chunk: %v", err)
+	}
+	if line != "id,email\n" {
+		t.Fatalf("unexpected first chunk: %q", line)
+	}
+	if resp.Header.Get("Content-Type") != "text/csv" {
+		t.Fatalf("content type lost: %q", resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestProxy_UpstreamDown(t *testing.T) {
+	gw := httptest.NewServer(newTestRouter(t, "http://127.0.0.1:1"))
+	defer gw.Close()
+
+	resp, err := http.Get(gw.URL + "/api/events/1")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d", resp.StatusCode)
+	}
+}
+
+func TestProxy_UpstreamTimeout(t *testing.T) {
+	release := make(chan struct{})
+	gw := newTestGateway(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer close(release)
+
+	resp, err := http.Post(gw.URL+"/api/events", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504, got %d", resp.StatusCode)
+	}
+}
+
+func TestProxy_PropagatesRequestID(t *testing.T) {
+	var gotID string
+	gw := newTestGateway(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = r.Header.Get("X-Request-Id")
+		w.Header().Set("X-Request-Id", gotID)
+	}))
+
+	resp, err := http.Get(gw.URL + "/api/events")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotID == "" {
+		t.Fatalf("expected generated request id to reach upstream")
+	}
+	if ids := resp.Header.Values("X-Request-Id"); len(ids) != 1 || ids[0] != gotID {
+		t.Fatalf("expected single X-Request-Id %q in response, got %v", gotID, ids)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, gw.URL+"/api/events", nil)
+	req.Header.Set("X-Request-Id", "client-abc-1")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotID != "client-abc-1" {
+		t.Fatalf("expected client request id to be kept, got %q", gotID)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, gw.URL+"/api/events", nil)
+	req.Header.Set("X-Request-Id", "has spaces")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotID == "has spaces" || gotID == "" {
+		t.Fatalf("expected invalid request id to be replaced, got %q", gotID)
+	}
+}
+
+func TestProxy_IgnoresSpoofedForwardedFor(t *testing.T) {
+	var gotXFF string
+	gw := newTestGateway(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotXFF = r.Header.Get("X-Forwarded-For")
+	}))
+
+	statuses := make([]int, 0, 2)
+	for _, spoofed := range []string{"1.2.3.4", "5.6.7.8"} {
+		req, _ := http.NewRequest(http.MethodPost, gw.URL+"/api/events/login", nil)
+		req.Header.Set("X-Forwarded-For", spoofed)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		statuses = append(statuses, resp.StatusCode)
+	}
+
+	// подмена X-Forwarded-For не даёт новый бакет лимита
+	if statuses[0] != http.StatusOK || statuses[1] != http.StatusTooManyRequests {
+		t.Fatalf("expected 200 then 429, got %v", statuses)
+	}
+	if gotXFF != "127.0.0.1" {
+		t.Fatalf("expected real client address upstream, got %q", gotXFF)
+	}
+}
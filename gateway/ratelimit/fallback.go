@@ -0,0 +1,28 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+)
+
+// FallbackLimiter обращается к primary, а при его ошибке
+// (например, Redis недоступен) считает запросы в secondary.
+type FallbackLimiter struct {
+	primary   Limiter
+	secondary Limiter
+	logger    *slog.Logger
+}
+
+func NewFallbackLimiter(primary, secondary Limiter, logger *slog.Logger) *FallbackLimiter {
+	return &FallbackLimiter{primary: primary, secondary: secondary, logger: logger}
+}
+
+func (l *FallbackLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	res, err := l.primary.Allow(ctx, key, limit)
+	if err == nil {
+		return res, nil
+	}
+
+	l.logger.Warn("rate limiter backend unavailable, using in-memory fallback", "error", err)
+	return l.secondary.Allow(ctx, key, limit)
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidLimit = errors.New("invalid rate limit")
+
+// Limit — не больше Requests запросов за окно Window.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// ParseLimit разбирает лимит в формате "<запросы>/<окно>", например "5/1m" или "100/s".
+func ParseLimit(s string) (Limit, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), "/", 2)
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, s)
+	}
+
+	requests, err := strconv.Atoi(parts[0])
+	if err != nil || requests < 1 {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, s)
+	}
+
+	window := parts[1]
+	switch window {
+	case "s", "m", "h":
+		window = "1" + window
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, s)
+	}
+
+	return Limit{Requests: requests, Window: d}, nil
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Window)
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+}
+
+// Limiter считает запросы по ключу в фиксированном окне.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+func newResult(count int, limit Limit, resetAfter time.Duration) Result {
+	remaining := limit.Requests - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	if resetAfter <= 0 {
+		resetAfter = limit.Window
+	}
+	return Result{
+		Allowed:    count <= limit.Requests,
+		Limit:      limit.Requests,
+		Remaining:  remaining,
+		ResetAfter: resetAfter,
+	}
+}
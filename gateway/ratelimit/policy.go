@@ -0,0 +1,61 @@
+package ratelimit
+
+import "strings"
+
+type KeyBy string
+
+const (
+	KeyByIP   KeyBy = "ip"
+	KeyByUser KeyBy = "user"
+)
+
+// Policy описывает лимит для группы маршрутов.
+//
+// Path — шаблон пути: ":name" совпадает с одним сегментом,
+// завершающий "*" — с любым остатком пути.
+// Пустой Method означает любой метод.
+type Policy struct {
+	Name   string
+	Method string
+	Path   string
+	KeyBy  KeyBy
+	Limit  Limit
+}
+
+func (p Policy) Matches(method, path string) bool {
+	if p.Method != "" && !strings.EqualFold(p.Method, method) {
+		return false
+	}
+	return MatchPath(p.Path, path)
+}
+
+// MatchPath сравнивает путь запроса с шаблоном вида "/api/ticket/events/:id/tickets" или "/api/auth/*".
+func MatchPath(pattern, path string) bool {
+	patternSegs := splitPath(pattern)
+	pathSegs := splitPath(path)
+
+	for i, seg := range patternSegs {
+		if seg == "*" {
+			return true
+		}
+		if i >= len(pathSegs) {
+			return false
+		}
+		if strings.HasPrefix(seg, ":") {
+			continue
+		}
+		if seg != pathSegs[i] {
+			return false
+		}
+	}
+
+	return len(patternSegs) == len(pathSegs)
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
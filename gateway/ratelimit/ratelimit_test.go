@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	cases := map[string]Limit{
+		"5/1m":    {Requests: 5, Window: time.Minute},
+		"100/s":   {Requests: 100, Window: time.Second},
+		" 10/30s": {Requests: 10, Window: 30 * time.Second},
+	}
+	for in, want := range cases {
+		got, err := ParseLimit(in)
+		if err != nil {
+			t.Fatalf("ParseLimit(%q) unexpected error: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("ParseLimit(%q) = %v, want %v", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "5", "0/1m", "x/1m", "5/xx", "5/-1s"} {
+		if _, err := ParseLimit(in); !errors.Is(err, ErrInvalidLimit) {
+			t.Fatalf("ParseLimit(%q) expected ErrInvalidLimit, got %v", in, err)
+		}
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	cases := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/api/auth/login", "/api/auth/login", true},
+		{"/api/auth/login", "/api/auth/login/extra", false},
+		{"/api/auth/*", "/api/auth/register", true},
+		{"/api/auth/*", "/api/users/me", false},
+		{"/api/ticket/events/:id/tickets", "/api/ticket/events/12/tickets", true},
+		{"/api/ticket/events/:id/tickets", "/api/ticket/events/12/ticket-types", false},
+		{"/api/*", "/api/events", true},
+	}
+	for _, tc := range cases {
+		if got := MatchPath(tc.pattern, tc.path); got != tc.want {
+			t.Fatalf("MatchPath(%q, %q) = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestMemoryLimiter_WindowResets(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewMemoryLimiter()
+	l.now = func() time.Time { return now }
+
+	limit := Limit{Requests: 2, Window: time.Minute}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		res, _ := l.Allow(ctx, "k", limit)
+		if !res.Allowed {
+			t.Fatalf("request %d should be allowed", i+1)
+		}
+	}
+
+	res, _ := l.Allow(ctx, "k", limit)
+	if res.Allowed || res.Remaining != 0 {
+		t.Fatalf("third request should be denied, got %+v", res)
+	}
+	if res.ResetAfter != time.Minute {
+		t.Fatalf("expected reset in 1m, got %v", res.ResetAfter)
+	}
+
+	now = now.Add(time.Minute)
+	res, _ = l.Allow(ctx, "k", limit)
+	if !res.Allowed || res.Remaining != 1 {
+		t.Fatalf("new window should allow request, got %+v", res)
+	}
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("redis down")
+}
+
+func TestFallbackLimiter_UsesSecondaryOnError(t *testing.T) {
+	l := NewFallbackLimiter(failingLimiter{}, NewMemoryLimiter(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	res, err := l.Allow(context.Background(), "k", Limit{Requests: 1, Window: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Allowed {
+		t.Fatalf("expected request to be allowed by fallback")
+	}
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fixedWindowScript атомарно увеличивает счётчик и выставляет TTL окна
+// при первом запросе. Возвращает {счётчик, оставшийся TTL в мс}.
+var fixedWindowScript = redis.NewScript(`
+local current = redis.call("INCR", KEYS[1])
+if current == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+local ttl = redis.call("PTTL", KEYS[1])
+return {current, ttl}
+`)
+
+// RedisLimiter делит счётчики между всеми репликами gateway.
+type RedisLimiter struct {
+	client redis.Scripter
+	prefix string
+}
+
+func NewRedisLimiter(client redis.Scripter) *RedisLimiter {
+	return &RedisLimiter{client: client, prefix: "ratelimit:"}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	res, err := fixedWindowScript.Run(
+		ctx,
+		l.client,
+		[]string{l.prefix + key},
+		limit.Window.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(int(res[0]), limit, time.Duration(res[1])*time.Millisecond), nil
+}
@@ -0,0 +1,215 @@
+package resilience
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half_open"
+)
+
+type BreakerSettings struct {
+	// FailureThreshold — сколько неудач подряд открывают цепь.
+	FailureThreshold int
+	// OpenTimeout — сколько цепь остаётся открытой до пробных запросов.
+	OpenTimeout time.Duration
+	// HalfOpenRequests — сколько пробных запросов пропускается одновременно.
+	HalfOpenRequests int
+}
+
+func (s BreakerSettings) withDefaults() BreakerSettings {
+	if s.FailureThreshold < 1 {
+		s.FailureThreshold = 5
+	}
+	if s.OpenTimeout <= 0 {
+		s.OpenTimeout = 30 * time.Second
+	}
+	if s.HalfOpenRequests < 1 {
+		s.HalfOpenRequests = 1
+	}
+	return s
+}
+
+// CircuitBreaker перестаёт пускать запросы в апстрим после серии
+// неудач и через OpenTimeout проверяет его пробными запросами.
+type CircuitBreaker struct {
+	mu       sync.Mutex
+	name     string
+	settings BreakerSettings
+	now      func() time.Time
+
+	state            State
+	failures         int
+	openedAt         time.Time
+	halfOpenInFlight int
+}
+
+func NewCircuitBreaker(name string, settings BreakerSettings) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:     name,
+		settings: settings.withDefaults(),
+		now:      time.Now,
+		state:    StateClosed,
+	}
+}
+
+// Allow решает, можно ли отправить запрос. При успехе возвращает
+// функцию, которую нужно вызвать с результатом запроса.
+func (b *CircuitBreaker) Allow() (func(success bool), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case StateOpen:
+		return nil, ErrCircuitOpen
+	case StateHalfOpen:
+		if b.halfOpenInFlight >= b.settings.HalfOpenRequests {
+			return nil, ErrCircuitOpen
+		}
+		b.halfOpenInFlight++
+		return b.doneHalfOpen, nil
+	default:
+		return b.doneClosed, nil
+	}
+}
+
+// currentState переводит открытую цепь в half-open по истечении таймаута.
+func (b *CircuitBreaker) currentState() State {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.state = StateHalfOpen
+		b.halfOpenInFlight = 0
+	}
+	return b.state
+}
+
+func (b *CircuitBreaker) doneClosed(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != StateClosed {
+		return
+	}
+	if success {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.settings.FailureThreshold {
+		b.open()
+	}
+}
+
+func (b *CircuitBreaker) doneHalfOpen(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != StateHalfOpen {
+		return
+	}
+	b.halfOpenInFlight--
+	if success {
+		b.state = StateClosed
+		b.failures = 0
+		return
+	}
+	b.open()
+}
+
+func (b *CircuitBreaker) open() {
+	b.state = StateOpen
+	b.openedAt = b.now()
+	b.halfOpenInFlight = 0
+}
+
+// RetryAfter — сколько осталось до пробных запросов, если цепь открыта.
+func (b *CircuitBreaker) RetryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.currentState() != StateOpen {
+		return 0
+	}
+	return b.settings.OpenTimeout - b.now().Sub(b.openedAt)
+}
+
+func (b *CircuitBreaker) updateSettings(settings BreakerSettings) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.settings = settings.withDefaults()
+}
+
+type BreakerSnapshot struct {
+	Name             string     `json:"name"`
+	State            State      `json:"state"`
+	Failures         int        `json:"consecutive_failures"`
+	FailureThreshold int        `json:"failure_threshold"`
+	OpenedAt         *time.Time `json:"opened_at,omitempty"`
+	OpenTimeout      string     `json:"open_timeout"`
+}
+
+func (b *CircuitBreaker) Snapshot() BreakerSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := BreakerSnapshot{
+		Name:             b.name,
+		State:            b.currentState(),
+		Failures:         b.failures,
+		FailureThreshold: b.settings.FailureThreshold,
+		OpenTimeout:      b.settings.OpenTimeout.String(),
+	}
+	if s.State != StateClosed {
+		openedAt := b.openedAt
+		s.OpenedAt = &openedAt
+	}
+	return s
+}
+
+// Registry хранит брейкеры по имени апстрима, чтобы их состояние
+// переживало перезагрузку таблицы маршрутов.
+type Registry struct {
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+func NewRegistry() *Registry {
+	return &Registry{breakers: make(map[string]*CircuitBreaker)}
+}
+
+func (r *Registry) Get(name string, settings BreakerSettings) *CircuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if b, ok := r.breakers[name]; ok {
+		b.updateSettings(settings)
+		return b
+	}
+	b := NewCircuitBreaker(name, settings)
+	r.breakers[name] = b
+	return b
+}
+
+func (r *Registry) Snapshots() []BreakerSnapshot {
+	r.mu.Lock()
+	breakers := make([]*CircuitBreaker, 0, len(r.breakers))
+	for _, b := range r.breakers {
+		breakers = append(breakers, b)
+	}
+	r.mu.Unlock()
+
+	out := make([]BreakerSnapshot, 0, len(breakers))
+	for _, b := range breakers {
+		out = append(out, b.Snapshot())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
@@ -0,0 +1,140 @@
+package resilience
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := NewCircuitBreaker("ticket", BreakerSettings{FailureThreshold: 2, OpenTimeout: 10 * time.Second})
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		done, err := b.Allow()
+		if err != nil {
+			t.Fatalf("attempt %d should be allowed: %v", i+1, err)
+		}
+		done(false)
+	}
+
+	if _, err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected open circuit, got %v", err)
+	}
+	if got := b.RetryAfter(); got != 10*time.Second {
+		t.Fatalf("expected retry after 10s, got %v", got)
+	}
+
+	now = now.Add(10 * time.Second)
+	probe, err := b.Allow()
+	if err != nil {
+		t.Fatalf("half-open probe should be allowed: %v", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("only one probe should pass in half-open state, got %v", err)
+	}
+	probe(true)
+
+	if s := b.Snapshot(); s.State != StateClosed || s.Failures != 0 {
+		t.Fatalf("expected closed breaker after successful probe, got %+v", s)
+	}
+}
+
+func TestCircuitBreaker_FailedProbeReopens(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := NewCircuitBreaker("ticket", BreakerSettings{FailureThreshold: 1, OpenTimeout: time.Second})
+	b.now = func() time.Time { return now }
+
+	done, _ := b.Allow()
+	done(false)
+
+	now = now.Add(time.Second)
+	probe, err := b.Allow()
+	if err != nil {
+		t.Fatalf("probe should be allowed: %v", err)
+	}
+	probe(false)
+
+	if s := b.Snapshot(); s.State != StateOpen {
+		t.Fatalf("expected breaker to reopen, got %s", s.State)
+	}
+}
+
+func TestTransport_RetriesIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tr := NewTransport(http.DefaultTransport, NewCircuitBreaker("event", BreakerSettings{}), time.Second,
+		RetryPolicy{Retries: 2, BaseDelay: time.Millisecond})
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Fatalf("expected 200 after 3 calls, got %d after %d", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestTransport_DoesNotRetryPost(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	tr := NewTransport(http.DefaultTransport, NewCircuitBreaker("ticket", BreakerSettings{}), time.Second,
+		RetryPolicy{Retries: 3, BaseDelay: time.Millisecond})
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{}`))
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls.Load() != 1 {
+		t.Fatalf("POST must not be retried, got %d calls", calls.Load())
+	}
+}
+
+func TestTransport_TimeoutTripsBreaker(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	breaker := NewCircuitBreaker("ticket", BreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute})
+	tr := NewTransport(http.DefaultTransport, breaker, 20*time.Millisecond, RetryPolicy{})
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if _, err := tr.RoundTrip(req); !errors.Is(err, ErrUpstreamTimeout) {
+		t.Fatalf("expected ErrUpstreamTimeout, got %v", err)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, srv.URL, nil)
+	if _, err := tr.RoundTrip(req); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+var ErrUpstreamTimeout = errors.New("upstream did not respond in time")
+
+type RetryPolicy struct {
+	// Retries — число повторов сверх первой попытки.
+	Retries   int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// Transport оборачивает RoundTripper апстрима: таймаут на попытку,
+// повторы идемпотентных запросов и circuit breaker.
+type Transport struct {
+	base    http.RoundTripper
+	breaker *CircuitBreaker
+	timeout time.Duration
+	retry   RetryPolicy
+}
+
+func NewTransport(base http.RoundTripper, breaker *CircuitBreaker, timeout time.Duration, retry RetryPolicy) *Transport {
+	if retry.BaseDelay <= 0 {
+		retry.BaseDelay = 50 * time.Millisecond
+	}
+	if retry.MaxDelay <= 0 {
+		retry.MaxDelay = time.Second
+	}
+	return &Transport{base: base, breaker: breaker, timeout: timeout, retry: retry}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempts := 1
+	if isRetryable(req) {
+		attempts += t.retry.Retries
+	}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if werr := sleepCtx(req.Context(), t.backoff(attempt)); werr != nil {
+				return nil, werr
+			}
+		}
+
+		resp, err = t.roundTripOnce(req)
+		if errors.Is(err, ErrCircuitOpen) || req.Context().Err() != nil {
+			return resp, err
+		}
+		if err == nil && !isFailureStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		if attempt < attempts-1 && resp != nil {
+			// ответ будет заменён следующей попыткой
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+	}
+
+	return resp, err
+}
+
+func (t *Transport) roundTripOnce(req *http.Request) (*http.Response, error) {
+	done, err := t.breaker.Allow()
+	if err != nil {
+		return nil, err
+	}
+
+	if t.timeout <= 0 {
+		resp, err := t.base.RoundTrip(req)
+		done(err == nil && !isFailureStatus(resp.StatusCode))
+		return resp, err
+	}
+
+	// таймаут ограничивает ожидание заголовков ответа,
+	// тело затем стримится без ограничения по времени
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(t.timeout, cancel)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if !timer.Stop() && req.Context().Err() == nil {
+		if err == nil {
+			resp.Body.Close()
+		}
+		cancel()
+		done(false)
+		return nil, ErrUpstreamTimeout
+	}
+	if err != nil {
+		cancel()
+		done(false)
+		return nil, err
+	}
+
+	// тело с апгрейдом соединения (101) должно остаться io.ReadWriteCloser
+	if _, upgraded := resp.Body.(io.ReadWriteCloser); !upgraded {
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	}
+	done(!isFailureStatus(resp.StatusCode))
+	return resp, nil
+}
+
+// backoff — экспоненциальная задержка с полным джиттером.
+func (t *Transport) backoff(attempt int) time.Duration {
+	d := t.retry.BaseDelay << (attempt - 1)
+	if d <= 0 || d > t.retry.MaxDelay {
+		d = t.retry.MaxDelay
+	}
+	return time.Duration(rand.Int64N(int64(d) + 1))
+}
+
+// isRetryable — повторяем только идемпотентные запросы без тела:
+// входящее тело стримится и не может быть прочитано повторно.
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete, http.MethodPut:
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody
+}
+
+func isFailureStatus(code int) bool {
+	return code == http.StatusBadGateway ||
+		code == http.StatusServiceUnavailable ||
+		code == http.StatusGatewayTimeout
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,141 @@
+// Package revocation проверяет, не отозван ли access-токен. Отзыв
+// записывает user-service при logout, деактивации и смене роли.
+package revocation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Формат ключей должен совпадать с user-service (repository.RevocationRepository).
+const (
+	tokenPrefix   = "revoked:jti:"
+	sessionPrefix = "revoked:sid:"
+	userPrefix    = "revoked:user:"
+)
+
+// Token — поля access-токена, по которым он может быть отозван.
+type Token struct {
+	ID        string
+	SessionID uint
+	UserID    uint
+	IssuedAt  time.Time
+}
+
+type Store interface {
+	IsRevoked(ctx context.Context, token Token) (bool, error)
+}
+
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) IsRevoked(ctx context.Context, token Token) (bool, error) {
+	keys := []string{tokenPrefix + token.ID}
+	if token.SessionID != 0 {
+		keys = append(keys, sessionPrefix+strconv.FormatUint(uint64(token.SessionID), 10))
+	}
+
+	pipe := s.client.Pipeline()
+	exists := pipe.Exists(ctx, keys...)
+	cutoff := pipe.Get(ctx, userPrefix+strconv.FormatUint(uint64(token.UserID), 10))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+
+	if exists.Val() > 0 {
+		return true, nil
+	}
+	if v, err := cutoff.Int64(); err == nil && issuedBefore(token.IssuedAt, v) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// issuedBefore — токен выдан не позже отметки отзыва всех токенов
+// пользователя. iat и отметка хранятся с точностью до секунды, поэтому
+// токен той же секунды тоже считается отозванным: выданный до отзыва
+// иначе пережил бы его, а выданный сразу после придётся обновить ещё раз.
+func issuedBefore(issuedAt time.Time, cutoff int64) bool {
+	return issuedAt.Unix() <= cutoff
+}
+
+// NopStore используется, когда Redis не настроен.
+type NopStore struct{}
+
+func (NopStore) IsRevoked(context.Context, Token) (bool, error) {
+	return false, nil
+}
+
+type cacheEntry struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+// CachedStore кэширует ответы на короткое время, чтобы не ходить
+// в Redis на каждый запрос. Отзыв вступает в силу с задержкой до ttl.
+type CachedStore struct {
+	next       Store
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func NewCachedStore(next Store, ttl time.Duration) *CachedStore {
+	return &CachedStore{
+		next:       next,
+		ttl:        ttl,
+		maxEntries: 100_000,
+		now:        time.Now,
+		entries:    make(map[string]cacheEntry),
+	}
+}
+
+func (s *CachedStore) IsRevoked(ctx context.Context, token Token) (bool, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	e, ok := s.entries[token.ID]
+	s.mu.Unlock()
+	if ok && now.Before(e.expiresAt) {
+		return e.revoked, nil
+	}
+
+	revoked, err := s.next.IsRevoked(ctx, token)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	if len(s.entries) >= s.maxEntries {
+		s.sweep(now)
+	}
+	s.entries[token.ID] = cacheEntry{revoked: revoked, expiresAt: now.Add(s.ttl)}
+	s.mu.Unlock()
+
+	return revoked, nil
+}
+
+// sweep удаляет устаревшие записи, а если их нет — очищает кэш целиком.
+func (s *CachedStore) sweep(now time.Time) {
+	for k, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+	if len(s.entries) >= s.maxEntries {
+		s.entries = make(map[string]cacheEntry)
+	}
+}
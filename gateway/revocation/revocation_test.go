@@ -0,0 +1,75 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type countingStore struct {
+	revoked bool
+	calls   int
+}
+
+func (s *countingStore) IsRevoked(context.Context, Token) (bool, error) {
+	s.calls++
+	return s.revoked, nil
+}
+
+func TestCachedStore_CachesWithinTTL(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	next := &countingStore{}
+	s := NewCachedStore(next, 5*time.Second)
+	s.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if revoked, _ := s.IsRevoked(ctx, Token{ID: "jti-1", UserID: 1, IssuedAt: now}); revoked {
+			t.Fatalf("token should not be revoked")
+		}
+	}
+	if next.calls != 1 {
+		t.Fatalf("expected single backend call, got %d", next.calls)
+	}
+
+	next.revoked = true
+	now = now.Add(5 * time.Second)
+	if revoked, _ := s.IsRevoked(ctx, Token{ID: "jti-1", UserID: 1, IssuedAt: now}); !revoked {
+		t.Fatalf("revocation must be picked up after cache ttl")
+	}
+	if next.calls != 2 {
+		t.Fatalf("expected backend call after ttl, got %d", next.calls)
+	}
+}
+
+func TestCachedStore_SweepsWhenFull(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewCachedStore(&countingStore{}, time.Second)
+	s.now = func() time.Time { return now }
+	s.maxEntries = 2
+
+	ctx := context.Background()
+	s.IsRevoked(ctx, Token{ID: "a"})
+	s.IsRevoked(ctx, Token{ID: "b"})
+	s.IsRevoked(ctx, Token{ID: "c"})
+
+	if len(s.entries) > 2 {
+		t.Fatalf("cache must stay bounded, got %d entries", len(s.entries))
+	}
+}
+
+func TestIssuedBefore_SameSecondIsRevoked(t *testing.T) {
+	cutoff := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[time.Duration]bool{
+		-time.Second:           true,
+		0:                      true,
+		900 * time.Millisecond: true,
+		time.Second:            false,
+	}
+	for offset, want := range cases {
+		if got := issuedBefore(cutoff.Add(offset), cutoff.Unix()); got != want {
+			t.Errorf("issuedBefore(cutoff%+v) = %v, want %v", offset, got, want)
+		}
+	}
+}
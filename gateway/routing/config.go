@@ -0,0 +1,115 @@
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Config — содержимое файла маршрутов gateway (YAML или JSON).
+// В значениях поддерживаются переменные окружения: ${VAR} и ${VAR:-default}.
+type Config struct {
+	Upstreams  map[string]UpstreamConfig `json:"upstreams" yaml:"upstreams"`
+	Routes     []RouteConfig             `json:"routes" yaml:"routes"`
+	RateLimits []RateLimitConfig         `json:"rate_limits" yaml:"rate_limits"`
+	// MFARequiredRoles — ролям из списка закрытые маршруты доступны
+	// только после входа со вторым фактором.
+	MFARequiredRoles []string `json:"mfa_required_roles" yaml:"mfa_required_roles"`
+}
+
+type UpstreamConfig struct {
+	URL string `json:"url" yaml:"url"`
+	// Timeout — сколько ждать заголовков ответа в одной попытке.
+	Timeout Duration `json:"timeout" yaml:"timeout"`
+	// Retries — повторы для идемпотентных запросов без тела.
+	Retries int           `json:"retries" yaml:"retries"`
+	Breaker BreakerConfig `json:"breaker" yaml:"breaker"`
+}
+
+type BreakerConfig struct {
+	FailureThreshold int      `json:"failure_threshold" yaml:"failure_threshold"`
+	OpenTimeout      Duration `json:"open_timeout" yaml:"open_timeout"`
+	HalfOpenRequests int      `json:"half_open_requests" yaml:"half_open_requests"`
+}
+
+type RouteConfig struct {
+	// Prefix — префикс пути по сегментам, ":name" совпадает с любым сегментом.
+	Prefix   string   `json:"prefix" yaml:"prefix"`
+	Upstream string   `json:"upstream" yaml:"upstream"`
+	Public   bool     `json:"public" yaml:"public"`
+	Roles    []string `json:"roles" yaml:"roles"`
+	Methods  []string `json:"methods" yaml:"methods"`
+	// VerifiedEmail — доступ только с подтверждённой почтой.
+	VerifiedEmail bool `json:"verified_email" yaml:"verified_email"`
+	// MFAExempt — маршрут доступен без второго фактора, например для его настройки.
+	MFAExempt bool `json:"mfa_exempt" yaml:"mfa_exempt"`
+	// Timeout — общий дедлайн запроса, включая повторы и чтение тела.
+	Timeout Duration `json:"timeout" yaml:"timeout"`
+}
+
+type RateLimitConfig struct {
+	Name   string `json:"name" yaml:"name"`
+	Method string `json:"method" yaml:"method"`
+	Path   string `json:"path" yaml:"path"`
+	KeyBy  string `json:"key_by" yaml:"key_by"`
+	Limit  string `json:"limit" yaml:"limit"`
+}
+
+// Duration разбирается из строки вида "10s" как в JSON, так и в YAML.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func LoadConfig(path string) (*Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read routes config: %w", err)
+	}
+
+	data := []byte(expandEnv(string(raw)))
+
+	var cfg Config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &cfg)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &cfg)
+	default:
+		return nil, fmt.Errorf("unsupported routes config format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse routes config: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// expandEnv подставляет ${VAR} и ${VAR:-default}.
+func expandEnv(s string) string {
+	return os.Expand(s, func(key string) string {
+		name, def, hasDefault := strings.Cut(key, ":-")
+		if v, ok := os.LookupEnv(name); ok && v != "" {
+			return v
+		}
+		if hasDefault {
+			return def
+		}
+		return ""
+	})
+}
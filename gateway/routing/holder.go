@@ -0,0 +1,66 @@
+package routing
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+)
+
+// Holder хранит текущую таблицу маршрутов и позволяет
+// атомарно заменить её без остановки gateway.
+type Holder struct {
+	path     string
+	newProxy ProxyFactory
+	table    atomic.Pointer[Table]
+}
+
+func NewHolder(path string, newProxy ProxyFactory) (*Holder, error) {
+	h := &Holder{path: path, newProxy: newProxy}
+	if err := h.Reload(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *Holder) Table() *Table {
+	return h.table.Load()
+}
+
+// Reload перечитывает файл маршрутов. При ошибке остаётся прежняя таблица.
+func (h *Holder) Reload() error {
+	cfg, err := LoadConfig(h.path)
+	if err != nil {
+		return err
+	}
+
+	table, err := NewTable(cfg, h.newProxy)
+	if err != nil {
+		return err
+	}
+
+	h.table.Store(table)
+	return nil
+}
+
+// ReloadOnSIGHUP перечитывает маршруты при получении SIGHUP до отмены ctx.
+func (h *Holder) ReloadOnSIGHUP(ctx context.Context, logger *slog.Logger) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	defer signal.Stop(sig)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sig:
+			if err := h.Reload(); err != nil {
+				logger.Error("failed to reload routes, keeping previous table", "error", err, "path", h.path)
+				continue
+			}
+			logger.Info("routes reloaded", "path", h.path)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"gateway/ratelimit"
+	"gateway/resilience"
+)
+
+var (
+	ErrRouteNotFound    = errors.New("route not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+const defaultUpstreamTimeout = 30 * time.Second
+
+// ProxyFactory строит обработчик, проксирующий запросы в апстрим.
+type ProxyFactory func(upstream *Upstream) http.Handler
+
+type Upstream struct {
+	Name    string
+	URL     *url.URL
+	Timeout time.Duration
+	Retries int
+	Breaker resilience.BreakerSettings
+	Proxy   http.Handler
+}
+
+type Route struct {
+	Prefix   string
+	Upstream *Upstream
+	Public   bool
+	Roles    map[string]struct{}
+	Methods  map[string]struct{}
+	// RequireVerifiedEmail — пользователь должен подтвердить почту.
+	RequireVerifiedEmail bool
+	// MFARoles — роли, которым нужен вход со вторым фактором.
+	MFARoles map[string]struct{}
+	// Timeout — общий дедлайн запроса, 0 — без дедлайна.
+	Timeout time.Duration
+
+	segments []string
+}
+
+func (r *Route) AllowsMethod(method string) bool {
+	if len(r.Methods) == 0 {
+		return true
+	}
+	_, ok := r.Methods[method]
+	return ok
+}
+
+func (r *Route) RequiresMFA(role string) bool {
+	_, ok := r.MFARoles[strings.ToLower(role)]
+	return ok
+}
+
+func (r *Route) AllowsRole(role string) bool {
+	if len(r.Roles) == 0 {
+		return true
+	}
+	_, ok := r.Roles[role]
+	return ok
+}
+
+// Table — скомпилированная таблица маршрутов.
+type Table struct {
+	Upstreams  map[string]*Upstream
+	routes     []*Route
+	RateLimits []ratelimit.Policy
+}
+
+// NewTable проверяет конфиг и строит по нему таблицу маршрутов.
+func NewTable(cfg *Config, newProxy ProxyFactory) (*Table, error) {
+	t := &Table{Upstreams: make(map[string]*Upstream, len(cfg.Upstreams))}
+
+	for name, uc := range cfg.Upstreams {
+		target, err := url.Parse(uc.URL)
+		if err != nil || target.Scheme == "" || target.Host == "" {
+			return nil, fmt.Errorf("upstream %q: invalid url %q", name, uc.URL)
+		}
+
+		timeout := time.Duration(uc.Timeout)
+		if timeout == 0 {
+			timeout = defaultUpstreamTimeout
+		}
+
+		if uc.Retries < 0 {
+			return nil, fmt.Errorf("upstream %q: retries cannot be negative", name)
+		}
+
+		upstream := &Upstream{
+			Name:    name,
+			URL:     target,
+			Timeout: timeout,
+			Retries: uc.Retries,
+			Breaker: resilience.BreakerSettings{
+				FailureThreshold: uc.Breaker.FailureThreshold,
+				OpenTimeout:      time.Duration(uc.Breaker.OpenTimeout),
+				HalfOpenRequests: uc.Breaker.HalfOpenRequests,
+			},
+		}
+		upstream.Proxy = newProxy(upstream)
+		t.Upstreams[name] = upstream
+	}
+
+	mfaRoles := toSet(cfg.MFARequiredRoles, strings.ToLower)
+
+	for i, rc := range cfg.Routes {
+		if !strings.HasPrefix(rc.Prefix, "/") {
+			return nil, fmt.Errorf("route #%d: prefix must start with /", i+1)
+		}
+
+		upstream, ok := t.Upstreams[rc.Upstream]
+		if !ok {
+			return nil, fmt.Errorf("route %q: unknown upstream %q", rc.Prefix, rc.Upstream)
+		}
+
+		if rc.Public && len(rc.Roles) > 0 {
+			return nil, fmt.Errorf("route %q: public route cannot require roles", rc.Prefix)
+		}
+		if rc.Public && rc.VerifiedEmail {
+			return nil, fmt.Errorf("route %q: public route cannot require verified email", rc.Prefix)
+		}
+
+		route := &Route{
+			Prefix:   rc.Prefix,
+			Upstream: upstream,
+			Public:   rc.Public,
+			Roles:    toSet(rc.Roles, strings.ToLower),
+			Methods:  toSet(rc.Methods, strings.ToUpper),
+			Timeout:  time.Duration(rc.Timeout),
+			segments: splitPath(rc.Prefix),
+
+			RequireVerifiedEmail: rc.VerifiedEmail,
+		}
+		if !rc.Public && !rc.MFAExempt {
+			route.MFARoles = mfaRoles
+		}
+
+		t.routes = append(t.routes, route)
+	}
+
+	// более длинные префиксы проверяются первыми
+	sort.SliceStable(t.routes, func(i, j int) bool {
+		return specificity(t.routes[i]) > specificity(t.routes[j])
+	})
+
+	for _, rl := range cfg.RateLimits {
+		limit, err := ratelimit.ParseLimit(rl.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit %q: %w", rl.Name, err)
+		}
+
+		keyBy := ratelimit.KeyBy(strings.ToLower(rl.KeyBy))
+		switch keyBy {
+		case ratelimit.KeyByIP, ratelimit.KeyByUser:
+		case "":
+			keyBy = ratelimit.KeyByUser
+		default:
+			return nil, fmt.Errorf("rate limit %q: unknown key_by %q", rl.Name, rl.KeyBy)
+		}
+
+		t.RateLimits = append(t.RateLimits, ratelimit.Policy{
+			Name:   rl.Name,
+			Method: strings.ToUpper(rl.Method),
+			Path:   rl.Path,
+			KeyBy:  keyBy,
+			Limit:  limit,
+		})
+	}
+
+	return t, nil
+}
+
+// Match находит маршрут с самым длинным подходящим префиксом, который
+// разрешает метод: маршрут с ограниченным списком методов не скрывает
+// менее конкретные префиксы для остальных методов. Если путь совпал, но
+// ни один маршрут не разрешает метод, возвращается ErrMethodNotAllowed
+// вместе со списком разрешённых методов.
+func (t *Table) Match(method, path string) (*Route, []string, error) {
+	pathSegs := splitPath(path)
+
+	matched := false
+	allowed := make(map[string]struct{})
+
+	for _, route := range t.routes {
+		if !matchPrefix(route.segments, pathSegs) {
+			continue
+		}
+
+		matched = true
+		if route.AllowsMethod(method) {
+			return route, nil, nil
+		}
+		for m := range route.Methods {
+			allowed[m] = struct{}{}
+		}
+	}
+
+	if !matched {
+		return nil, nil, ErrRouteNotFound
+	}
+
+	methods := make([]string, 0, len(allowed))
+	for m := range allowed {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return nil, methods, ErrMethodNotAllowed
+}
+
+func matchPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, seg := range prefix {
+		if strings.HasPrefix(seg, ":") {
+			continue
+		}
+		if seg != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// specificity — число сегментов; статический сегмент весит больше параметра.
+func specificity(r *Route) int {
+	score := 0
+	for _, seg := range r.segments {
+		score += 2
+		if !strings.HasPrefix(seg, ":") {
+			score++
+		}
+	}
+	return score
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+func toSet(values []string, normalize func(string) string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[normalize(strings.TrimSpace(v))] = struct{}{}
+	}
+	return set
+}
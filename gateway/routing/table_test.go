@@ -0,0 +1,273 @@
+package routing
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func nopProxy(*Upstream) http.Handler {
+	return http.NotFoundHandler()
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const testConfig = `
+upstreams:
+  event:
+    url: ${TEST_EVENT_URL:-http://event:8083}
+    timeout: 5s
+  ticket:
+    url: http://ticket:8082
+routes:
+  - prefix: /api/events
+    upstream: event
+    public: true
+    methods: [GET]
+  - prefix: /api/events
+    upstream: event
+    methods: [POST, DELETE]
+    roles: [organizer]
+  - prefix: /api/ticket
+    upstream: ticket
+  - prefix: /api/ticket/events/:id/ticket-types
+    upstream: ticket
+    methods: [POST]
+    roles: [organizer]
+    timeout: 60s
+rate_limits:
+  - name: login
+    path: /api/auth/login
+    key_by: ip
+    limit: 5/1m
+`
+
+func loadTestTable(t *testing.T) *Table {
+	t.Helper()
+	cfg, err := LoadConfig(writeConfig(t, "routes.yaml", testConfig))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	table, err := NewTable(cfg, nopProxy)
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+	return table
+}
+
+func TestTable_MatchByMethod(t *testing.T) {
+	table := loadTestTable(t)
+
+	route, _, err := table.Match(http.MethodGet, "/api/events/5")
+	if err != nil || !route.Public {
+		t.Fatalf("expected public GET route, got %+v, %v", route, err)
+	}
+
+	route, _, err = table.Match(http.MethodPost, "/api/events")
+	if err != nil || route.Public || !route.AllowsRole("organizer") || route.AllowsRole("user") {
+		t.Fatalf("expected organizer-only POST route, got %+v, %v", route, err)
+	}
+
+	_, allowed, err := table.Match(http.MethodPatch, "/api/events/5")
+	if !errors.Is(err, ErrMethodNotAllowed) {
+		t.Fatalf("expected ErrMethodNotAllowed, got %v", err)
+	}
+	if len(allowed) != 3 {
+		t.Fatalf("expected 3 allowed methods, got %v", allowed)
+	}
+
+	if _, _, err := table.Match(http.MethodGet, "/api/eventsx"); !errors.Is(err, ErrRouteNotFound) {
+		t.Fatalf("expected ErrRouteNotFound, got %v", err)
+	}
+}
+
+func TestTable_LongestPrefixWins(t *testing.T) {
+	table := loadTestTable(t)
+
+	route, _, err := table.Match(http.MethodPost, "/api/ticket/events/7/ticket-types")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !route.AllowsRole("organizer") || route.AllowsRole("user") {
+		t.Fatalf("expected organizer route, got roles %v", route.Roles)
+	}
+	if route.Timeout != time.Minute {
+		t.Fatalf("expected route timeout 60s, got %v", route.Timeout)
+	}
+
+	route, _, err = table.Match(http.MethodPost, "/api/ticket/events/7/tickets")
+	if err != nil || len(route.Roles) != 0 {
+		t.Fatalf("expected generic ticket route, got %+v, %v", route, err)
+	}
+	if route.Timeout != 0 || route.Upstream.Timeout != defaultUpstreamTimeout {
+		t.Fatalf("expected no route deadline and default upstream timeout, got %v/%v", route.Timeout, route.Upstream.Timeout)
+	}
+}
+
+func TestTable_ShippedRoutes(t *testing.T) {
+	cfg, err := LoadConfig("../routes.yaml")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	table, err := NewTable(cfg, nopProxy)
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+
+	cases := []struct {
+		method string
+		path   string
+		public bool
+	}{
+		{http.MethodGet, "/api/events/5", true},
+		{http.MethodGet, "/api/events/5/schedule", true},
+		{http.MethodPost, "/api/events/5", false},
+		{http.MethodPost, "/api/events/5/schedule", false},
+	}
+	for _, c := range cases {
+		route, _, err := table.Match(c.method, c.path)
+		if err != nil {
+			t.Fatalf("%s %s: %v", c.method, c.path, err)
+		}
+		if route.Upstream.Name != "event" || route.Public != c.public {
+			t.Fatalf("%s %s: unexpected route %q public=%v", c.method, c.path, route.Prefix, route.Public)
+		}
+	}
+
+	// запись в мероприятие не требует роли organizer: права проверяет event-service
+	route, _, _ := table.Match(http.MethodPost, "/api/events/5/schedule")
+	if !route.AllowsRole("user") {
+		t.Fatalf("expected event write route for any role, got %v", route.Roles)
+	}
+
+	// маршруты организатора открыты и администратору
+	for _, path := range []string{"/api/events", "/api/categories", "/api/venues", "/api/ticket/events/5/ticket-types"} {
+		route, _, err := table.Match(http.MethodPost, path)
+		if err != nil {
+			t.Fatalf("POST %s: %v", path, err)
+		}
+		if !route.AllowsRole("organizer") || !route.AllowsRole("admin") || route.AllowsRole("user") {
+			t.Fatalf("POST %s: unexpected roles %v", path, route.Roles)
+		}
+	}
+}
+
+func TestLoadConfig_EnvAndJSON(t *testing.T) {
+	t.Setenv("TEST_EVENT_URL", "http://override:9000")
+
+	table := loadTestTable(t)
+	if got := table.Upstreams["event"].URL.Host; got != "override:9000" {
+		t.Fatalf("expected env override, got %q", got)
+	}
+	if got := table.Upstreams["event"].Timeout; got != 5*time.Second {
+		t.Fatalf("expected 5s timeout, got %v", got)
+	}
+	if len(table.RateLimits) != 1 || table.RateLimits[0].Limit.Requests != 5 {
+		t.Fatalf("unexpected rate limits: %+v", table.RateLimits)
+	}
+
+	path := writeConfig(t, "routes.json", `{
+		"upstreams": {"user": {"url": "http://user:8081", "timeout": "3s"}},
+		"routes": [{"prefix": "/api/auth", "upstream": "user", "public": true}]
+	}`)
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig json: %v", err)
+	}
+	if _, err := NewTable(cfg, nopProxy); err != nil {
+		t.Fatalf("NewTable json: %v", err)
+	}
+}
+
+func TestNewTable_Validation(t *testing.T) {
+	cases := []string{
+		"routes:\n  - prefix: /api/x\n    upstream: missing\n",
+		"upstreams:\n  u:\n    url: not-a-url\n",
+		"upstreams:\n  u:\n    url: http://u\nroutes:\n  - prefix: /api/x\n    upstream: u\n    public: true\n    roles: [organizer]\n",
+		"upstreams:\n  u:\n    url: http://u\nroutes:\n  - prefix: /api/x\n    upstream: u\n    public: true\n    verified_email: true\n",
+	}
+	for _, c := range cases {
+		cfg, err := LoadConfig(writeConfig(t, "routes.yml", c))
+		if err != nil {
+			t.Fatalf("LoadConfig: %v", err)
+		}
+		if _, err := NewTable(cfg, nopProxy); err == nil {
+			t.Fatalf("expected validation error for config:\n%s", c)
+		}
+	}
+}
+
+func TestHolder_ReloadKeepsPreviousTableOnError(t *testing.T) {
+	path := writeConfig(t, "routes.yaml", testConfig)
+
+	h, err := NewHolder(path, nopProxy)
+	if err != nil {
+		t.Fatalf("NewHolder: %v", err)
+	}
+	before := h.Table()
+
+	if err := os.WriteFile(path, []byte("routes: [\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Reload(); err == nil {
+		t.Fatalf("expected reload error")
+	}
+	if h.Table() != before {
+		t.Fatalf("table must not change on failed reload")
+	}
+}
+
+func TestNewTable_MFARequiredRoles(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, "routes.yaml", `
+upstreams:
+  user:
+    url: http://user:8081
+mfa_required_roles: [Organizer]
+routes:
+  - prefix: /api/auth
+    upstream: user
+    public: true
+  - prefix: /api/users
+    upstream: user
+  - prefix: /api/users/me
+    upstream: user
+    mfa_exempt: true
+`))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	table, err := NewTable(cfg, nopProxy)
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+
+	cases := []struct {
+		path string
+		role string
+		want bool
+	}{
+		{"/api/users/7", "organizer", true},
+		{"/api/users/7", "user", false},
+		{"/api/users/me/mfa/enroll", "organizer", false},
+		{"/api/auth/login", "organizer", false},
+	}
+	for _, c := range cases {
+		route, _, err := table.Match(http.MethodGet, c.path)
+		if err != nil {
+			t.Fatalf("Match(%s): %v", c.path, err)
+		}
+		if got := route.RequiresMFA(c.role); got != c.want {
+			t.Fatalf("%s as %s: expected RequiresMFA=%v, got %v", c.path, c.role, c.want, got)
+		}
+	}
+}
@@ -38,5 +38,40 @@ $KAFKA_HOME/bin/kafka-topics.sh --bootstrap-server $BROKER --create --if-not-exi
   --partitions 1 \
   --replication-factor 1 || true
 
+# Топики для user-service
+$KAFKA_HOME/bin/kafka-topics.sh --bootstrap-server $BROKER --create --if-not-exists \
+  --topic organizer.application.submitted \
+  --partitions 1 \
+  --replication-factor 1 || true
+
+$KAFKA_HOME/bin/kafka-topics.sh --bootstrap-server $BROKER --create --if-not-exists \
+  --topic organizer.application.approved \
+  --partitions 1 \
+  --replication-factor 1 || true
+
+$KAFKA_HOME/bin/kafka-topics.sh --bootstrap-server $BROKER --create --if-not-exists \
+  --topic organizer.application.rejected \
+  --partitions 1 \
+  --replication-factor 1 || true
+
+# Жизненный цикл пользователя
+for topic in user.registered user.updated user.role_changed user.deactivated; do
+  $KAFKA_HOME/bin/kafka-topics.sh --bootstrap-server $BROKER --create --if-not-exists \
+    --topic $topic \
+    --partitions 1 \
+    --replication-factor 1 || true
+done
+
+# Запросы на выгрузку и удаление данных пользователя
+$KAFKA_HOME/bin/kafka-topics.sh --bootstrap-server $BROKER --create --if-not-exists \
+  --topic user.export_requested \
+  --partitions 1 \
+  --replication-factor 1 || true
+
+$KAFKA_HOME/bin/kafka-topics.sh --bootstrap-server $BROKER --create --if-not-exists \
+  --topic user.deletion_requested \
+  --partitions 1 \
+  --replication-factor 1 || true
+
 echo "Topics created successfully!"
 $KAFKA_HOME/bin/kafka-topics.sh --bootstrap-server $BROKER --list
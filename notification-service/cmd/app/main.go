@@ -2,6 +2,7 @@ package main
 
 import (
 	"log/slog"
+	api_http "notification-service/internal/api/http"
 	"notification-service/internal/config"
 	"notification-service/internal/kafka"
 	"notification-service/internal/models"
@@ -11,6 +12,9 @@ import (
 	"os"
 
 	"github.com/gin-gonic/gin"
+
+	"user-service/userclient"
+	"user-service/userclient/requestid"
 )
 
 func main() {
@@ -33,11 +37,35 @@ func main() {
 	notRepo := repository.NewNotificationRepo(db, log)
 	notService := services.NewNotificationService(notRepo, log, redis)
 
-	consumer := kafka.NewConsumer(config.KafkaBrokers(), notService, log)
+	users := userClient(log)
+	var directory kafka.UserDirectory
+	if users != nil {
+		directory = users
+	}
+	var holders kafka.TicketHolders
+	if tickets := ticketClient(log); tickets != nil {
+		holders = tickets
+	}
+	consumer := kafka.NewConsumer(config.KafkaBrokers(), notService, directory, holders, log)
 	go consumer.Start()
 
+	// запросы на выгрузку и удаление данных подтверждаются в user-service,
+	// без клиента их не обработать
+	if users != nil {
+		privacyService := services.NewPrivacyService(notRepo, log, redis)
+		kafka.NewDataRequestConsumer(config.KafkaBrokers(), privacyService, users, log).Start()
+	}
+
+
+	identitySecret := os.Getenv("INTERNAL_IDENTITY_SECRET")
+	if identitySecret == "" {
+		log.Error("INTERNAL_IDENTITY_SECRET is not set")
+		os.Exit(1)
+	}
 
-	httpServer:= gin.Default()
+	httpServer := gin.Default()
+	httpServer.Use(requestid.Middleware())
+	httpServer.Use(userclient.RequireIdentity(identitySecret))
 	transport.RegisterRoutes(
 		httpServer,
 		log,
@@ -53,3 +81,28 @@ func main() {
 		log.Error("не удалось запустить сервер", slog.Any("error", err))
 	}
 }
+
+// userClient — клиент user-service: имена получателей и подтверждение
+// запросов на выгрузку и удаление данных. Без USER_SERVICE_URL уведомления
+// остаются обезличенными, а такие запросы не обрабатываются.
+func userClient(log *slog.Logger) *userclient.Client {
+	baseURL := os.Getenv("USER_SERVICE_URL")
+	secret := os.Getenv("INTERNAL_SERVICE_SECRET")
+	if baseURL == "" || secret == "" {
+		log.Warn("USER_SERVICE_URL or INTERNAL_SERVICE_SECRET is not set, notifications are not personalized and data requests are not processed")
+		return nil
+	}
+	return userclient.New(baseURL, "notification-service", secret)
+}
+
+// ticketClient — клиент ticket-service: владельцы билетов для уведомлений
+// об изменении расписания. Без TICKET_SERVICE_URL такие уведомления не отправляются.
+func ticketClient(log *slog.Logger) *api_http.TicketClient {
+	baseURL := os.Getenv("TICKET_SERVICE_URL")
+	secret := os.Getenv("INTERNAL_SERVICE_SECRET")
+	if baseURL == "" || secret == "" {
+		log.Warn("TICKET_SERVICE_URL or INTERNAL_SERVICE_SECRET is not set, schedule changes are not notified")
+		return nil
+	}
+	return api_http.NewTicketClient(baseURL, secret)
+}
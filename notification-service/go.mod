@@ -11,6 +11,7 @@ require (
 	github.com/stretchr/testify v1.11.1
 	gorm.io/driver/postgres v1.6.0
 	gorm.io/gorm v1.31.1
+	user-service/userclient v0.0.0
 )
 
 require (
@@ -69,3 +70,5 @@ require (
 	modernc.org/memory v1.5.0 // indirect
 	modernc.org/sqlite v1.23.1 // indirect
 )
+
+replace user-service/userclient => ../user-service/userclient
@@ -0,0 +1,80 @@
+package api_http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"user-service/userclient"
+	"user-service/userclient/requestid"
+)
+
+// tokenTTL — срок действия сервисного токена одного запроса.
+const tokenTTL = time.Minute
+
+// TicketClient получает у ticket-service владельцев билетов. Запросы
+// подписываются тем же сервисным токеном, что и запросы к user-service.
+type TicketClient struct {
+	baseURL string
+	secret  []byte
+	client  *http.Client
+}
+
+func NewTicketClient(baseURL, secret string) *TicketClient {
+	return &TicketClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  []byte(secret),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type holdersResponse struct {
+	UserIDs []uint `json:"user_ids"`
+}
+
+// HolderIDs возвращает владельцев активных билетов мероприятия, которых
+// касается одно из повторений scheduleIDs или одна из серий seriesIDs (без
+// них — всё мероприятие).
+func (c *TicketClient) HolderIDs(ctx context.Context, eventID uint, scheduleIDs, seriesIDs []uint) ([]uint, error) {
+	query := url.Values{}
+	for _, id := range scheduleIDs {
+		query.Add("schedule_id", strconv.FormatUint(uint64(id), 10))
+	}
+	for _, id := range seriesIDs {
+		query.Add("series_id", strconv.FormatUint(uint64(id), 10))
+	}
+	endpoint := fmt.Sprintf("%s/internal/events/%d/holders?%s", c.baseURL, eventID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	expires := time.Now().Add(tokenTTL).Unix()
+	req.Header.Set(userclient.HeaderService, "notification-service")
+	req.Header.Set(userclient.HeaderExpires, strconv.FormatInt(expires, 10))
+	req.Header.Set(userclient.HeaderSignature, userclient.Sign(c.secret, "notification-service", expires))
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ticket-service holders: unexpected status %d", resp.StatusCode)
+	}
+
+	var out holdersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return out.UserIDs, nil
+}
@@ -1,9 +1,11 @@
 package dto
 
+import "time"
 
 type UpdateNotificationPreferencesRequest struct {
 	TicketPurchased *bool `json:"ticket_purchased"`
 	EventCanceled   *bool `json:"event_canceled"`
+	ScheduleChanged *bool `json:"schedule_changed"`
 	EventReminder   *bool `json:"event_reminder"`
 	PushEnabled     *bool `json:"push_enabled"`
 	InAppEnabled    *bool `json:"in_app_enabled"`
@@ -15,6 +17,7 @@ const (
 	NotificationTypeTicket   NotificationType = "ticket_purchase"
 	NotificationTypeEvent    NotificationType = "event_notification"
 	NotificationTypeReminder NotificationType = "reminder"
+	NotificationTypeAccount  NotificationType = "account"
 )
 
 type TicketPurchasedEvent struct {
@@ -35,4 +38,27 @@ type EventReminder struct {
 	UserIDs    []uint `json:"user_ids"` // всех владельцев билетов
 }
 
+// ScheduleChangedEvent — изменение расписания мероприятия от event-service.
+// Action: created / updated / deleted; Scope: this / following / series.
+type ScheduleChangedEvent struct {
+	EventID         uint       `json:"event_id"`
+	EventTitle      string     `json:"event_title"`
+	Action          string     `json:"action"`
+	Scope           string     `json:"scope"`
+	ScheduleID      uint       `json:"schedule_id"`
+	ScheduleIDs     []uint     `json:"schedule_ids"`
+	SeriesID        *uint      `json:"series_id"`
+	SeriesIDs       []uint     `json:"series_ids"`
+	ActivityName    string     `json:"activity_name"`
+	StartAt         time.Time  `json:"start_at"`
+	PreviousStartAt *time.Time `json:"previous_start_at"`
+}
 
+// OrganizerApplicationEvent — событие user-service по заявке на роль организатора.
+type OrganizerApplicationEvent struct {
+	ApplicationID    uint   `json:"application_id"`
+	UserID           uint   `json:"user_id"`
+	OrganizationName string `json:"organization_name"`
+	Status           string `json:"status"`
+	Reason           string `json:"reason"`
+}
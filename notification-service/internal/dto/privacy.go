@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// UserNotificationsExport — данные пользователя в notification-service для выгрузки.
+type UserNotificationsExport struct {
+	Notifications []UserNotificationExport `json:"notifications"`
+	Preferences   *UserPreferencesExport   `json:"preferences"`
+}
+
+type UserNotificationExport struct {
+	ID        uint       `json:"id"`
+	EventID   uint       `json:"event_id,omitempty"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Read      bool       `json:"read"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type UserPreferencesExport struct {
+	TicketPurchased bool `json:"ticket_purchased"`
+	EventCanceled   bool `json:"event_canceled"`
+	ScheduleChanged bool `json:"schedule_changed"`
+	EventReminder   bool `json:"event_reminder"`
+	PushEnabled     bool `json:"push_enabled"`
+	InAppEnabled    bool `json:"in_app_enabled"`
+}
+
+// UserNotificationsErasure — сводка по удалению данных пользователя.
+type UserNotificationsErasure struct {
+	NotificationsDeleted int64 `json:"notifications_deleted"`
+}
@@ -8,13 +8,31 @@ import (
 	"notification-service/internal/dto"
 	"notification-service/internal/models"
 	"notification-service/internal/services"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/segmentio/kafka-go"
+
+	"user-service/userclient"
+	"user-service/userclient/requestid"
 )
 
+// UserDirectory — откуда брать имена получателей. Реализуется userclient.Client.
+type UserDirectory interface {
+	GetUsers(ctx context.Context, ids []uint, view userclient.View) (map[uint]userclient.User, error)
+}
+
+// TicketHolders — кому из владельцев билетов сообщать об изменении
+// расписания. Реализуется api_http.TicketClient.
+type TicketHolders interface {
+	HolderIDs(ctx context.Context, eventID uint, scheduleIDs, seriesIDs []uint) ([]uint, error)
+}
+
 type Consumer struct {
 	brokers []string
 	srv     services.NotificationService
+	users   UserDirectory
+	holders TicketHolders
 	log     *slog.Logger
 	groupID string
 	topics  []string
@@ -22,16 +40,30 @@ type Consumer struct {
 	cancel  context.CancelFunc
 }
 
-func NewConsumer(brokers []string, srv services.NotificationService, log *slog.Logger) *Consumer {
+// NewConsumer создаёт консьюмер. users может быть nil — тогда уведомления
+// не персонализируются; holders может быть nil — тогда об изменениях
+// расписания не сообщается.
+func NewConsumer(brokers []string, srv services.NotificationService, users UserDirectory, holders TicketHolders, log *slog.Logger) *Consumer {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Consumer{
 		brokers: brokers,
 		srv:     srv,
+		users:   users,
+		holders: holders,
 		log:     log,
 		groupID: "notification-service",
-		topics:  []string{"ticket.purchased", "event.cancelled", "event.reminder"},
-		ctx:     ctx,
-		cancel:  cancel,
+		topics: []string{
+			"ticket.purchased",
+			"event.cancelled",
+			"event.reminder",
+			"event.schedule_changed",
+			"organizer.application.submitted",
+			"organizer.application.approved",
+			"organizer.application.rejected",
+			userclient.TopicUserRegistered,
+		},
+		ctx:    ctx,
+		cancel: cancel,
 	}
 }
 
@@ -59,36 +91,45 @@ func (c *Consumer) consumeTopic(topic string) {
 			continue
 		}
 
-		c.log.Info("received message", "topic", topic, "value", string(m.Value))
+		ctx := requestid.NewContext(c.ctx, requestIDFromHeaders(m.Headers))
+
+		c.log.InfoContext(ctx, "received message", "topic", topic, "value", string(m.Value))
 
 		switch topic {
 		case "ticket.purchased":
-			c.handleTicketPurchased(m.Value)
+			c.handleTicketPurchased(ctx, m.Value)
 		case "event.cancelled":
-			c.handleEventCancelled(m.Value)
+			c.handleEventCancelled(ctx, m.Value)
 		case "event.reminder":
-			c.handleEventReminder(m.Value)
+			c.handleEventReminder(ctx, m.Value)
+		case "event.schedule_changed":
+			c.handleScheduleChanged(ctx, m.Value)
+		case "organizer.application.submitted",
+			"organizer.application.approved",
+			"organizer.application.rejected":
+			c.handleOrganizerApplication(ctx, topic, m.Value)
+		case userclient.TopicUserRegistered:
+			c.handleUserRegistered(ctx, m.Value)
 		}
 	}
 
 }
 
-func (c *Consumer) handleTicketPurchased(payload []byte) {
+func (c *Consumer) handleTicketPurchased(ctx context.Context, payload []byte) {
 	var evt dto.TicketPurchasedEvent
 	if err := json.Unmarshal(payload, &evt); err != nil {
-		c.log.Error("failed to unmarshal ticket purchased", "error", err)
+		c.log.ErrorContext(ctx, "failed to unmarshal ticket purchased", "error", err)
 		return
 	}
 
 	pref, err := c.srv.GetNotificationPreferences(evt.UserID)
 	if err != nil {
-		c.log.Error("failed to load preferences", "user_id", evt.UserID, "error", err)
+		c.log.ErrorContext(ctx, "failed to load preferences", "user_id", evt.UserID, "error", err)
 		return
 	}
 
 	if !pref.TicketPurchased {
-		c.log.Info(
-			"ticket purchased notification disabled",
+		c.log.InfoContext(ctx, "ticket purchased notification disabled",
 			"user_id", evt.UserID,
 		)
 		return
@@ -102,23 +143,24 @@ func (c *Consumer) handleTicketPurchased(payload []byte) {
 		Body:    fmt.Sprintf("Ты успешно приобрёл билет на %s", evt.EventTitle),
 	}
 
-	if err := c.srv.CreateNotificationInternal(notification); err != nil {
-		c.log.Error("failed to create notification", "error", err)
+	if err := c.srv.CreateNotificationInternal(ctx, notification); err != nil {
+		c.log.ErrorContext(ctx, "failed to create notification", "error", err)
 	}
 }
 
-func (c *Consumer) handleEventCancelled(payload []byte) {
+func (c *Consumer) handleEventCancelled(ctx context.Context, payload []byte) {
 	var evt dto.EventCancelledEvent
 	if err := json.Unmarshal(payload, &evt); err != nil {
-		c.log.Error("failed to unmarshal event cancelled", "error", err)
+		c.log.ErrorContext(ctx, "failed to unmarshal event cancelled", "error", err)
 		return
 	}
 
+	names := c.firstNames(ctx, evt.UserIDs)
 	for _, userID := range evt.UserIDs {
 
 		pref, err := c.srv.GetNotificationPreferences(userID)
 		if err != nil {
-			c.log.Error("failed to load preferences", "user_id", userID, "error", err)
+			c.log.ErrorContext(ctx, "failed to load preferences", "user_id", userID, "error", err)
 			continue
 		}
 
@@ -131,25 +173,26 @@ func (c *Consumer) handleEventCancelled(payload []byte) {
 			EventID: evt.EventID,
 			Type:    string(dto.NotificationTypeEvent),
 			Title:   "Мероприятие отменено",
-			Body:    fmt.Sprintf("Мероприятие %s отменено", evt.EventTitle),
+			Body:    greet(names[userID], fmt.Sprintf("Мероприятие %s отменено", evt.EventTitle)),
 		}
-		if err := c.srv.CreateNotificationInternal(notification); err != nil {
-			c.log.Error("failed to create notification", "error", err)
+		if err := c.srv.CreateNotificationInternal(ctx, notification); err != nil {
+			c.log.ErrorContext(ctx, "failed to create notification", "error", err)
 		}
 	}
 }
-func (c *Consumer) handleEventReminder(payload []byte) {
+func (c *Consumer) handleEventReminder(ctx context.Context, payload []byte) {
 	var evt dto.EventReminder
 	if err := json.Unmarshal(payload, &evt); err != nil {
-		c.log.Error("failed to unmarshal event reminder", "error", err)
+		c.log.ErrorContext(ctx, "failed to unmarshal event reminder", "error", err)
 		return
 	}
 
+	names := c.firstNames(ctx, evt.UserIDs)
 	for _, userID := range evt.UserIDs {
 
 		pref, err := c.srv.GetNotificationPreferences(userID)
 		if err != nil {
-			c.log.Error("failed to load preferences", "user_id", userID, "error", err)
+			c.log.ErrorContext(ctx, "failed to load preferences", "user_id", userID, "error", err)
 			continue
 		}
 
@@ -161,12 +204,179 @@ func (c *Consumer) handleEventReminder(payload []byte) {
 			EventID: evt.EventID,
 			Type:    string(dto.NotificationTypeReminder),
 			Title:   "Напоминание о мероприятии",
-			Body:    fmt.Sprintf("Завтра состоится мероприятие %s", evt.EventTitle),
+			Body:    greet(names[userID], fmt.Sprintf("Завтра состоится мероприятие %s", evt.EventTitle)),
+		}
+		if err := c.srv.CreateNotificationInternal(ctx, notification); err != nil {
+			c.log.ErrorContext(ctx, "failed to create notification", "error", err)
+		}
+	}
+}
+
+// handleScheduleChanged уведомляет владельцев билетов, которых касается
+// изменение: билеты на всё мероприятие, на повторение или на серию.
+// Отключается той же настройкой, что и уведомления об отмене.
+func (c *Consumer) handleScheduleChanged(ctx context.Context, payload []byte) {
+	var evt dto.ScheduleChangedEvent
+	if err := json.Unmarshal(payload, &evt); err != nil {
+		c.log.ErrorContext(ctx, "failed to unmarshal schedule changed", "error", err)
+		return
+	}
+	if c.holders == nil {
+		c.log.WarnContext(ctx, "ticket holders are not available, schedule change skipped", "event_id", evt.EventID)
+		return
+	}
+
+	// schedule_ids — все изменённые повторения, series_ids — серия и те, от
+	// которых она отделена; у старых сообщений только schedule_id и series_id
+	scheduleIDs := evt.ScheduleIDs
+	if len(scheduleIDs) == 0 && evt.ScheduleID != 0 && evt.Scope != "series" {
+		scheduleIDs = []uint{evt.ScheduleID}
+	}
+	seriesIDs := evt.SeriesIDs
+	if len(seriesIDs) == 0 && evt.SeriesID != nil {
+		seriesIDs = []uint{*evt.SeriesID}
+	}
+	userIDs, err := c.holders.HolderIDs(ctx, evt.EventID, scheduleIDs, seriesIDs)
+	if err != nil {
+		c.log.ErrorContext(ctx, "failed to load ticket holders", "event_id", evt.EventID, "error", err)
+		return
+	}
+
+	body := scheduleChangeText(evt)
+	names := c.firstNames(ctx, userIDs)
+	for _, userID := range userIDs {
+		pref, err := c.srv.GetNotificationPreferences(userID)
+		if err != nil {
+			c.log.ErrorContext(ctx, "failed to load preferences", "user_id", userID, "error", err)
+			continue
+		}
+
+		if !pref.ScheduleChanged {
+			continue
 		}
-		if err := c.srv.CreateNotificationInternal(notification); err != nil {
-			c.log.Error("failed to create notification", "error", err)
+
+		notification := &models.Notification{
+			UserID:  userID,
+			EventID: evt.EventID,
+			Type:    string(dto.NotificationTypeEvent),
+			Title:   "Расписание изменилось",
+			Body:    greet(names[userID], body),
+		}
+		if err := c.srv.CreateNotificationInternal(ctx, notification); err != nil {
+			c.log.ErrorContext(ctx, "failed to create notification", "error", err)
+		}
+	}
+}
+
+// scheduleChangeText — текст уведомления об изменении расписания.
+func scheduleChangeText(evt dto.ScheduleChangedEvent) string {
+	const layout = "02.01.2006 15:04"
+	start := evt.StartAt.Format(layout)
+
+	switch {
+	case evt.Action == "created" && evt.Scope == "series":
+		return fmt.Sprintf("В программе мероприятия %s новая регулярная активность «%s», первая — %s", evt.EventTitle, evt.ActivityName, start)
+	case evt.Action == "created":
+		return fmt.Sprintf("В программе мероприятия %s новая активность «%s» — %s", evt.EventTitle, evt.ActivityName, start)
+	case evt.Action == "deleted" && evt.Scope == "series":
+		return fmt.Sprintf("Регулярная активность «%s» мероприятия %s отменена", evt.ActivityName, evt.EventTitle)
+	case evt.Action == "deleted" && evt.Scope == "following":
+		return fmt.Sprintf("Активность «%s» мероприятия %s отменена начиная с %s", evt.ActivityName, evt.EventTitle, start)
+	case evt.Action == "deleted":
+		return fmt.Sprintf("Активность «%s» мероприятия %s %s отменена", evt.ActivityName, evt.EventTitle, start)
+	case evt.PreviousStartAt != nil && !evt.PreviousStartAt.Equal(evt.StartAt):
+		return fmt.Sprintf("Активность «%s» мероприятия %s перенесена с %s на %s", evt.ActivityName, evt.EventTitle, evt.PreviousStartAt.Format(layout), start)
+	default:
+		return fmt.Sprintf("Активность «%s» мероприятия %s %s изменена", evt.ActivityName, evt.EventTitle, start)
+	}
+}
+
+// firstNames получает имена получателей одним запросом к user-service.
+// При ошибке уведомления уходят без обращения по имени.
+func (c *Consumer) firstNames(ctx context.Context, userIDs []uint) map[uint]string {
+	if c.users == nil || len(userIDs) == 0 {
+		return nil
+	}
+
+	users, err := c.users.GetUsers(ctx, userIDs, userclient.ViewPublic)
+	if err != nil {
+		c.log.WarnContext(ctx, "failed to resolve recipient names", "error", err)
+		return nil
+	}
+
+	names := make(map[uint]string, len(users))
+	for id, u := range users {
+		names[id] = u.FirstName
+	}
+	return names
+}
+
+// greet добавляет обращение по имени, если оно известно.
+func greet(name, text string) string {
+	if name == "" {
+		return text
+	}
+	r, size := utf8.DecodeRuneInString(text)
+	return name + ", " + string(unicode.ToLower(r)) + text[size:]
+}
+
+// handleOrganizerApplication уведомляет заявителя о ходе рассмотрения заявки.
+// Настройками не отключается: это уведомление об учётной записи.
+func (c *Consumer) handleOrganizerApplication(ctx context.Context, topic string, payload []byte) {
+	var evt dto.OrganizerApplicationEvent
+	if err := json.Unmarshal(payload, &evt); err != nil {
+		c.log.ErrorContext(ctx, "failed to unmarshal organizer application", "error", err)
+		return
+	}
+
+	notification := &models.Notification{
+		UserID: evt.UserID,
+		Type:   string(dto.NotificationTypeAccount),
+	}
+
+	switch topic {
+	case "organizer.application.submitted":
+		notification.Title = "Заявка отправлена"
+		notification.Body = fmt.Sprintf("Заявка на роль организатора от %s отправлена на рассмотрение", evt.OrganizationName)
+	case "organizer.application.approved":
+		notification.Title = "Заявка одобрена"
+		notification.Body = fmt.Sprintf("Заявка %s одобрена, теперь ты можешь создавать мероприятия. Войди заново, чтобы права обновились", evt.OrganizationName)
+	case "organizer.application.rejected":
+		notification.Title = "Заявка отклонена"
+		notification.Body = fmt.Sprintf("Заявка %s отклонена. Причина: %s", evt.OrganizationName, evt.Reason)
+	}
+
+	if err := c.srv.CreateNotificationInternal(ctx, notification); err != nil {
+		c.log.ErrorContext(ctx, "failed to create notification", "error", err)
+	}
+}
+
+// handleUserRegistered заводит настройки уведомлений и приветствует
+// нового пользователя.
+func (c *Consumer) handleUserRegistered(ctx context.Context, payload []byte) {
+	var evt userclient.UserEvent
+	if err := json.Unmarshal(payload, &evt); err != nil {
+		c.log.ErrorContext(ctx, "failed to unmarshal user registered", "error", err)
+		return
+	}
+	if !evt.Supported() {
+		c.log.WarnContext(ctx, "unsupported user event version", "version", evt.Version, "user_id", evt.UserID)
+		return
+	}
+
+	if err := c.srv.WelcomeUser(ctx, evt.UserID, evt.FirstName); err != nil {
+		c.log.ErrorContext(ctx, "failed to welcome user", "user_id", evt.UserID, "error", err)
+	}
+}
+
+// requestIDFromHeaders достаёт ID запроса, проставленный продюсером.
+func requestIDFromHeaders(headers []kafka.Header) string {
+	for _, h := range headers {
+		if h.Key == requestid.Header && requestid.Valid(string(h.Value)) {
+			return string(h.Value)
 		}
 	}
+	return ""
 }
 
 func (c *Consumer) Stop() {
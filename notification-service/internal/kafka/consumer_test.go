@@ -0,0 +1,88 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"notification-service/internal/dto"
+	"notification-service/internal/models"
+	"notification-service/internal/services"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockService реализует только то, что нужно консьюмеру.
+type mockService struct {
+	services.NotificationService
+	created []models.Notification
+}
+
+func (m *mockService) GetNotificationPreferences(userID uint) (*models.NotificationPreference, error) {
+	return &models.NotificationPreference{UserID: userID, ScheduleChanged: true}, nil
+}
+
+func (m *mockService) CreateNotificationInternal(ctx context.Context, n *models.Notification) error {
+	m.created = append(m.created, *n)
+	return nil
+}
+
+type mockHolders struct {
+	scheduleIDs []uint
+	seriesIDs   []uint
+	userIDs     []uint
+}
+
+func (m *mockHolders) HolderIDs(ctx context.Context, eventID uint, scheduleIDs, seriesIDs []uint) ([]uint, error) {
+	m.scheduleIDs = scheduleIDs
+	m.seriesIDs = seriesIDs
+	return m.userIDs, nil
+}
+
+func newTestConsumer(srv services.NotificationService, holders TicketHolders) *Consumer {
+	return NewConsumer(nil, srv, nil, holders, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestHandleScheduleChanged_FollowingCoversAllOccurrences(t *testing.T) {
+	srv := &mockService{}
+	holders := &mockHolders{userIDs: []uint{7, 8}}
+	c := newTestConsumer(srv, holders)
+
+	seriesID := uint(12)
+	payload, err := json.Marshal(dto.ScheduleChangedEvent{
+		EventID:      1,
+		EventTitle:   "GoConf",
+		Action:       "updated",
+		Scope:        "following",
+		ScheduleID:   101,
+		ScheduleIDs:  []uint{101, 102, 103},
+		SeriesID:     &seriesID,
+		SeriesIDs:    []uint{5, 12},
+		ActivityName: "Meetup",
+	})
+	require.NoError(t, err)
+
+	c.handleScheduleChanged(context.Background(), payload)
+
+	require.Equal(t, []uint{101, 102, 103}, holders.scheduleIDs)
+	require.Equal(t, []uint{5, 12}, holders.seriesIDs)
+	require.Len(t, srv.created, 2)
+}
+
+func TestHandleScheduleChanged_LegacyMessage(t *testing.T) {
+	holders := &mockHolders{}
+	c := newTestConsumer(&mockService{}, holders)
+
+	seriesID := uint(12)
+	payload, err := json.Marshal(map[string]any{
+		"event_id": 1, "action": "updated", "scope": "this", "schedule_id": 101, "series_id": seriesID,
+	})
+	require.NoError(t, err)
+
+	c.handleScheduleChanged(context.Background(), payload)
+
+	require.Equal(t, []uint{101}, holders.scheduleIDs)
+	require.Equal(t, []uint{12}, holders.seriesIDs)
+}
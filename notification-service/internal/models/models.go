@@ -14,6 +14,8 @@ type Notification struct {
 	Title   string `json:"title"`
 	Body    string `json:"body"`
 	Read    bool   `json:"read"`
+	// RequestID — X-Request-Id запроса, породившего уведомление
+	RequestID string `json:"request_id,omitempty" gorm:"size:128;index"`
 }
 
 type NotificationPreference struct {
@@ -21,6 +23,7 @@ type NotificationPreference struct {
 
 	TicketPurchased bool // отключает уведомление о покупке билетов
 	EventCanceled   bool // отключает уведомления о мероприятиях
+	ScheduleChanged bool `gorm:"not null;default:true"` // отключает уведомления об изменении программы
 	EventReminder   bool // отключает напоминания
 
 	PushEnabled  bool
@@ -126,6 +126,7 @@ func TestNotificationRepo_Preferences_CreateDefault(t *testing.T) {
 	require.Equal(t, uint(10), pref.UserID)
 	require.True(t, pref.TicketPurchased)
 	require.True(t, pref.EventCanceled)
+	require.True(t, pref.ScheduleChanged)
 	require.True(t, pref.EventReminder)
 	require.True(t, pref.PushEnabled)
 	require.True(t, pref.InAppEnabled)
@@ -149,3 +150,68 @@ func TestNotificationRepo_Preferences_Update(t *testing.T) {
 	require.False(t, got.InAppEnabled)
 	require.False(t, got.TicketPurchased)
 }
+
+func TestNotificationRepo_ExportAndDeleteUserData(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewNotificationRepo(db, newTestLogger())
+
+	n1 := &models.Notification{UserID: 1, Title: "A"}
+	n2 := &models.Notification{UserID: 1, Title: "B"}
+	n3 := &models.Notification{UserID: 2, Title: "C"}
+	require.NoError(t, repo.Create(n1))
+	require.NoError(t, repo.Create(n2))
+	require.NoError(t, repo.Create(n3))
+	require.NoError(t, repo.DeleteNotificationsByID(1, n2.ID))
+	_, err := repo.CreateDefaultPreferences(1)
+	require.NoError(t, err)
+
+	// в выгрузку попадают и уведомления, которые пользователь удалил сам
+	nots, pref, err := repo.ExportUserData(1)
+	require.NoError(t, err)
+	require.Len(t, nots, 2)
+	require.NotNil(t, pref)
+
+	deleted, err := repo.DeleteUserData(1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+
+	nots, pref, err = repo.ExportUserData(1)
+	require.NoError(t, err)
+	require.Empty(t, nots)
+	require.Nil(t, pref)
+
+	// чужие уведомления не затронуты
+	list, err := repo.GetNotifications(2, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+}
+
+func TestNotificationRepo_Preferences_DefaultsNotPersistedOnRead(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewNotificationRepo(db, newTestLogger())
+
+	_, err := repo.GetNotificationPreferences(10)
+	require.NoError(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&models.NotificationPreference{}).Count(&count).Error)
+	require.Zero(t, count)
+
+	created, err := repo.CreateDefaultPreferences(10)
+	require.NoError(t, err)
+	require.True(t, created)
+
+	// повторное событие не перезаписывает изменённые настройки
+	pref, err := repo.GetNotificationPreferences(10)
+	require.NoError(t, err)
+	pref.PushEnabled = false
+	require.NoError(t, repo.UpdateNotificationPreferences(pref))
+
+	created, err = repo.CreateDefaultPreferences(10)
+	require.NoError(t, err)
+	require.False(t, created)
+
+	got, err := repo.GetNotificationPreferences(10)
+	require.NoError(t, err)
+	require.False(t, got.PushEnabled)
+}
@@ -7,6 +7,7 @@ import (
 	"notification-service/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type NotificationRepo interface {
@@ -15,9 +16,16 @@ type NotificationRepo interface {
 	AllRead(userID uint) error
 	ReadNotificationsByID(userID, id uint) error
 	DeleteNotificationsByID(userID, id uint) error
+	// GetNotificationPreferences возвращает настройки по умолчанию, не
+	// сохраняя их, если пользователь их ещё не менял.
 	GetNotificationPreferences(userID uint) (*models.NotificationPreference, error)
+	// CreateDefaultPreferences сохраняет настройки по умолчанию; false —
+	// настройки уже были.
+	CreateDefaultPreferences(userID uint) (bool, error)
 	UpdateNotificationPreferences(pref *models.NotificationPreference) error
 	UnreadNotificationsCounts(userID uint) (int64, error)
+	ExportUserData(userID uint) ([]models.Notification, *models.NotificationPreference, error)
+	DeleteUserData(userID uint) (int64, error)
 }
 
 type notificationRepo struct {
@@ -114,33 +122,18 @@ func (r *notificationRepo) GetNotificationPreferences(userID uint) (*models.Noti
 	err := r.db.Where("user_id = ?", userID).First(&pref).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		// создаём дефолтные настройки
-		r.log.Warn(
-			"notification preferences not found, creating default",
-			"user_id", userID,
-		)
-		pref = models.NotificationPreference{
-			UserID:          userID,
-			TicketPurchased: true,
-			EventCanceled:   true,
-			EventReminder:   true,
-			PushEnabled:     true,
-			InAppEnabled:    true,
-		}
-		if err := r.db.Create(&pref).Error; err != nil {
-			r.log.Error(
-				"failed to create default notification preferences",
-				"user_id", userID,
-				"error", err,
-			)
-			return nil, err
-		}
-
-		r.log.Info(
-			"default notification preferences created",
+		// настройки создаются по событию user.registered; у пользователей,
+		// зарегистрированных раньше, их может не быть
+		pref = defaultPreferences(userID)
+		return &pref, nil
+	}
+	if err != nil {
+		r.log.Error(
+			"failed to load notification preferences",
 			"user_id", userID,
+			"error", err,
 		)
-		return &pref, nil
+		return nil, err
 	}
 
 	r.log.Info(
@@ -150,6 +143,33 @@ func (r *notificationRepo) GetNotificationPreferences(userID uint) (*models.Noti
 	return &pref, nil
 }
 
+func (r *notificationRepo) CreateDefaultPreferences(userID uint) (bool, error) {
+	pref := defaultPreferences(userID)
+	res := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&pref)
+	if res.Error != nil {
+		r.log.Error(
+			"failed to create default notification preferences",
+			"user_id", userID,
+			"error", res.Error,
+		)
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func defaultPreferences(userID uint) models.NotificationPreference {
+	return models.NotificationPreference{
+		UserID:          userID,
+		TicketPurchased: true,
+		EventCanceled:   true,
+		ScheduleChanged: true,
+		EventReminder:   true,
+		PushEnabled:     true,
+		InAppEnabled:    true,
+	}
+}
+
 func (r *notificationRepo) UpdateNotificationPreferences(pref *models.NotificationPreference) error {
 	if err := r.db.Save(pref).Error; err != nil {
 		r.log.Error("failed to update notification preferences", "error", err, "userID", pref.UserID)
@@ -170,3 +190,47 @@ func (r *notificationRepo) UnreadNotificationsCounts(userID uint) (int64, error)
 	r.log.Info("unread notifications count retrieved", "userID", userID, "count", count)
 	return count, nil
 }
+
+// ExportUserData возвращает все уведомления пользователя, включая удалённые
+// им самим, и его настройки; pref == nil, если настроек нет.
+func (r *notificationRepo) ExportUserData(userID uint) ([]models.Notification, *models.NotificationPreference, error) {
+	var nots []models.Notification
+	if err := r.db.Unscoped().Where("user_id = ?", userID).Order("id ASC").Find(&nots).Error; err != nil {
+		r.log.Error("failed to export notifications", "error", err, "userID", userID)
+		return nil, nil, err
+	}
+
+	var pref models.NotificationPreference
+	err := r.db.Where("user_id = ?", userID).First(&pref).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nots, nil, nil
+	}
+	if err != nil {
+		r.log.Error("failed to export notification preferences", "error", err, "userID", userID)
+		return nil, nil, err
+	}
+
+	return nots, &pref, nil
+}
+
+// DeleteUserData безвозвратно удаляет уведомления и настройки пользователя.
+func (r *notificationRepo) DeleteUserData(userID uint) (int64, error) {
+	var deleted int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.Notification{})
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected
+
+		return tx.Where("user_id = ?", userID).Delete(&models.NotificationPreference{}).Error
+	})
+	if err != nil {
+		r.log.Error("failed to delete user data", "error", err, "userID", userID)
+		return 0, err
+	}
+
+	r.log.Info("user notifications deleted", "userID", userID, "count", deleted)
+	return deleted, nil
+}
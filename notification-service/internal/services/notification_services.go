@@ -17,10 +17,12 @@ import (
 	"github.com/redis/go-redis/v9"
 	// "time"
 	// "github.com/redis/go-redis/v9"
+
+	"user-service/userclient/requestid"
 )
 
 type NotificationService interface {
-	CreateNotificationInternal(notification *models.Notification) error
+	CreateNotificationInternal(ctx context.Context, notification *models.Notification) error
 	GetNotifications(ctx context.Context, userID uint, limit int, lastID uint) ([]models.Notification, error)
 	CheckAll(userID uint) error
 	CheckNotificationsByID(userID, id uint) error
@@ -28,6 +30,9 @@ type NotificationService interface {
 	GetNotificationPreferences(userID uint) (*models.NotificationPreference, error)
 	Update(userID uint, req dto.UpdateNotificationPreferencesRequest) (*models.NotificationPreference, error)
 	Count(userID uint) (int64, error)
+	// WelcomeUser заводит настройки нового пользователя и приветствует его.
+	// Повторный вызов ничего не создаёт.
+	WelcomeUser(ctx context.Context, userID uint, firstName string) error
 }
 
 type notificationService struct {
@@ -44,16 +49,19 @@ func NewNotificationService(notificationRepo repository.NotificationRepo, log *s
 	}
 }
 
-func (s *notificationService) CreateNotificationInternal(notification *models.Notification) error {
+func (s *notificationService) CreateNotificationInternal(ctx context.Context, notification *models.Notification) error {
 	if notification.UserID == 0 {
-		s.log.Warn("create notification failed: invalid user id")
+		s.log.WarnContext(ctx, "create notification failed: invalid user id")
 		return errors.New("invalid user id")
 	}
 
 	notification.Read = false
+	if notification.RequestID == "" {
+		notification.RequestID = requestid.FromContext(ctx)
+	}
 
 	if err := s.notificationRepo.Create(notification); err != nil {
-		s.log.Error(
+		s.log.ErrorContext(ctx,
 			"failed to create notification",
 			"error", err,
 			"userID", notification.UserID,
@@ -61,7 +69,7 @@ func (s *notificationService) CreateNotificationInternal(notification *models.No
 		return err
 	}
 
-	s.log.Info(
+	s.log.InfoContext(ctx,
 		"notification created",
 		"userID", notification.UserID,
 		"notificationID", notification.ID,
@@ -257,6 +265,9 @@ func (s *notificationService) Update(userID uint, req dto.UpdateNotificationPref
 	if req.EventCanceled != nil {
 		val.EventCanceled = *req.EventCanceled
 	}
+	if req.ScheduleChanged != nil {
+		val.ScheduleChanged = *req.ScheduleChanged
+	}
 	if req.EventReminder != nil {
 		val.EventReminder = *req.EventReminder
 	}
@@ -308,3 +319,26 @@ func (s *notificationService) Count(userID uint) (int64, error) {
 
 	return count, nil
 }
+
+func (s *notificationService) WelcomeUser(ctx context.Context, userID uint, firstName string) error {
+	created, err := s.notificationRepo.CreateDefaultPreferences(userID)
+	if err != nil {
+		return err
+	}
+	if !created {
+		s.log.InfoContext(ctx, "user already welcomed", "userID", userID)
+		return nil
+	}
+
+	body := "Добро пожаловать! Здесь будут билеты, напоминания и новости мероприятий"
+	if firstName != "" {
+		body = fmt.Sprintf("%s, добро пожаловать! Здесь будут билеты, напоминания и новости мероприятий", firstName)
+	}
+
+	return s.CreateNotificationInternal(ctx, &models.Notification{
+		UserID: userID,
+		Type:   string(dto.NotificationTypeAccount),
+		Title:  "Добро пожаловать",
+		Body:   body,
+	})
+}
@@ -14,6 +14,8 @@ import (
 	"notification-service/internal/models"
 
 	"github.com/stretchr/testify/require"
+
+	"user-service/userclient/requestid"
 )
 
 type mockRepo struct {
@@ -25,6 +27,9 @@ type mockRepo struct {
 	GetNotificationPreferencesFn    func(userID uint) (*models.NotificationPreference, error)
 	UpdateNotificationPreferencesFn func(*models.NotificationPreference) error
 	UnreadNotificationsCountsFn     func(userID uint) (int64, error)
+	ExportUserDataFn                func(userID uint) ([]models.Notification, *models.NotificationPreference, error)
+	DeleteUserDataFn                func(userID uint) (int64, error)
+	CreateDefaultPreferencesFn      func(userID uint) (bool, error)
 	
 }
 
@@ -77,6 +82,27 @@ func (m *mockRepo) UpdateNotificationPreferences(pref *models.NotificationPrefer
 	}
 	return nil
 }
+
+func (m *mockRepo) ExportUserData(userID uint) ([]models.Notification, *models.NotificationPreference, error) {
+	if m.ExportUserDataFn != nil {
+		return m.ExportUserDataFn(userID)
+	}
+	return nil, nil, nil
+}
+
+func (m *mockRepo) DeleteUserData(userID uint) (int64, error) {
+	if m.DeleteUserDataFn != nil {
+		return m.DeleteUserDataFn(userID)
+	}
+	return 0, nil
+}
+func (m *mockRepo) CreateDefaultPreferences(userID uint) (bool, error) {
+	if m.CreateDefaultPreferencesFn != nil {
+		return m.CreateDefaultPreferencesFn(userID)
+	}
+	return true, nil
+}
+
 func (m *mockRepo) UnreadNotificationsCounts(userID uint) (int64, error) {
 	if m.UnreadNotificationsCountsFn != nil {
 		return m.UnreadNotificationsCountsFn(userID)
@@ -94,7 +120,7 @@ func TestService_CreateNotificationInternal(t *testing.T) {
 	svc := newSvc(m)
 
 	// invalid user id
-	err := svc.CreateNotificationInternal(&models.Notification{UserID: 0})
+	err := svc.CreateNotificationInternal(context.Background(), &models.Notification{UserID: 0})
 	require.Error(t, err)
 
 	// success path ensures Read=false and Create called
@@ -103,9 +129,10 @@ func TestService_CreateNotificationInternal(t *testing.T) {
 		called = true
 		require.Equal(t, uint(5), n.UserID)
 		require.False(t, n.Read)
+		require.Equal(t, "req-1", n.RequestID)
 		return nil
 	}
-	err = svc.CreateNotificationInternal(&models.Notification{UserID: 5})
+	err = svc.CreateNotificationInternal(requestid.NewContext(context.Background(), "req-1"), &models.Notification{UserID: 5})
 	require.NoError(t, err)
 	require.True(t, called)
 }
@@ -250,3 +277,31 @@ func TestService_Count(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int64(7), got)
 }
+
+func TestService_WelcomeUser(t *testing.T) {
+	m := &mockRepo{}
+	svc := newSvc(m)
+
+	welcomed := map[uint]bool{}
+	m.CreateDefaultPreferencesFn = func(userID uint) (bool, error) {
+		if welcomed[userID] {
+			return false, nil
+		}
+		welcomed[userID] = true
+		return true, nil
+	}
+	var created []*models.Notification
+	m.CreateFn = func(n *models.Notification) error {
+		created = append(created, n)
+		return nil
+	}
+
+	require.NoError(t, svc.WelcomeUser(context.Background(), 7, "Анна"))
+	// повторная доставка user.registered не дублирует приветствие
+	require.NoError(t, svc.WelcomeUser(context.Background(), 7, "Анна"))
+
+	require.Len(t, created, 1)
+	require.Equal(t, uint(7), created[0].UserID)
+	require.Equal(t, string(dto.NotificationTypeAccount), created[0].Type)
+	require.Contains(t, created[0].Body, "Анна")
+}
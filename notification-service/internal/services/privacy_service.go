@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"notification-service/internal/dto"
+	"notification-service/internal/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// PrivacyService выгружает и удаляет уведомления пользователя по запросу
+// из user-service (топики user.export_requested и user.deletion_requested).
+type PrivacyService struct {
+	notificationRepo repository.NotificationRepo
+	redis            *redis.Client
+	log              *slog.Logger
+}
+
+func NewPrivacyService(notificationRepo repository.NotificationRepo, log *slog.Logger, redis *redis.Client) *PrivacyService {
+	return &PrivacyService{
+		notificationRepo: notificationRepo,
+		redis:            redis,
+		log:              log,
+	}
+}
+
+func (s *PrivacyService) Export(ctx context.Context, userID uint) (*dto.UserNotificationsExport, error) {
+	nots, pref, err := s.notificationRepo.ExportUserData(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dto.UserNotificationsExport{
+		Notifications: make([]dto.UserNotificationExport, 0, len(nots)),
+	}
+	for _, n := range nots {
+		item := dto.UserNotificationExport{
+			ID:        n.ID,
+			EventID:   n.EventID,
+			Type:      n.Type,
+			Title:     n.Title,
+			Body:      n.Body,
+			Read:      n.Read,
+			CreatedAt: n.CreatedAt,
+		}
+		if n.DeletedAt.Valid {
+			item.DeletedAt = &n.DeletedAt.Time
+		}
+		out.Notifications = append(out.Notifications, item)
+	}
+	if pref != nil {
+		out.Preferences = &dto.UserPreferencesExport{
+			TicketPurchased: pref.TicketPurchased,
+			EventCanceled:   pref.EventCanceled,
+			ScheduleChanged: pref.ScheduleChanged,
+			EventReminder:   pref.EventReminder,
+			PushEnabled:     pref.PushEnabled,
+			InAppEnabled:    pref.InAppEnabled,
+		}
+	}
+
+	s.log.InfoContext(ctx, "user notifications exported", "userID", userID, "count", len(out.Notifications))
+	return out, nil
+}
+
+// Erase удаляет уведомления и настройки пользователя вместе с кэшем.
+func (s *PrivacyService) Erase(ctx context.Context, userID uint) (*dto.UserNotificationsErasure, error) {
+	deleted, err := s.notificationRepo.DeleteUserData(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.redis != nil {
+		cacheKey := fmt.Sprintf("notifications:%d:first", userID)
+		if err := s.redis.Del(ctx, cacheKey).Err(); err != nil {
+			s.log.WarnContext(ctx, "failed to drop notifications cache", "userID", userID, "error", err)
+		}
+	}
+
+	return &dto.UserNotificationsErasure{NotificationsDeleted: deleted}, nil
+}
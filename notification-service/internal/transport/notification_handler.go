@@ -53,7 +53,7 @@ func getUserID(ctx *gin.Context) (uint, error) {
 func (h *NotificationHandler) GetAllNotifications(ctx *gin.Context) {
 	userID, err := getUserID(ctx)
 	if err != nil {
-		h.log.Warn("unauthorized request", "error", err)
+		h.log.WarnContext(ctx.Request.Context(), "unauthorized request", "error", err)
 		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
@@ -61,7 +61,7 @@ func (h *NotificationHandler) GetAllNotifications(ctx *gin.Context) {
 	limitStr := ctx.DefaultQuery("limit", "50")
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit <= 0 {
-		h.log.Warn("invalid limit parameter", "userID", userID, "limit", limitStr)
+		h.log.WarnContext(ctx.Request.Context(), "invalid limit parameter", "userID", userID, "limit", limitStr)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
 		return
 	}
@@ -70,7 +70,7 @@ func (h *NotificationHandler) GetAllNotifications(ctx *gin.Context) {
 	if lastIDStr := ctx.Query("last_id"); lastIDStr != "" {
 		val, err := strconv.ParseUint(lastIDStr, 10, 64)
 		if err != nil {
-			h.log.Warn("invalid last_id parameter", "userID", userID, "last_id", lastIDStr)
+			h.log.WarnContext(ctx.Request.Context(), "invalid last_id parameter", "userID", userID, "last_id", lastIDStr)
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid last_id"})
 			return
 		}
@@ -79,55 +79,55 @@ func (h *NotificationHandler) GetAllNotifications(ctx *gin.Context) {
 
 	list, err := h.srv.GetNotifications(ctx.Request.Context(), userID, limit, lastID)
 	if err != nil {
-		h.log.Error("failed to get notifications", "userID", userID, "error", err)
+		h.log.ErrorContext(ctx.Request.Context(), "failed to get notifications", "userID", userID, "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	h.log.Info("notifications returned", "userID", userID, "count", len(list))
+	h.log.InfoContext(ctx.Request.Context(), "notifications returned", "userID", userID, "count", len(list))
 	ctx.JSON(http.StatusOK, list)
 }
 
 func (h *NotificationHandler) ReadAllNotification(ctx *gin.Context) {
 	userID, err := getUserID(ctx)
 	if err != nil {
-		h.log.Warn("unauthorized request", "error", err)
+		h.log.WarnContext(ctx.Request.Context(), "unauthorized request", "error", err)
 		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
 	if err := h.srv.CheckAll(userID); err != nil {
-		h.log.Warn("failed to mark all notifications as read", "userID", userID, "error", err)
+		h.log.WarnContext(ctx.Request.Context(), "failed to mark all notifications as read", "userID", userID, "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	h.log.Info("all notifications marked as read", "userID", userID)
+	h.log.InfoContext(ctx.Request.Context(), "all notifications marked as read", "userID", userID)
 	ctx.JSON(http.StatusOK, gin.H{"message": "all notifications marked as read"})
 }
 
 func (h *NotificationHandler) ReadNotificationByID(ctx *gin.Context) {
 	userID, err := getUserID(ctx)
 	if err != nil {
-		h.log.Warn("unauthorized request", "error", err)
+		h.log.WarnContext(ctx.Request.Context(), "unauthorized request", "error", err)
 		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
-		h.log.Warn("invalid notification id", "userID", userID, "id", ctx.Param("id"))
+		h.log.WarnContext(ctx.Request.Context(), "invalid notification id", "userID", userID, "id", ctx.Param("id"))
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification id"})
 		return
 	}
 
 	if err := h.srv.CheckNotificationsByID(userID, uint(id)); err != nil {
-		h.log.Warn("failed to mark notification as read", "userID", userID, "notificationID", id, "error", err)
+		h.log.WarnContext(ctx.Request.Context(), "failed to mark notification as read", "userID", userID, "notificationID", id, "error", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	h.log.Info("notification marked as read", "userID", userID, "notificationID", id)
+	h.log.InfoContext(ctx.Request.Context(), "notification marked as read", "userID", userID, "notificationID", id)
 	ctx.JSON(http.StatusOK, gin.H{"message": "notification marked as read"})
 }
 
@@ -140,7 +140,7 @@ func (h *NotificationHandler) DeleteNotification(ctx *gin.Context) {
 
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
 	if err != nil {
-		h.log.Warn(
+		h.log.WarnContext(ctx.Request.Context(),
 			"invalid notification id for delete",
 			"userID", userID,
 			"id", ctx.Param("id"),
@@ -150,7 +150,7 @@ func (h *NotificationHandler) DeleteNotification(ctx *gin.Context) {
 	}
 
 	if err := h.srv.DeleteNotificationByID(userID, uint(id)); err != nil {
-		h.log.Warn(
+		h.log.WarnContext(ctx.Request.Context(),
 			"failed to delete notification",
 			"userID", userID,
 			"notificationID", id,
@@ -160,7 +160,7 @@ func (h *NotificationHandler) DeleteNotification(ctx *gin.Context) {
 		return
 	}
 
-	h.log.Info(
+	h.log.InfoContext(ctx.Request.Context(),
 		"notification deleted",
 		"userID", userID,
 		"notificationID", id,
@@ -177,7 +177,7 @@ func (h *NotificationHandler) GetNotificationsPreferences(ctx *gin.Context) {
 
 	settings, err := h.srv.GetNotificationPreferences(userID)
 	if err != nil {
-		h.log.Warn(
+		h.log.WarnContext(ctx.Request.Context(),
 			"failed to get notification preferences",
 			"userID", userID,
 			"error", err,
@@ -186,7 +186,7 @@ func (h *NotificationHandler) GetNotificationsPreferences(ctx *gin.Context) {
 		return
 	}
 
-	h.log.Info(
+	h.log.InfoContext(ctx.Request.Context(),
 		"notification preferences returned",
 		"userID", userID,
 	)
@@ -203,7 +203,7 @@ func (h *NotificationHandler) UpdateNotificationPreferences(ctx *gin.Context) {
 
 	var req dto.UpdateNotificationPreferencesRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		h.log.Warn(
+		h.log.WarnContext(ctx.Request.Context(),
 			"invalid update notification preferences payload",
 			"userID", userID,
 			"error", err,
@@ -214,7 +214,7 @@ func (h *NotificationHandler) UpdateNotificationPreferences(ctx *gin.Context) {
 
 	settings, err := h.srv.Update(userID, req)
 	if err != nil {
-		h.log.Warn(
+		h.log.WarnContext(ctx.Request.Context(),
 			"failed to update notification preferences",
 			"userID", userID,
 			"error", err,
@@ -223,7 +223,7 @@ func (h *NotificationHandler) UpdateNotificationPreferences(ctx *gin.Context) {
 		return
 	}
 
-	h.log.Info(
+	h.log.InfoContext(ctx.Request.Context(),
 		"notification preferences updated",
 		"userID", userID,
 	)
@@ -240,7 +240,7 @@ func (h *NotificationHandler) Count(ctx *gin.Context) {
 
 	count, err := h.srv.Count(userID)
 	if err != nil {
-		h.log.Warn(
+		h.log.WarnContext(ctx.Request.Context(),
 			"failed to count unread notifications",
 			"userID", userID,
 			"error", err,
@@ -249,7 +249,7 @@ func (h *NotificationHandler) Count(ctx *gin.Context) {
 		return
 	}
 
-	h.log.Info(
+	h.log.InfoContext(ctx.Request.Context(),
 		"unread notifications count returned",
 		"userID", userID,
 		"count", count,
@@ -19,7 +19,7 @@ import (
 )
 
 type mockService struct {
-	CreateNotificationInternalFn func(*models.Notification) error
+	CreateNotificationInternalFn func(ctx context.Context, n *models.Notification) error
 	GetNotificationsFn           func(ctx context.Context, userID uint, limit int, lastID uint) ([]models.Notification, error)
 	CheckAllFn                   func(userID uint) error
 	CheckNotificationsByIDFn     func(userID, id uint) error
@@ -29,9 +29,9 @@ type mockService struct {
 	CountFn                      func(userID uint) (int64, error)
 }
 
-func (m *mockService) CreateNotificationInternal(n *models.Notification) error {
+func (m *mockService) CreateNotificationInternal(ctx context.Context, n *models.Notification) error {
 	if m.CreateNotificationInternalFn != nil {
-		return m.CreateNotificationInternalFn(n)
+		return m.CreateNotificationInternalFn(ctx, n)
 	}
 	return nil
 }
@@ -78,6 +78,10 @@ func (m *mockService) Count(userID uint) (int64, error) {
 	return 0, nil
 }
 
+func (m *mockService) WelcomeUser(ctx context.Context, userID uint, firstName string) error {
+	return nil
+}
+
 func newRouter(ms services.NotificationService) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
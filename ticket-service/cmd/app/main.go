@@ -6,13 +6,15 @@ import (
 	"ticket-service/internal/config"
 	"ticket-service/internal/kafka"
 	"ticket-service/internal/transport"
+	"user-service/userclient"
+	"user-service/userclient/requestid"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
 func main() {
-	logger := slog.New(slog.Default().Handler())
+	logger := config.InitLogger()
 
 	err := godotenv.Load(".env")
 
@@ -22,9 +24,8 @@ func main() {
 
 	db := config.DBConnect(logger)
 
-	kafkaProducer := kafka.NewProducer(
-		[]string{os.Getenv("KAFKA_BROKER")},
-	)
+	kafkaBrokers := []string{os.Getenv("KAFKA_BROKER")}
+	kafkaProducer := kafka.NewProducer(kafkaBrokers)
 	defer kafkaProducer.Close()
 
 	port := os.Getenv("SERVICE_PORT")
@@ -32,9 +33,17 @@ func main() {
 		port = "8082"
 	}
 
+	identitySecret := os.Getenv("INTERNAL_IDENTITY_SECRET")
+	if identitySecret == "" {
+		logger.Error("INTERNAL_IDENTITY_SECRET is not set")
+		os.Exit(1)
+	}
+
 	r := gin.Default()
+	r.Use(requestid.Middleware())
+	r.Use(userclient.RequireIdentity(identitySecret))
 
-	transport.RegisterRoutes(r, logger, db, kafkaProducer)
+	transport.RegisterRoutes(r, logger, db, kafkaProducer, kafkaBrokers)
 
 	if err := r.Run(":" + port); err != nil {
 		logger.Error("не удалось запустить сервер: ", slog.Any("error", err))
@@ -2,6 +2,8 @@ module ticket-service
 
 go 1.25.3
 
+require user-service/userclient v0.0.0
+
 require (
 	github.com/bytedance/sonic v1.14.0 // indirect
 	github.com/bytedance/sonic/loader v0.3.0 // indirect
@@ -49,3 +51,5 @@ require (
 	gorm.io/driver/postgres v1.6.0 // indirect
 	gorm.io/gorm v1.31.1 // indirect
 )
+
+replace user-service/userclient => ../user-service/userclient
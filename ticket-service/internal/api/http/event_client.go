@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"ticket-service/internal/dto"
 	dto_api "ticket-service/internal/dto/api"
+
+	"user-service/userclient/requestid"
 )
 
 type EventClient struct {
@@ -22,7 +24,7 @@ func NewEventClient(baseURL string) *EventClient {
 }
 
 func (c *EventClient) GetEvent(ctx context.Context, eventId uint64) (*dto_api.EventResponse, error) {
-	url := fmt.Sprintf("%s/event/%d", c.baseURL, eventId)
+	url := fmt.Sprintf("%s/events/%d", c.baseURL, eventId)
 
 	req, err := http.NewRequestWithContext(
 		ctx, http.MethodGet, url, nil,
@@ -30,6 +32,9 @@ func (c *EventClient) GetEvent(ctx context.Context, eventId uint64) (*dto_api.Ev
 	if err != nil {
 		return nil, err
 	}
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
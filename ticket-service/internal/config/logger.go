@@ -0,0 +1,28 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+
+	"user-service/userclient/requestid"
+)
+
+func InitLogger() *slog.Logger {
+	level := slog.LevelInfo
+
+	switch strings.ToLower(os.Getenv("LOG_LEVEL")) {
+	case "debug":
+		level = slog.LevelDebug
+	case "warn":
+		level = slog.LevelWarn
+	case "error":
+		level = slog.LevelError
+	}
+
+	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: level,
+	})
+
+	return slog.New(requestid.NewHandler(handler))
+}
@@ -3,9 +3,17 @@ package dto_api
 type EventStatus string
 
 const (
-	EventStatusPublished  EventStatus = "published"
+	EventStatusPublished EventStatus = "published"
 )
 
 type EventResponse struct {
-	Status EventStatus `json:"status"`
+	Status         EventStatus             `json:"status"`
+	UserID         uint64                  `json:"user_id"`
+	OrganizationID *uint64                 `json:"organization_id"`
+	Schedule       []EventScheduleResponse `json:"schedule"`
+}
+
+type EventScheduleResponse struct {
+	ID       uint64  `json:"id"`
+	SeriesID *uint64 `json:"series_id"`
 }
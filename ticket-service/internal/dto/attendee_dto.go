@@ -0,0 +1,27 @@
+package dto
+
+import "ticket-service/internal/models"
+
+// Attendee — строка списка участников мероприятия для организатора.
+// Имя и email приходят из user-service и пусты, если он недоступен.
+type Attendee struct {
+	TicketID     uint                `json:"ticket_id"`
+	TicketTypeID uint                `json:"ticket_type_id"`
+	Status       models.TicketStatus `json:"status"`
+	UserID       uint64              `json:"user_id"`
+	FirstName    string              `json:"first_name,omitempty"`
+	LastName     string              `json:"last_name,omitempty"`
+	Email        string              `json:"email,omitempty"`
+}
+
+// HoldersQuery — чьи билеты действуют на изменённую активность: кроме билетов
+// на всё мероприятие, билеты на любое из повторений ScheduleIDs и на любую
+// из серий SeriesIDs. Оба параметра можно повторять.
+type HoldersQuery struct {
+	ScheduleIDs []uint64 `form:"schedule_id"`
+	SeriesIDs   []uint64 `form:"series_id"`
+}
+
+type HoldersResponse struct {
+	UserIDs []uint64 `json:"user_ids"`
+}
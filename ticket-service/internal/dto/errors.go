@@ -7,6 +7,10 @@ var (
 	ErrEventNotPublished = errors.New("event not published")
 	ErrEventNotStarted   = errors.New("event not started")
 	ErrEventEnded        = errors.New("event already ended")
+	ErrEventAccessDenied = errors.New("access to event denied")
+
+	ErrScheduleNotFound       = errors.New("schedule occurrence or series not found in event")
+	ErrTicketTypeScopeInvalid = errors.New("ticket type can apply to an occurrence or to a series, not both")
 
 	ErrTicketSoldOut             = errors.New("tickets sold out")
 	ErrTicketNotFoundOrNotActive = errors.New("tickets not found or not active")
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"time"
+
+	"ticket-service/internal/models"
+)
+
+// UserTicketsExport — данные пользователя в ticket-service для выгрузки.
+type UserTicketsExport struct {
+	Tickets []UserTicketExport `json:"tickets"`
+}
+
+type UserTicketExport struct {
+	ID          uint                  `json:"id"`
+	EventID     uint64                `json:"event_id"`
+	TicketType  models.TicketTypeKind `json:"ticket_type"`
+	Price       int64                 `json:"price"`
+	Code        string                `json:"code"`
+	Status      models.TicketStatus   `json:"status"`
+	PurchasedAt time.Time             `json:"purchased_at"`
+}
+
+// UserTicketsErasure — сводка по удалению данных пользователя.
+type UserTicketsErasure struct {
+	TicketsAnonymized int64 `json:"tickets_anonymized"`
+}
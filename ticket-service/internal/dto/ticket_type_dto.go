@@ -8,4 +8,6 @@ type CreateTicketTypeRequest struct {
 	Quantity   int64     `json:"quantity" binding:"required,gt=0"`
 	SalesStart time.Time `json:"sales_start" binding:"required"`
 	SalesEnd   time.Time `json:"sales_end" binding:"required"`
+	ScheduleID *uint64   `json:"schedule_id"` // билет на одно повторение
+	SeriesID   *uint64   `json:"series_id"`   // билет на все повторения серии
 }
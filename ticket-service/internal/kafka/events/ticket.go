@@ -19,3 +19,13 @@ type TicketCheckinEvent struct {
 	UserID       uint64    `json:"user_id"`
 	CheckedinAt  time.Time `json:"checked_in_at"`
 }
+
+// TicketInventoryChangedEvent — цены и остаток билетов мероприятия после
+// изменения. Это снимок, а не разница: повторная доставка безопасна.
+type TicketInventoryChangedEvent struct {
+	EventID    uint64    `json:"event_id"`
+	MinPrice   int64     `json:"min_price"`
+	MaxPrice   int64     `json:"max_price"`
+	Available  int       `json:"available"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -3,9 +3,12 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	kafka "ticket-service/internal/kafka/events"
 
 	kafka_go "github.com/segmentio/kafka-go"
+
+	"user-service/userclient/requestid"
 )
 
 type Producer struct {
@@ -26,8 +29,9 @@ func (p *Producer) PublishTicketPurchased(
 	event kafka.TicketPurchasedEvent,
 ) error {
 	return p.writer.WriteMessages(ctx, kafka_go.Message{
-		Topic: TopicTicketPurchased,
-		Value: mustJSON(event),
+		Topic:   TopicTicketPurchased,
+		Value:   mustJSON(event),
+		Headers: messageHeaders(ctx),
 	})
 }
 
@@ -36,8 +40,23 @@ func (p *Producer) PublishTicketCheckin(
 	event kafka.TicketCheckinEvent,
 ) error {
 	return p.writer.WriteMessages(ctx, kafka_go.Message{
-		Topic: TopicTicketCheckin,
-		Value: mustJSON(event),
+		Topic:   TopicTicketCheckin,
+		Value:   mustJSON(event),
+		Headers: messageHeaders(ctx),
+	})
+}
+
+// PublishInventoryChanged публикует снимок с ключом по мероприятию, чтобы
+// снимки одного мероприятия читались по порядку.
+func (p *Producer) PublishInventoryChanged(
+	ctx context.Context,
+	event kafka.TicketInventoryChangedEvent,
+) error {
+	return p.writer.WriteMessages(ctx, kafka_go.Message{
+		Topic:   TopicTicketInventoryChanged,
+		Key:     []byte(strconv.FormatUint(event.EventID, 10)),
+		Value:   mustJSON(event),
+		Headers: messageHeaders(ctx),
 	})
 }
 
@@ -45,6 +64,15 @@ func (p *Producer) Close() error {
 	return p.writer.Close()
 }
 
+// messageHeaders передаёт ID запроса консьюмерам вместе с событием.
+func messageHeaders(ctx context.Context) []kafka_go.Header {
+	id := requestid.FromContext(ctx)
+	if id == "" {
+		return nil
+	}
+	return []kafka_go.Header{{Key: requestid.Header, Value: []byte(id)}}
+}
+
 func mustJSON(v any) []byte {
 	b, err := json.Marshal(v)
 	if err != nil {
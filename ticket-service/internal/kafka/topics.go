@@ -3,4 +3,7 @@ package kafka
 const (
 	TopicTicketPurchased = "ticket.purchased"
 	TopicTicketCheckin   = "ticket.checkin"
+	// TopicTicketInventoryChanged — снимок цен и остатка билетов мероприятия
+	// для поиска в event-service.
+	TopicTicketInventoryChanged = "ticket.inventory_changed"
 )
@@ -17,4 +17,5 @@ type Ticket struct {
 	UserID       uint64       `json:"user_id" gorm:"not null"`
 	Code         string       `json:"code" gorm:"type:varchar(64);not null;uniqueIndex"`
 	Status       TicketStatus `json:"status" gorm:"type:varchar(20);not null;default:'active'"`
+	ScheduleID   *uint64      `json:"schedule_id,omitempty" gorm:"index"` // повторение, на которое куплен билет
 }
@@ -19,4 +19,9 @@ type TicketType struct {
 	Sold       int            `json:"sold" gorm:"not null;default:0"`
 	SalesStart time.Time      `json:"sales_start" gorm:"not null"`
 	SalesEnd   time.Time      `json:"sales_end" gorm:"not null"`
+
+	// Область действия: одно повторение расписания или вся серия event-service.
+	// Оба nil — билет на всё мероприятие.
+	ScheduleID *uint64 `json:"schedule_id,omitempty" gorm:"index"`
+	SeriesID   *uint64 `json:"series_id,omitempty" gorm:"index"`
 }
@@ -77,6 +77,25 @@ func (r *TicketRepository) IsExist(code string) (bool, error) {
 	return true, nil
 }
 
+func (r *TicketRepository) GetActiveByCode(code string) (*models.Ticket, error) {
+	var ticket models.Ticket
+
+	err := r.db.
+		Where("code = ?", code).
+		Where("status = ?", models.TicketStatusActive).
+		First(&ticket).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dto.ErrTicketNotFoundOrNotActive
+		}
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
 func (r *TicketRepository) Checkin(code string) (*models.Ticket, error) {
 	var ticket models.Ticket
 
@@ -110,3 +129,87 @@ func (r *TicketRepository) Checkin(code string) (*models.Ticket, error) {
 
 	return &ticket, nil
 }
+
+// ListByUser возвращает все билеты пользователя, включая удалённые.
+func (r *TicketRepository) ListByUser(userID uint64) ([]models.Ticket, error) {
+	var tickets []models.Ticket
+
+	err := r.db.Unscoped().
+		Preload("TicketType", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("user_id = ?", userID).
+		Order("id ASC").
+		Find(&tickets).
+		Error
+
+	return tickets, err
+}
+
+// AnonymizeUser отвязывает билеты от пользователя. Активные билеты
+// аннулируются, места возвращаются в продажу. Возвращает число билетов.
+func (r *TicketRepository) AnonymizeUser(userID uint64) (int64, error) {
+	var affected int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var active []models.Ticket
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND status = ?", userID, models.TicketStatusActive).
+			Find(&active).
+			Error; err != nil {
+			return err
+		}
+
+		released := make(map[uint]int)
+		for _, t := range active {
+			released[t.TicketTypeID]++
+		}
+		for typeID, n := range released {
+			if err := tx.Model(&models.TicketType{}).
+				Where("id = ?", typeID).
+				UpdateColumn("sold", gorm.Expr("GREATEST(sold - ?, 0)", n)).
+				Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.Ticket{}).
+			Where("user_id = ? AND status = ?", userID, models.TicketStatusActive).
+			Update("status", models.TicketStatusCancelled).
+			Error; err != nil {
+			return err
+		}
+
+		res := tx.Unscoped().Model(&models.Ticket{}).
+			Where("user_id = ?", userID).
+			Update("user_id", 0)
+		affected = res.RowsAffected
+		return res.Error
+	})
+
+	return affected, err
+}
+
+// HolderIDs возвращает владельцев активных билетов мероприятия. Без
+// scheduleIDs и seriesIDs — всех; иначе тех, чей билет действует на всё
+// мероприятие, на одно из повторений или на одну из серий.
+func (r *TicketRepository) HolderIDs(eventID uint64, scheduleIDs, seriesIDs []uint64) ([]uint64, error) {
+	var ids []uint64
+
+	q := r.db.Model(&models.Ticket{}).
+		Joins("JOIN ticket_types ON ticket_types.id = tickets.ticket_type_id").
+		Where("tickets.event_id = ? AND tickets.status = ? AND tickets.user_id <> 0", eventID, models.TicketStatusActive)
+
+	if len(scheduleIDs) > 0 || len(seriesIDs) > 0 {
+		scope := r.db.Where("ticket_types.schedule_id IS NULL AND ticket_types.series_id IS NULL")
+		if len(scheduleIDs) > 0 {
+			scope = scope.Or("ticket_types.schedule_id IN ?", scheduleIDs)
+		}
+		if len(seriesIDs) > 0 {
+			scope = scope.Or("ticket_types.series_id IN ?", seriesIDs)
+		}
+		q = q.Where(scope)
+	}
+
+	err := q.Distinct().Order("tickets.user_id").Pluck("tickets.user_id", &ids).Error
+	return ids, err
+}
@@ -51,3 +51,24 @@ func (r *TicketTypeRepository) IncrementSold(id uint64) error {
 		UpdateColumn("sold", gorm.Expr("sold + 1")).
 		Error
 }
+
+// EventInventory — цены и остаток билетов по всем типам мероприятия.
+type EventInventory struct {
+	MinPrice  int64
+	MaxPrice  int64
+	Available int
+}
+
+func (r *TicketTypeRepository) InventoryByEvent(ctx context.Context, eventID uint64) (*EventInventory, error) {
+	var inv EventInventory
+	err := r.db.WithContext(ctx).Model(&models.TicketType{}).
+		Select(`COALESCE(MIN(price), 0) AS min_price,
+			COALESCE(MAX(price), 0) AS max_price,
+			COALESCE(SUM(GREATEST(quantity - sold, 0)), 0) AS available`).
+		Where("event_id = ?", eventID).
+		Scan(&inv).Error
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"ticket-service/internal/dto"
+	"ticket-service/internal/repository"
+
+	"user-service/userclient"
+)
+
+// AttendeeService собирает список участников: билеты мероприятия
+// плюс имена и email владельцев из user-service одним пакетным запросом.
+type AttendeeService struct {
+	access     *EventAccess
+	ticketRepo *repository.TicketRepository
+	users      *userclient.Client
+	logger     *slog.Logger
+}
+
+func NewAttendeeService(
+	access *EventAccess,
+	ticketRepo *repository.TicketRepository,
+	users *userclient.Client,
+	logger *slog.Logger,
+) *AttendeeService {
+	return &AttendeeService{
+		access:     access,
+		ticketRepo: ticketRepo,
+		users:      users,
+		logger:     logger,
+	}
+}
+
+// List возвращает участников мероприятия. Список с контактами видят только
+// те, кто ведёт мероприятие (см. EventAccess.CanManage).
+func (s *AttendeeService) List(ctx context.Context, actor Actor, eventId uint64) ([]dto.Attendee, error) {
+	if err := s.access.CanManage(ctx, actor, eventId); err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.ticketRepo.List(dto.TicketListFilter{EventID: &eventId})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uint, 0, len(tickets))
+	for _, t := range tickets {
+		userIDs = append(userIDs, uint(t.UserID))
+	}
+
+	// без user-service отдаём список без имён, а не ошибку
+	users, err := s.users.GetUsers(ctx, userIDs, userclient.ViewContact)
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to resolve attendees", "error", err, "event_id", eventId)
+	}
+
+	attendees := make([]dto.Attendee, 0, len(tickets))
+	for _, t := range tickets {
+		a := dto.Attendee{
+			TicketID:     t.ID,
+			TicketTypeID: t.TicketTypeID,
+			Status:       t.Status,
+			UserID:       t.UserID,
+		}
+		if u, ok := users[uint(t.UserID)]; ok {
+			a.FirstName = u.FirstName
+			a.LastName = u.LastName
+			a.Email = u.Email
+		}
+		attendees = append(attendees, a)
+	}
+
+	return attendees, nil
+}
+
+// Holders — владельцы билетов, которых касается изменение расписания.
+// Вызывается другими сервисами, права проверяет userclient.RequireService.
+func (s *AttendeeService) Holders(eventId uint64, query dto.HoldersQuery) ([]uint64, error) {
+	return s.ticketRepo.HolderIDs(eventId, query.ScheduleIDs, query.SeriesIDs)
+}
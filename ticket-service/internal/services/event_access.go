@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	api_http "ticket-service/internal/api/http"
+	"ticket-service/internal/dto"
+
+	"user-service/userclient"
+)
+
+const RoleAdmin = "admin"
+
+// Actor — пользователь из заголовков, подписанных gateway.
+type Actor struct {
+	UserID uint64
+	Role   string
+}
+
+// EventAccess решает, кто работает с мероприятием: администратор, автор
+// личного мероприятия или участник организации-владельца с нужной ролью.
+type EventAccess struct {
+	eventClient *api_http.EventClient
+	members     *userclient.Client
+}
+
+func NewEventAccess(eventClient *api_http.EventClient, members *userclient.Client) *EventAccess {
+	return &EventAccess{
+		eventClient: eventClient,
+		members:     members,
+	}
+}
+
+// CanManage — владелец и менеджер организации: список участников и т.п.
+func (a *EventAccess) CanManage(ctx context.Context, actor Actor, eventId uint64) error {
+	return a.authorize(ctx, actor, eventId, userclient.OrgRole.CanManageEvents)
+}
+
+// CanCheckIn — любой участник организации, включая сотрудников на входе.
+func (a *EventAccess) CanCheckIn(ctx context.Context, actor Actor, eventId uint64) error {
+	return a.authorize(ctx, actor, eventId, userclient.OrgRole.CanCheckIn)
+}
+
+func (a *EventAccess) authorize(ctx context.Context, actor Actor, eventId uint64, allowed func(userclient.OrgRole) bool) error {
+	if actor.Role == RoleAdmin {
+		return nil
+	}
+
+	eventResp, err := a.eventClient.GetEvent(ctx, eventId)
+	if err != nil {
+		return err
+	}
+
+	if eventResp.OrganizationID == nil {
+		if eventResp.UserID != actor.UserID {
+			return dto.ErrEventAccessDenied
+		}
+		return nil
+	}
+
+	m, err := a.members.GetMembership(ctx, uint(*eventResp.OrganizationID), uint(actor.UserID))
+	if errors.Is(err, userclient.ErrNotMember) {
+		return dto.ErrEventAccessDenied
+	}
+	if err != nil {
+		return err
+	}
+	if !allowed(m.Role) {
+		return dto.ErrEventAccessDenied
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"ticket-service/internal/kafka"
+	kafka_events "ticket-service/internal/kafka/events"
+	"ticket-service/internal/repository"
+	"time"
+)
+
+// InventoryPublisher сообщает event-service цены и остаток билетов
+// мероприятия, по ним работают фильтры поиска.
+type InventoryPublisher struct {
+	ticketTypeRepo *repository.TicketTypeRepository
+	kafkaProducer  *kafka.Producer
+	logger         *slog.Logger
+}
+
+func NewInventoryPublisher(
+	ticketTypeRepo *repository.TicketTypeRepository,
+	kafkaProducer *kafka.Producer,
+	logger *slog.Logger,
+) *InventoryPublisher {
+	return &InventoryPublisher{
+		ticketTypeRepo: ticketTypeRepo,
+		kafkaProducer:  kafkaProducer,
+		logger:         logger,
+	}
+}
+
+// Publish отправляет текущий снимок. Ошибка только логируется: билеты уже
+// сохранены, а следующий снимок исправит данные поиска.
+func (p *InventoryPublisher) Publish(ctx context.Context, eventId uint64) {
+	inv, err := p.ticketTypeRepo.InventoryByEvent(ctx, eventId)
+	if err != nil {
+		p.logger.WarnContext(ctx, "failed to load ticket inventory", "error", err, "event_id", eventId)
+		return
+	}
+
+	event := kafka_events.TicketInventoryChangedEvent{
+		EventID:    eventId,
+		MinPrice:   inv.MinPrice,
+		MaxPrice:   inv.MaxPrice,
+		Available:  inv.Available,
+		OccurredAt: time.Now(),
+	}
+	if err := p.kafkaProducer.PublishInventoryChanged(ctx, event); err != nil {
+		p.logger.WarnContext(ctx, "kafka publish failed", "error", err.Error(), "event_id", eventId)
+	}
+}
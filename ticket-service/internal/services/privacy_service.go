@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"ticket-service/internal/dto"
+	"ticket-service/internal/repository"
+)
+
+// PrivacyService выгружает и обезличивает билеты пользователя по запросу
+// из user-service (топики user.export_requested и user.deletion_requested).
+type PrivacyService struct {
+	ticketRepo *repository.TicketRepository
+	logger     *slog.Logger
+}
+
+func NewPrivacyService(ticketRepo *repository.TicketRepository, logger *slog.Logger) *PrivacyService {
+	return &PrivacyService{
+		ticketRepo: ticketRepo,
+		logger:     logger,
+	}
+}
+
+func (s *PrivacyService) Export(ctx context.Context, userID uint64) (*dto.UserTicketsExport, error) {
+	tickets, err := s.ticketRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dto.UserTicketsExport{Tickets: make([]dto.UserTicketExport, 0, len(tickets))}
+	for _, t := range tickets {
+		out.Tickets = append(out.Tickets, dto.UserTicketExport{
+			ID:          t.ID,
+			EventID:     t.EventID,
+			TicketType:  t.TicketType.Type,
+			Price:       t.TicketType.Price,
+			Code:        t.Code,
+			Status:      t.Status,
+			PurchasedAt: t.CreatedAt,
+		})
+	}
+
+	s.logger.InfoContext(ctx, "user tickets exported", "user_id", userID, "tickets", len(out.Tickets))
+	return out, nil
+}
+
+// Erase отвязывает билеты от пользователя; повторный вызов ничего не меняет.
+func (s *PrivacyService) Erase(ctx context.Context, userID uint64) (*dto.UserTicketsErasure, error) {
+	n, err := s.ticketRepo.AnonymizeUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "user tickets anonymized", "user_id", userID, "tickets", n)
+	return &dto.UserTicketsErasure{TicketsAnonymized: n}, nil
+}
@@ -23,7 +23,9 @@ type TicketService struct {
 	ticketRepo     *repository.TicketRepository
 	ticketTypeRepo *repository.TicketTypeRepository
 	eventClient    *api_http.EventClient
+	access         *EventAccess
 	kafkaProducer  *kafka.Producer
+	inventory      *InventoryPublisher
 	db             *gorm.DB
 	logger         *slog.Logger
 }
@@ -32,7 +34,9 @@ func NewTicketService(
 	ticketRepo *repository.TicketRepository,
 	ticketTypeRepo *repository.TicketTypeRepository,
 	eventClient *api_http.EventClient,
+	access *EventAccess,
 	kafkaProducer *kafka.Producer,
+	inventory *InventoryPublisher,
 	db *gorm.DB,
 	logger *slog.Logger,
 ) *TicketService {
@@ -40,7 +44,9 @@ func NewTicketService(
 		ticketRepo:     ticketRepo,
 		ticketTypeRepo: ticketTypeRepo,
 		eventClient:    eventClient,
+		access:         access,
 		kafkaProducer:  kafkaProducer,
+		inventory:      inventory,
 		db:             db,
 		logger:         logger,
 	}
@@ -92,13 +98,14 @@ func (s *TicketService) Create(ctx context.Context, eventId uint64, requestDto d
 			UserID:       requestDto.UserID,
 			Code:         uuid.NewString(),
 			Status:       models.TicketStatusActive,
+			ScheduleID:   ticketType.ScheduleID,
 		}
 
 		return ticketRepo.Create(ticket)
 	})
 
 	if err != nil {
-		s.logger.Error(err.Error())
+		s.logger.ErrorContext(ctx, err.Error())
 		return nil, err
 	}
 
@@ -113,8 +120,9 @@ func (s *TicketService) Create(ctx context.Context, eventId uint64, requestDto d
 	}
 
 	if err := s.kafkaProducer.PublishTicketPurchased(ctx, event); err != nil {
-		s.logger.Warn("kafka publish failed", "error", err.Error())
+		s.logger.WarnContext(ctx, "kafka publish failed", "error", err.Error())
 	}
+	s.inventory.Publish(ctx, eventId)
 
 	return ticket, nil
 }
@@ -138,7 +146,17 @@ func (s *TicketService) IsExist(codeDto *dto.TicketCode) (bool, error) {
 	return isExist, nil
 }
 
-func (s *TicketService) Checkin(ctx context.Context, codeDto *dto.TicketCode) error {
+// Checkin гасит билет. Право проверяется по мероприятию билета: его могут
+// гасить сотрудники организации, включая роль checkin.
+func (s *TicketService) Checkin(ctx context.Context, actor Actor, codeDto *dto.TicketCode) error {
+	found, err := s.ticketRepo.GetActiveByCode(codeDto.Code)
+	if err != nil {
+		return err
+	}
+	if err := s.access.CanCheckIn(ctx, actor, found.EventID); err != nil {
+		return err
+	}
+
 	ticket, err := s.ticketRepo.Checkin(codeDto.Code)
 	if err != nil {
 		return err
@@ -153,7 +171,7 @@ func (s *TicketService) Checkin(ctx context.Context, codeDto *dto.TicketCode) er
 	}
 
 	if err := s.kafkaProducer.PublishTicketCheckin(ctx, event); err != nil {
-		s.logger.Warn("kafka publish failed", "error", err.Error())
+		s.logger.WarnContext(ctx, "kafka publish failed", "error", err.Error())
 	}
 
 	return nil
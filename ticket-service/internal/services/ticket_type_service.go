@@ -11,24 +11,36 @@ import (
 
 type TicketTypeService struct {
 	eventClient    *api_http.EventClient
+	access         *EventAccess
 	ticketTypeRepo *repository.TicketTypeRepository
+	inventory      *InventoryPublisher
 }
 
 func NewTicketTypeService(
 	eventClient *api_http.EventClient,
+	access *EventAccess,
 	ticketTypeRepo *repository.TicketTypeRepository,
+	inventory *InventoryPublisher,
 ) *TicketTypeService {
 	return &TicketTypeService{
 		eventClient:    eventClient,
+		access:         access,
 		ticketTypeRepo: ticketTypeRepo,
+		inventory:      inventory,
 	}
 }
 
+// Create заводит тип билетов. Доступно тем, кто ведёт мероприятие.
 func (s *TicketTypeService) Create(
 	ctx context.Context,
+	actor Actor,
 	eventId uint64,
 	requestDto dto.CreateTicketTypeRequest,
 ) (*models.TicketType, error) {
+	if err := s.access.CanManage(ctx, actor, eventId); err != nil {
+		return nil, err
+	}
+
 	eventResp, err := s.eventClient.GetEvent(ctx, eventId)
 	if err != nil {
 		return nil, err
@@ -38,6 +50,10 @@ func (s *TicketTypeService) Create(
 		return nil, dto.ErrEventNotPublished
 	}
 
+	if err := checkScheduleScope(eventResp, requestDto.ScheduleID, requestDto.SeriesID); err != nil {
+		return nil, err
+	}
+
 	ticketType := &models.TicketType{
 		EventID:    eventId,
 		Type:       models.TicketTypeKind(requestDto.Type),
@@ -46,11 +62,34 @@ func (s *TicketTypeService) Create(
 		SalesStart: requestDto.SalesStart,
 		SalesEnd:   requestDto.SalesEnd,
 		Sold:       0,
+		ScheduleID: requestDto.ScheduleID,
+		SeriesID:   requestDto.SeriesID,
 	}
 
 	if err := s.ticketTypeRepo.Create(ctx, ticketType); err != nil {
 		return nil, err
 	}
+	s.inventory.Publish(ctx, eventId)
 
 	return ticketType, nil
 }
+
+// checkScheduleScope проверяет, что повторение или серия есть в расписании мероприятия.
+func checkScheduleScope(event *dto_api.EventResponse, scheduleID, seriesID *uint64) error {
+	if scheduleID != nil && seriesID != nil {
+		return dto.ErrTicketTypeScopeInvalid
+	}
+	if scheduleID == nil && seriesID == nil {
+		return nil
+	}
+
+	for _, schedule := range event.Schedule {
+		if scheduleID != nil && schedule.ID == *scheduleID {
+			return nil
+		}
+		if seriesID != nil && schedule.SeriesID != nil && *schedule.SeriesID == *seriesID {
+			return nil
+		}
+	}
+	return dto.ErrScheduleNotFound
+}
@@ -0,0 +1,50 @@
+package transport
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"ticket-service/internal/dto"
+	"ticket-service/internal/services"
+	"user-service/userclient"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InternalHandler — ручки для других сервисов. Gateway их не проксирует,
+// запросы подписываются сервисным токеном.
+type InternalHandler struct {
+	attendeeService *services.AttendeeService
+	logger          *slog.Logger
+}
+
+func NewInternalHandler(attendeeService *services.AttendeeService, logger *slog.Logger) *InternalHandler {
+	return &InternalHandler{attendeeService: attendeeService, logger: logger}
+}
+
+func (h *InternalHandler) RegisterRoutes(r *gin.Engine, serviceSecret string) {
+	internal := r.Group("/internal", userclient.RequireService(serviceSecret))
+	internal.GET("/events/:id/holders", h.GetHolders)
+}
+
+func (h *InternalHandler) GetHolders(c *gin.Context) {
+	eventId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || eventId == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+		return
+	}
+	var query dto.HoldersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ids, err := h.attendeeService.Holders(eventId, query)
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "failed to list ticket holders", "error", err, "event_id", eventId)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.HoldersResponse{UserIDs: ids})
+}
@@ -7,6 +7,7 @@ import (
 	"ticket-service/internal/kafka"
 	"ticket-service/internal/repository"
 	"ticket-service/internal/services"
+	"user-service/userclient"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -17,6 +18,7 @@ func RegisterRoutes(
 	logger *slog.Logger,
 	db *gorm.DB,
 	kafkaProducer *kafka.Producer,
+	kafkaBrokers []string,
 ) {
 	eventClientBaseUrl := os.Getenv("EVENT_SERVICE_BASE_URL")
 	if eventClientBaseUrl == "" {
@@ -30,12 +32,33 @@ func RegisterRoutes(
 
 	eventClient := api_http.NewEventClient(eventClientBaseUrl)
 
+	userServiceUrl := os.Getenv("USER_SERVICE_URL")
+	serviceSecret := os.Getenv("INTERNAL_SERVICE_SECRET")
+	if userServiceUrl == "" || serviceSecret == "" {
+		logger.Error("cannot resolve env params: USER_SERVICE_URL and INTERNAL_SERVICE_SECRET")
+		os.Exit(1)
+	}
+	userClient := userclient.New(userServiceUrl, "ticket-service", serviceSecret)
+
 	ticketTypeRepo := repository.NewTicketTypeRepository(db)
 	ticketRepo := repository.NewTicketRepository(db)
 
-	ticketTypeService := services.NewTicketTypeService(eventClient, ticketTypeRepo)
-	ticketService := services.NewTicketService(ticketRepo, ticketTypeRepo, eventClient, kafkaProducer, db, logger)
+	eventAccess := services.NewEventAccess(eventClient, userClient)
+
+	inventory := services.NewInventoryPublisher(ticketTypeRepo, kafkaProducer, logger)
 
-	ticketHandler := NewTicketHandler(ticketTypeService, ticketService, logger)
+	ticketTypeService := services.NewTicketTypeService(eventClient, eventAccess, ticketTypeRepo, inventory)
+	ticketService := services.NewTicketService(ticketRepo, ticketTypeRepo, eventClient, eventAccess, kafkaProducer, inventory, db, logger)
+
+	attendeeService := services.NewAttendeeService(eventAccess, ticketRepo, userClient, logger)
+
+	privacyService := services.NewPrivacyService(ticketRepo, logger)
+	kafka.NewDataRequestConsumer(kafkaBrokers, privacyService, userClient, logger).Start()
+
+	ticketHandler := NewTicketHandler(ticketTypeService, ticketService, attendeeService, logger)
 	ticketHandler.RegisterRoutes(router)
+
+	// владельцы билетов для уведомлений об изменении расписания
+	internalHandler := NewInternalHandler(attendeeService, logger)
+	internalHandler.RegisterRoutes(router, serviceSecret)
 }
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"ticket-service/internal/dto"
 	"ticket-service/internal/services"
+	"user-service/userclient"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,17 +15,20 @@ import (
 type TicketHandler struct {
 	ticketTypeService *services.TicketTypeService
 	ticketService     *services.TicketService
+	attendeeService   *services.AttendeeService
 	logger            *slog.Logger
 }
 
 func NewTicketHandler(
 	ticketTypeService *services.TicketTypeService,
 	ticketService *services.TicketService,
+	attendeeService *services.AttendeeService,
 	logger *slog.Logger,
 ) *TicketHandler {
 	return &TicketHandler{
 		ticketTypeService: ticketTypeService,
 		ticketService:     ticketService,
+		attendeeService:   attendeeService,
 		logger:            logger,
 	}
 }
@@ -36,6 +40,7 @@ func (h *TicketHandler) RegisterRoutes(r *gin.Engine) {
 	r.GET("/tickets", h.GetTickets)
 	r.POST("/events/:id/ticket-types", h.CreateTicketType)
 	r.POST("/events/:id/tickets", h.CreateTicket)
+	r.GET("/events/:id/attendees", h.GetAttendees)
 }
 
 func (h *TicketHandler) Ping(c *gin.Context) {
@@ -49,19 +54,25 @@ func (h *TicketHandler) CreateTicketType(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
 		return
 	}
+	actor, ok := requestActor(c)
+	if !ok {
+		return
+	}
 	var ttDto dto.CreateTicketTypeRequest
 	if err := c.ShouldBindJSON(&ttDto); err != nil {
-		h.logger.Error(err.Error())
+		h.logger.ErrorContext(c.Request.Context(), err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	ticketType, err := h.ticketTypeService.Create(ctx, uint64(eventId), ttDto)
+	ticketType, err := h.ticketTypeService.Create(ctx, actor, uint64(eventId), ttDto)
 	if err != nil {
-		h.logger.Error(err.Error())
+		h.logger.ErrorContext(c.Request.Context(), err.Error())
 		switch {
-		case errors.Is(err, dto.ErrEventNotFound):
+		case errors.Is(err, dto.ErrEventNotFound), errors.Is(err, dto.ErrScheduleNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		case errors.Is(err, dto.ErrEventNotPublished):
+		case errors.Is(err, dto.ErrEventAccessDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, dto.ErrEventNotPublished), errors.Is(err, dto.ErrTicketTypeScopeInvalid):
 			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
@@ -81,7 +92,7 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 	}
 	var requestDto dto.CreateTicketRequest
 	if err := c.ShouldBindJSON(&requestDto); err != nil {
-		h.logger.Error(err.Error())
+		h.logger.ErrorContext(c.Request.Context(), err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -89,7 +100,7 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 	ticket, err := h.ticketService.Create(ctx, eventId, requestDto)
 
 	if err != nil {
-		h.logger.Error(err.Error())
+		h.logger.ErrorContext(c.Request.Context(), err.Error())
 		switch {
 		case errors.Is(err, dto.ErrEventNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -110,14 +121,14 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 func (h *TicketHandler) GetTickets(c *gin.Context) {
 	var filter dto.TicketListFilter
 	if err := c.ShouldBindQuery(&filter); err != nil {
-		h.logger.Error("binding error", "error", err.Error())
+		h.logger.ErrorContext(c.Request.Context(), "binding error", "error", err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	tickets, err := h.ticketService.List(filter)
 	if err != nil {
-		h.logger.Error(err.Error())
+		h.logger.ErrorContext(c.Request.Context(), err.Error())
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
 		return
 	}
@@ -125,6 +136,35 @@ func (h *TicketHandler) GetTickets(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": tickets})
 }
 
+func (h *TicketHandler) GetAttendees(c *gin.Context) {
+	eventId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || eventId <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+		return
+	}
+
+	actor, ok := requestActor(c)
+	if !ok {
+		return
+	}
+
+	attendees, err := h.attendeeService.List(c.Request.Context(), actor, eventId)
+	if err != nil {
+		switch {
+		case errors.Is(err, dto.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, dto.ErrEventAccessDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			h.logger.ErrorContext(c.Request.Context(), "failed to list attendees", "error", err, "event_id", eventId)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": attendees})
+}
+
 func (h *TicketHandler) TicketValidate(c *gin.Context) {
 	var codeDto *dto.TicketCode
 	if err := c.ShouldBindJSON(&codeDto); err != nil {
@@ -147,17 +187,25 @@ func (h *TicketHandler) TicketValidate(c *gin.Context) {
 }
 
 func (h *TicketHandler) TicketCheckin(c *gin.Context) {
+	actor, ok := requestActor(c)
+	if !ok {
+		return
+	}
+
 	var codeDto *dto.TicketCode
 	if err := c.ShouldBindJSON(&codeDto); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	err := h.ticketService.Checkin(c.Request.Context(), codeDto)
+	err := h.ticketService.Checkin(c.Request.Context(), actor, codeDto)
 	if err != nil {
 		switch {
-		case errors.Is(err, dto.ErrTicketNotFoundOrNotActive):
+		case errors.Is(err, dto.ErrTicketNotFoundOrNotActive),
+			errors.Is(err, dto.ErrEventNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, dto.ErrEventAccessDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		}
@@ -166,3 +214,16 @@ func (h *TicketHandler) TicketCheckin(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "success"})
 }
+
+// requestActor — пользователь из заголовков, проверенных userclient.RequireIdentity.
+func requestActor(c *gin.Context) (services.Actor, bool) {
+	userId, err := strconv.ParseUint(c.GetHeader(userclient.HeaderUserID), 10, 64)
+	if err != nil || userId == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return services.Actor{}, false
+	}
+	return services.Actor{
+		UserID: userId,
+		Role:   c.GetHeader(userclient.HeaderUserRole),
+	}, true
+}
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"context"
+	"encoding/base64"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"log/slog"
@@ -10,11 +14,16 @@ import (
 	"gorm.io/gorm"
 
 	"user-service/internal/config"
+	"user-service/internal/kafka"
+	"user-service/internal/mailer"
 	"user-service/internal/models"
+	"user-service/internal/oidc"
 	"user-service/internal/repository"
 	"user-service/internal/services"
 	"user-service/internal/transport"
 	"user-service/internal/utils"
+	"user-service/userclient"
+	"user-service/userclient/requestid"
 )
 
 func main() {
@@ -34,31 +43,175 @@ func main() {
 	}
 	log.Info("migrations completed")
 
+	// ---------- REDIS ----------
+	redisClient := config.ConnectRedis(log)
+
 	// ---------- REPOSITORIES ----------
 	userRepo := repository.NewUserRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	revocationRepo := repository.NewRevocationRepository(redisClient)
+	oneTimeTokenRepo := repository.NewOneTimeTokenRepository(db)
+	mfaRepo := repository.NewMFARepository(db)
+	identityRepo := repository.NewUserIdentityRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	applicationRepo := repository.NewOrganizerApplicationRepository(db)
+	securityEventRepo := repository.NewSecurityEventRepository(db)
+	organizationRepo := repository.NewOrganizationRepository(db)
+	dataRequestRepo := repository.NewDataRequestRepository(db)
+	if redisClient == nil {
+		log.Warn("login attempts are counted in memory, run a single replica")
+	}
+	loginAttemptRepo := repository.NewLoginAttemptRepository(redisClient)
+	if redisClient == nil {
+		log.Warn("oidc login state is kept in memory, run a single replica")
+	}
+	oidcStateRepo := repository.NewOIDCStateRepository(redisClient)
+
+	// ---------- MAILER ----------
+	mail, err := mailer.FromEnv(log)
+	if err != nil {
+		log.Error("failed to configure mailer", "error", err)
+		os.Exit(1)
+	}
+	mailQueue := mailer.NewQueue(mail, log)
+	defer mailQueue.Close()
+
+	// ---------- KAFKA ----------
+	kafkaProducer := kafka.NewProducer(config.KafkaBrokers(), log)
+	defer func() {
+		if err := kafkaProducer.Close(); err != nil {
+			log.Error("failed to close kafka producer", "error", err)
+		}
+	}()
 
 	// ---------- TOKEN MANAGER ----------
+	signingKeys, err := loadSigningKeys(log)
+	if err != nil {
+		log.Error("failed to load jwt signing keys", "error", err)
+		os.Exit(1)
+	}
+	log.Info("jwt signing key loaded", "kid", signingKeys.Active().ID)
+
 	tokenManager := utils.NewTokenManager(
-		os.Getenv("JWT_SECRET"),
+		signingKeys,
 		mustDuration(os.Getenv("JWT_ACCESS_TTL")),
 		mustDuration(os.Getenv("JWT_REFRESH_TTL")),
 		"user-service",
 	)
 
 	// ---------- SERVICES ----------
-	userService := services.NewUserService(userRepo)
-	authService := services.NewAuthService(userRepo, tokenManager)
+	userEvents := services.NewUserEvents(kafkaProducer, log)
+	defer userEvents.Close()
+	userService := services.NewUserService(userRepo, userEvents)
+	mfaSecrets, err := loadMFASecretBox(log)
+	if err != nil {
+		log.Error("failed to load mfa encryption key", "error", err)
+		os.Exit(1)
+	}
+	mfaService := services.NewMFAService(userRepo, mfaRepo, mfaSecrets, mfaIssuer())
+	securityService := services.NewSecurityService(loginAttemptRepo, securityEventRepo, loginPolicy())
+	authService := services.NewAuthService(userRepo, sessionRepo, tokenManager, revocationRepo, mfaService, oneTimeTokenRepo, securityService, userEvents)
+
+	adminService := services.NewAdminService(userRepo, auditRepo, authService, userEvents)
+	if email := os.Getenv("BOOTSTRAP_ADMIN_EMAIL"); email != "" {
+		if _, err := adminService.BootstrapAdmin(context.Background(), email); err != nil {
+			log.Warn("failed to bootstrap admin", "email", email, "error", err)
+		}
+	}
+
+	applicationService := services.NewOrganizerApplicationService(
+		applicationRepo,
+		userRepo,
+		adminService,
+		kafkaProducer,
+		log,
+	)
+
+	organizationService := services.NewOrganizationService(organizationRepo, userRepo, log)
+	dataRequestService := services.NewDataRequestService(
+		dataRequestRepo,
+		userRepo,
+		organizationRepo,
+		authService,
+		kafkaProducer,
+		userEvents,
+		dataRequestServices(),
+		log,
+	)
+
+	oidcConfigs, err := oidc.LoadConfigs()
+	if err != nil {
+		log.Error("invalid oidc configuration", "error", err)
+		os.Exit(1)
+	}
+	for _, p := range oidcConfigs {
+		log.Info("oidc provider configured", "provider", p.Name, "issuer", p.Issuer)
+	}
+	oidcService := services.NewOIDCService(
+		oidc.NewRegistry(oidcConfigs),
+		oidcStateRepo,
+		userRepo,
+		identityRepo,
+		authService,
+		userEvents,
+	)
+	verificationService := services.NewVerificationService(
+		userRepo,
+		oneTimeTokenRepo,
+		mailQueue,
+		appBaseURL(),
+		durationOrDefault(os.Getenv("EMAIL_VERIFICATION_TTL"), 24*time.Hour),
+		userEvents,
+	)
+	resetService := services.NewPasswordResetService(
+		userRepo,
+		oneTimeTokenRepo,
+		authService,
+		mailQueue,
+		appBaseURL(),
+		durationOrDefault(os.Getenv("PASSWORD_RESET_TTL"), time.Hour),
+	)
 
 	// ---------- HTTP ----------
+	identitySecret := os.Getenv("INTERNAL_IDENTITY_SECRET")
+	if identitySecret == "" {
+		log.Error("INTERNAL_IDENTITY_SECRET is not set")
+		os.Exit(1)
+	}
+
+	serviceSecret := os.Getenv("INTERNAL_SERVICE_SECRET")
+	if serviceSecret == "" {
+		log.Error("INTERNAL_SERVICE_SECRET is not set")
+		os.Exit(1)
+	}
+
 	httpServer := gin.Default()
+	// адрес клиента для лимитов входа и аудита — из X-Forwarded-For от
+	// gateway; прямым запросам с подменённым заголовком не верим
+	if err := httpServer.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Error("invalid TRUSTED_PROXIES", "error", err)
+		os.Exit(1)
+	}
+	httpServer.Use(requestid.Middleware())
+	httpServer.Use(userclient.RequireIdentity(identitySecret))
 
 	userHandler := transport.NewUserHandler(
 		userService,
 		authService,
+		verificationService,
+		resetService,
+		mfaService,
+		oidcService,
 		log,
 	)
 
 	userHandler.RegisterRoutes(httpServer)
+	transport.NewAdminHandler(adminService, log).RegisterRoutes(httpServer)
+	transport.NewOrganizerApplicationHandler(applicationService, log).RegisterRoutes(httpServer)
+	transport.NewSecurityHandler(securityService, log).RegisterRoutes(httpServer)
+	transport.NewOrganizationHandler(organizationService, log).RegisterRoutes(httpServer)
+	transport.NewDataRequestHandler(dataRequestService, log).RegisterRoutes(httpServer)
+	transport.NewInternalHandler(userService, organizationService, dataRequestService, serviceSecret, log).RegisterRoutes(httpServer)
 
 	// ---------- START ----------
 	port := os.Getenv("PORT")
@@ -78,9 +231,112 @@ func main() {
 func migrate(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&models.User{},
+		&models.Session{},
+		&models.OneTimeToken{},
+		&models.MFAFactor{},
+		&models.RecoveryCode{},
+		&models.UserIdentity{},
+		&models.AuditLog{},
+		&models.OrganizerApplication{},
+		&models.SecurityEvent{},
+		&models.Organization{},
+		&models.OrganizationMember{},
+		&models.DataRequest{},
+		&models.DataRequestPart{},
 	)
 }
 
+// loadSigningKeys читает ключи из JWT_KEYS_DIR. Без него генерируется
+// временный ключ — подходит только для локального запуска.
+func loadSigningKeys(log *slog.Logger) (*utils.KeyStore, error) {
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		log.Warn("JWT_KEYS_DIR is not set, using ephemeral signing key")
+		return utils.NewEphemeralKeyStore()
+	}
+	return utils.LoadKeyStore(dir, os.Getenv("JWT_ACTIVE_KID"))
+}
+
+// loadMFASecretBox — ключ шифрования TOTP-секретов из MFA_ENCRYPTION_KEY
+// (32 байта в base64). Без него ключ временный: после перезапуска
+// настроенная 2FA перестанет работать.
+func loadMFASecretBox(log *slog.Logger) (*utils.SecretBox, error) {
+	raw := os.Getenv("MFA_ENCRYPTION_KEY")
+	if raw == "" {
+		log.Warn("MFA_ENCRYPTION_KEY is not set, using ephemeral key")
+		return utils.NewEphemeralSecretBox()
+	}
+
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+	return utils.NewSecretBox(key)
+}
+
+// mfaIssuer — имя сервиса в приложении-аутентификаторе.
+func mfaIssuer() string {
+	if v := os.Getenv("MFA_ISSUER"); v != "" {
+		return v
+	}
+	return "Sbor"
+}
+
+// loginPolicy — защита входа от перебора, LOGIN_* переопределяют значения по умолчанию.
+func loginPolicy() services.LoginPolicy {
+	p := services.DefaultLoginPolicy()
+	p.MaxFailures = intOrDefault(os.Getenv("LOGIN_MAX_FAILURES"), p.MaxFailures)
+	p.IPMaxFailures = intOrDefault(os.Getenv("LOGIN_IP_MAX_FAILURES"), p.IPMaxFailures)
+	p.Window = durationOrDefault(os.Getenv("LOGIN_FAILURE_WINDOW"), p.Window)
+	p.Lockout = durationOrDefault(os.Getenv("LOGIN_LOCKOUT"), p.Lockout)
+	p.BaseDelay = durationOrDefault(os.Getenv("LOGIN_BASE_DELAY"), p.BaseDelay)
+	p.MaxDelay = durationOrDefault(os.Getenv("LOGIN_MAX_DELAY"), p.MaxDelay)
+	return p
+}
+
+// dataRequestServices — сервисы, которые должны подтвердить выгрузку и
+// удаление данных пользователя (DATA_REQUEST_SERVICES через запятую).
+func dataRequestServices() []string {
+	v := os.Getenv("DATA_REQUEST_SERVICES")
+	if v == "" {
+		return []string{"event-service", "notification-service", "ticket-service"}
+	}
+
+	var names []string
+	for _, name := range strings.Split(v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// appBaseURL — адрес фронтенда, на который ведут ссылки из писем.
+func appBaseURL() string {
+	if v := os.Getenv("APP_BASE_URL"); v != "" {
+		return v
+	}
+	return "http://localhost:3000"
+}
+
+func durationOrDefault(value string, def time.Duration) time.Duration {
+	if value == "" {
+		return def
+	}
+	return mustDuration(value)
+}
+
+func intOrDefault(value string, def int) int {
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		panic("invalid integer: " + value)
+	}
+	return n
+}
+
 func mustDuration(value string) time.Duration {
 	d, err := time.ParseDuration(value)
 	if err != nil {
@@ -88,3 +344,19 @@ func mustDuration(value string) time.Duration {
 	}
 	return d
 }
+
+// trustedProxies — сети, из которых приходит gateway (TRUSTED_PROXIES, CIDR
+// через запятую). По умолчанию — частные сети, в которых живёт docker compose.
+func trustedProxies() []string {
+	raw := os.Getenv("TRUSTED_PROXIES")
+	if raw == "" {
+		return []string{"127.0.0.1/32", "::1/128", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+	}
+	var proxies []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
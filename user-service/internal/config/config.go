@@ -20,7 +20,10 @@ func ConnectDB(logger *slog.Logger) *gorm.DB {
 		os.Getenv("DB_SSLMODE"),
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		// нарушения уникальности приходят как gorm.ErrDuplicatedKey
+		TranslateError: true,
+	})
 	if err != nil {
 		logger.Error("failed to connect to database", "error", err)
 		os.Exit(1)
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ConnectRedis подключается к Redis, общему с gateway. Без REDIS_ADDR
+// возвращает nil — отзыв токенов при этом отключён.
+func ConnectRedis(logger *slog.Logger) *redis.Client {
+	redisAddr := os.Getenv("REDIS_ADDR")
+	if redisAddr == "" {
+		logger.Warn("REDIS_ADDR is not set, token revocation is disabled")
+		return nil
+	}
+
+	dbNum := 0
+	if v := os.Getenv("REDIS_DB"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			logger.Warn("invalid REDIS_DB, using 0", "value", v)
+		} else {
+			dbNum = n
+		}
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     redisAddr,
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       dbNum,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		logger.Warn("redis is unavailable at startup", "addr", redisAddr, "error", err)
+	} else {
+		logger.Info("connected to Redis", "addr", redisAddr)
+	}
+
+	return client
+}
@@ -10,4 +10,45 @@ var (
 	ErrUserInactive     = errors.New("Пользователь неактивен")
 	ErrAlreadyOrganizer = errors.New("Пользователь уже является организатором")
 	ErrNotOrganizer     = errors.New("Пользователь не является организатором")
+
+	ErrSessionNotFound    = errors.New("Сессия не найдена")
+	ErrRefreshTokenReused = errors.New("Refresh-токен уже был использован")
+
+	ErrEmailNotVerified     = errors.New("Адрес электронной почты не подтверждён")
+	ErrEmailAlreadyVerified = errors.New("Адрес электронной почты уже подтверждён")
+	ErrInvalidOneTimeToken  = errors.New("Ссылка недействительна или устарела")
+
+	ErrMFANotEnrolled      = errors.New("Двухфакторная аутентификация не настроена")
+	ErrMFAAlreadyEnabled   = errors.New("Двухфакторная аутентификация уже включена")
+	ErrInvalidMFACode      = errors.New("Неверный код подтверждения")
+	ErrInvalidMFAChallenge = errors.New("Сессия входа истекла, войдите заново")
+
+	ErrUnknownOIDCProvider = errors.New("Неизвестный провайдер входа")
+	ErrInvalidOIDCState    = errors.New("Сессия входа через провайдера истекла, начните заново")
+	ErrOIDCEmailMissing    = errors.New("Провайдер не передал адрес электронной почты")
+	ErrOIDCEmailUnverified = errors.New("Аккаунт с этой почтой не подтверждён: подтвердите почту или восстановите пароль")
+	ErrIdentityNotFound    = errors.New("Внешний аккаунт не привязан")
+
+	ErrInvalidRole      = errors.New("Недопустимая роль")
+	ErrCannotModifySelf = errors.New("Нельзя изменить собственную учётную запись")
+
+	ErrAccountLocked        = errors.New("Вход временно заблокирован из-за неверных попыток")
+	ErrTooManyLoginAttempts = errors.New("Слишком много неверных попыток, повторите позже")
+
+	ErrApplicationNotFound        = errors.New("Заявка не найдена")
+	ErrApplicationPending         = errors.New("Заявка уже на рассмотрении")
+	ErrApplicationAlreadyReviewed = errors.New("Заявка уже рассмотрена")
+	ErrDecisionReasonRequired     = errors.New("Укажите причину решения")
+
+	ErrOrganizationNotFound         = errors.New("Организация не найдена")
+	ErrInvalidOrganizationRole      = errors.New("Недопустимая роль в организации")
+	ErrAlreadyOrganizationMember    = errors.New("Пользователь уже состоит в организации")
+	ErrNotOrganizationMember        = errors.New("Пользователь не состоит в организации")
+	ErrInsufficientOrganizationRole = errors.New("Недостаточно прав в организации")
+	ErrLastOrganizationOwner        = errors.New("В организации должен остаться хотя бы один владелец")
+
+	ErrDataRequestNotFound       = errors.New("Запрос не найден")
+	ErrDataExportNotReady        = errors.New("Выгрузка ещё готовится")
+	ErrDeletionNotConfirmed      = errors.New("Для подтверждения укажите адрес электронной почты учётной записи")
+	ErrDataRequestNotDeliverable = errors.New("Не удалось передать запрос сервисам, повторите позже")
 )
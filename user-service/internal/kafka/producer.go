@@ -0,0 +1,163 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"user-service/userclient"
+	"user-service/userclient/requestid"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	TopicApplicationSubmitted = "organizer.application.submitted"
+	TopicApplicationApproved  = "organizer.application.approved"
+	TopicApplicationRejected  = "organizer.application.rejected"
+)
+
+type Producer struct {
+	writer *kafka.Writer
+	logger *slog.Logger
+}
+
+type EventProducer interface {
+	SendOrganizerApplication(ctx context.Context, topic string, msg OrganizerApplicationMessage) error
+	Close() error
+}
+
+// OrganizerApplicationMessage — событие по заявке на роль организатора.
+// Reason и ReviewerID заполнены только для решений администратора.
+type OrganizerApplicationMessage struct {
+	ApplicationID    uint      `json:"application_id"`
+	UserID           uint      `json:"user_id"`
+	OrganizationName string    `json:"organization_name"`
+	Status           string    `json:"status"`
+	Reason           string    `json:"reason,omitempty"`
+	ReviewerID       uint      `json:"reviewer_id,omitempty"`
+	OccurredAt       time.Time `json:"occurred_at"`
+}
+
+func NewProducer(brokers []string, logger *slog.Logger) *Producer {
+	return &Producer{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Balancer:     &kafka.Hash{}, // события одного пользователя в топике — в одной партиции
+			WriteTimeout: 20 * time.Second,
+			ReadTimeout:  10 * time.Second,
+			RequiredAcks: kafka.RequireOne,
+			Async:        false,
+			Compression:  kafka.Snappy,
+		},
+		logger: logger,
+	}
+}
+
+func (p *Producer) SendOrganizerApplication(ctx context.Context, topic string, msg OrganizerApplicationMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "failed to marshal organizer application message",
+			"error", err,
+			"application_id", msg.ApplicationID)
+		return err
+	}
+
+	return p.send(ctx, kafka.Message{
+		Topic:   topic,
+		Key:     []byte(fmt.Sprintf("%d", msg.UserID)),
+		Value:   data,
+		Headers: messageHeaders(ctx),
+		Time:    time.Now(),
+	})
+}
+
+func (p *Producer) send(ctx context.Context, kafkaMessage kafka.Message) error {
+	var err error
+
+	// Retry logic
+	maxRetries := 3
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		err = p.writer.WriteMessages(ctx, kafkaMessage)
+		if err == nil {
+			p.logger.InfoContext(ctx, "kafka message sent",
+				"topic", kafkaMessage.Topic,
+				"key", string(kafkaMessage.Key))
+			return nil
+		}
+
+		if attempt < maxRetries-1 {
+			backoff := time.Duration(1<<uint(attempt)) * time.Second
+			p.logger.WarnContext(ctx, "failed to send kafka message, retrying",
+				"error", err,
+				"topic", kafkaMessage.Topic,
+				"attempt", attempt+1,
+				"backoff", backoff)
+			time.Sleep(backoff)
+		}
+	}
+
+	p.logger.ErrorContext(ctx, "failed to send kafka message after retries",
+		"error", err,
+		"topic", kafkaMessage.Topic,
+		"max_retries", maxRetries)
+	return err
+}
+
+func (p *Producer) Close() error {
+	if p.writer != nil {
+		return p.writer.Close()
+	}
+	return nil
+}
+
+// messageHeaders передаёт ID запроса консьюмерам, чтобы по нему
+// можно было связать HTTP-запрос и созданные им уведомления.
+func messageHeaders(ctx context.Context) []kafka.Header {
+	id := requestid.FromContext(ctx)
+	if id == "" {
+		return nil
+	}
+	return []kafka.Header{{Key: requestid.Header, Value: []byte(id)}}
+}
+
+// SendDataRequest публикует запрос на выгрузку или удаление данных
+// пользователя. Ключ — ID пользователя, как у остальных событий.
+func (p *Producer) SendDataRequest(ctx context.Context, topic string, msg userclient.DataRequestMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "failed to marshal data request message",
+			"error", err,
+			"request_id", msg.RequestID)
+		return err
+	}
+
+	return p.send(ctx, kafka.Message{
+		Topic:   topic,
+		Key:     []byte(fmt.Sprintf("%d", msg.UserID)),
+		Value:   data,
+		Headers: messageHeaders(ctx),
+		Time:    time.Now(),
+	})
+}
+
+// SendUserEvent публикует событие жизненного цикла пользователя в топик msg.Type.
+func (p *Producer) SendUserEvent(ctx context.Context, msg userclient.UserEvent) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "failed to marshal user event",
+			"error", err,
+			"user_id", msg.UserID)
+		return err
+	}
+
+	return p.send(ctx, kafka.Message{
+		Topic:   msg.Type,
+		Key:     []byte(fmt.Sprintf("%d", msg.UserID)),
+		Value:   data,
+		Headers: messageHeaders(ctx),
+		Time:    time.Now(),
+	})
+}
@@ -0,0 +1,35 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LogMailer ничего не отправляет: пишет письмо в лог и, если задан dir,
+// сохраняет его .eml-файлом, чтобы ссылку можно было открыть руками.
+type LogMailer struct {
+	logger *slog.Logger
+	dir    string
+}
+
+func NewLogMailer(logger *slog.Logger, dir string) *LogMailer {
+	return &LogMailer{logger: logger, dir: dir}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.InfoContext(ctx, "mail sent to log", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+
+	if m.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), filepath.Base(msg.To))
+	return os.WriteFile(filepath.Join(m.dir, name), buildMessage("no-reply@localhost", msg), 0o644)
+}
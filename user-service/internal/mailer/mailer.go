@@ -0,0 +1,57 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer отправляет письма пользователям. Реализация выбирается
+// переменной MAILER: smtp или log.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// FromEnv собирает Mailer по переменным окружения. Без MAILER письма
+// только пишутся в лог — подходит для локального запуска.
+func FromEnv(logger *slog.Logger) (Mailer, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+
+	switch kind := os.Getenv("MAILER"); kind {
+	case "", "log":
+		return NewLogMailer(logger, os.Getenv("MAIL_DIR")), nil
+	case "smtp":
+		port := 587
+		if v := os.Getenv("SMTP_PORT"); v != "" {
+			p, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SMTP_PORT %q", v)
+			}
+			port = p
+		}
+		host := os.Getenv("SMTP_HOST")
+		if host == "" {
+			return nil, fmt.Errorf("SMTP_HOST is not set")
+		}
+		return NewSMTPMailer(SMTPConfig{
+			Host:     host,
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     from,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown MAILER %q", kind)
+	}
+}
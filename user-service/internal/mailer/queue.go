@@ -0,0 +1,61 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+)
+
+// queueSize — сколько писем ждут отправки, пока SMTP недоступен.
+const queueSize = 256
+
+type queuedMessage struct {
+	ctx context.Context
+	msg Message
+}
+
+// Queue отправляет письма в фоне одной горутиной. Send не ждёт SMTP:
+// время ответа не зависит от того, ушло ли письмо, и не выдаёт, есть ли
+// такой адрес. Ошибка отправки только логируется.
+type Queue struct {
+	mailer Mailer
+	logger *slog.Logger
+	queue  chan queuedMessage
+	done   chan struct{}
+}
+
+func NewQueue(m Mailer, logger *slog.Logger) *Queue {
+	q := &Queue{
+		mailer: m,
+		logger: logger,
+		queue:  make(chan queuedMessage, queueSize),
+		done:   make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// Send ставит письмо в очередь. Переполненная очередь письмо отбрасывает.
+func (q *Queue) Send(ctx context.Context, msg Message) error {
+	select {
+	case q.queue <- queuedMessage{ctx: context.WithoutCancel(ctx), msg: msg}:
+	default:
+		q.logger.ErrorContext(ctx, "mail queue is full, message dropped", "subject", msg.Subject)
+	}
+	return nil
+}
+
+// Close дожидается отправки писем из очереди.
+func (q *Queue) Close() {
+	close(q.queue)
+	<-q.done
+}
+
+func (q *Queue) run() {
+	defer close(q.done)
+
+	for m := range q.queue {
+		if err := q.mailer.Send(m.ctx, m.msg); err != nil {
+			q.logger.ErrorContext(m.ctx, "failed to send mail", "error", err, "subject", m.msg.Subject)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditUserActivated   AuditAction = "user.activated"
+	AuditUserDeactivated AuditAction = "user.deactivated"
+	AuditUserRoleChanged AuditAction = "user.role_changed"
+
+	AuditApplicationApproved AuditAction = "organizer_application.approved"
+	AuditApplicationRejected AuditAction = "organizer_application.rejected"
+)
+
+// AuditLog — запись о действии администратора. ActorID = 0 — действие
+// выполнено самим сервисом (например, назначение первого администратора).
+type AuditLog struct {
+	ID           uint        `gorm:"primaryKey"`
+	ActorID      uint        `gorm:"index;not null"`
+	Action       AuditAction `gorm:"type:varchar(64);not null;index"`
+	TargetUserID uint        `gorm:"index;not null"`
+	// Details — JSON с подробностями: старое и новое значение и т.п.
+	Details   string    `gorm:"type:jsonb;not null;default:'{}'"`
+	RequestID string    `gorm:"size:128"`
+	IP        string    `gorm:"size:64"`
+	CreatedAt time.Time `gorm:"index"`
+}
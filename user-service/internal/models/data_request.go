@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+type DataRequestType string
+
+const (
+	DataRequestExport   DataRequestType = "export"
+	DataRequestDeletion DataRequestType = "deletion"
+)
+
+type DataRequestStatus string
+
+const (
+	DataRequestPending   DataRequestStatus = "pending"
+	DataRequestCompleted DataRequestStatus = "completed"
+)
+
+// DataRequest — запрос пользователя на выгрузку или удаление своих данных.
+// Завершён, когда все сервисы из Parts подтвердили обработку.
+type DataRequest struct {
+	ID          uint              `gorm:"primaryKey"`
+	UserID      uint              `gorm:"index;not null"`
+	Type        DataRequestType   `gorm:"type:varchar(16);not null"`
+	Status      DataRequestStatus `gorm:"type:varchar(16);not null;default:'pending';index"`
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+
+	Parts []DataRequestPart `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// DataRequestPart — обработка запроса одним сервисом. Data — выгрузка
+// сервиса (JSON) или сводка по обезличенным данным.
+type DataRequestPart struct {
+	ID             uint              `gorm:"primaryKey"`
+	DataRequestID  uint              `gorm:"not null;uniqueIndex:idx_data_request_part"`
+	Service        string            `gorm:"size:64;not null;uniqueIndex:idx_data_request_part"`
+	Status         DataRequestStatus `gorm:"type:varchar(16);not null;default:'pending'"`
+	Data           []byte            `gorm:"type:jsonb"`
+	AcknowledgedAt *time.Time
+}
+
+// Done — все сервисы подтвердили обработку.
+func (r *DataRequest) Done() bool {
+	for _, p := range r.Parts {
+		if p.Status != DataRequestCompleted {
+			return false
+		}
+	}
+	return true
+}
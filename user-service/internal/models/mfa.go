@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// MFAFactor — TOTP-аутентификатор пользователя. Secret хранится
+// зашифрованным; до подтверждения первым кодом фактор не действует.
+type MFAFactor struct {
+	UserID      uint   `gorm:"primaryKey;autoIncrement:false"`
+	Secret      string `gorm:"not null"`
+	ConfirmedAt *time.Time
+	// LastUsedStep — интервал последнего принятого кода, защита от повтора.
+	LastUsedStep int64 `gorm:"not null;default:0"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (f *MFAFactor) Enabled() bool {
+	return f.ConfirmedAt != nil
+}
+
+// RecoveryCode — одноразовый код на случай потери аутентификатора.
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index;not null"`
+	CodeHash  string `gorm:"size:64;not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type TokenPurpose string
+
+const (
+	PurposeEmailVerification TokenPurpose = "email_verification"
+	PurposePasswordReset     TokenPurpose = "password_reset"
+	// PurposeMFAChallenge — вход, ожидающий второго фактора.
+	PurposeMFAChallenge TokenPurpose = "mfa_challenge"
+)
+
+// OneTimeToken — одноразовый токен из письма. В базе хранится только
+// хэш, сам токен знает лишь получатель письма.
+type OneTimeToken struct {
+	ID        uint         `gorm:"primaryKey"`
+	UserID    uint         `gorm:"index;not null"`
+	Purpose   TokenPurpose `gorm:"type:varchar(32);not null"`
+	TokenHash string       `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt time.Time    `gorm:"not null"`
+	UsedAt    *time.Time
+	// Attempts — сколько раз предъявляли код к токену (challenge 2FA).
+	Attempts  int `gorm:"not null;default:0"`
+	CreatedAt time.Time
+}
+
+func (t *OneTimeToken) Usable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// OrganizationRole — роль участника в организации.
+type OrganizationRole string
+
+const (
+	OrgRoleOwner   OrganizationRole = "owner"
+	OrgRoleManager OrganizationRole = "manager"
+	// OrgRoleCheckin — сотрудник на входе: только проверка билетов.
+	OrgRoleCheckin OrganizationRole = "checkin"
+)
+
+func (r OrganizationRole) Valid() bool {
+	switch r {
+	case OrgRoleOwner, OrgRoleManager, OrgRoleCheckin:
+		return true
+	}
+	return false
+}
+
+// Organization — компания или команда, от имени которой несколько
+// пользователей ведут мероприятия.
+type Organization struct {
+	ID          uint   `gorm:"primaryKey"`
+	Name        string `gorm:"size:255;not null"`
+	Description string `gorm:"type:text"`
+	// CreatedBy — пользователь, создавший организацию.
+	CreatedBy uint `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type OrganizationMember struct {
+	ID             uint             `gorm:"primaryKey"`
+	OrganizationID uint             `gorm:"uniqueIndex:idx_organization_member;not null"`
+	UserID         uint             `gorm:"uniqueIndex:idx_organization_member;index;not null"`
+	Role           OrganizationRole `gorm:"type:varchar(16);not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	Organization *Organization `gorm:"constraint:OnDelete:CASCADE"`
+	User         *User         `gorm:"constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+type ApplicationStatus string
+
+const (
+	ApplicationPending  ApplicationStatus = "pending"
+	ApplicationApproved ApplicationStatus = "approved"
+	ApplicationRejected ApplicationStatus = "rejected"
+)
+
+func (s ApplicationStatus) Valid() bool {
+	switch s {
+	case ApplicationPending, ApplicationApproved, ApplicationRejected:
+		return true
+	}
+	return false
+}
+
+// OrganizerApplication — заявка пользователя на роль организатора.
+// Роль выдаётся только после одобрения администратором. На рассмотрении
+// у пользователя может быть только одна заявка — частичный уникальный индекс.
+type OrganizerApplication struct {
+	ID               uint              `gorm:"primaryKey"`
+	UserID           uint              `gorm:"index;not null;uniqueIndex:idx_organizer_application_pending,where:status = 'pending'"`
+	OrganizationName string            `gorm:"size:255;not null"`
+	ContactName      string            `gorm:"size:255;not null"`
+	ContactEmail     string            `gorm:"size:255;not null"`
+	ContactPhone     string            `gorm:"size:32"`
+	Website          string            `gorm:"size:255"`
+	Description      string            `gorm:"type:text;not null"`
+	Status           ApplicationStatus `gorm:"type:varchar(16);not null;default:'pending';index"`
+	// ReviewerID — администратор, принявший решение; nil — заявка ещё не рассмотрена.
+	ReviewerID     *uint
+	DecisionReason string `gorm:"type:text"`
+	ReviewedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (a *OrganizerApplication) Pending() bool {
+	return a.Status == ApplicationPending
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type SecurityEventType string
+
+const (
+	// SecurityAccountLocked — вход в учётную запись заблокирован после серии неверных паролей.
+	SecurityAccountLocked SecurityEventType = "account_locked"
+	// SecurityIPLocked — вход с IP-адреса заблокирован после серии неверных паролей.
+	SecurityIPLocked SecurityEventType = "ip_locked"
+)
+
+// SecurityEvent — событие безопасности, которое видят пользователь
+// и администраторы. UserID = 0 — учётная запись не найдена.
+type SecurityEvent struct {
+	ID        uint              `gorm:"primaryKey"`
+	UserID    uint              `gorm:"index;not null"`
+	Type      SecurityEventType `gorm:"type:varchar(32);not null;index"`
+	IP        string            `gorm:"size:64"`
+	UserAgent string            `gorm:"size:512"`
+	// Details — JSON с подробностями: число попыток, срок блокировки.
+	Details   string    `gorm:"type:jsonb;not null;default:'{}'"`
+	CreatedAt time.Time `gorm:"index"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Session — одно устройство пользователя. Refresh-токены сессии
+// ротируются, в базе хранится только хэш последнего выданного.
+type Session struct {
+	ID               uint   `gorm:"primaryKey"`
+	UserID           uint   `gorm:"index;not null"`
+	RefreshTokenHash string `gorm:"size:64;not null"`
+	UserAgent        string `gorm:"size:512"`
+	IP               string `gorm:"size:64"`
+	// MFA — вход в сессию подтверждён вторым фактором.
+	MFA        bool `gorm:"not null;default:false"`
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time `gorm:"index"`
+}
+
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
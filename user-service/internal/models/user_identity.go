@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// UserIdentity — внешний аккаунт (OIDC-провайдер), привязанный к пользователю.
+type UserIdentity struct {
+	ID       uint   `gorm:"primaryKey"`
+	UserID   uint   `gorm:"index;not null"`
+	Provider string `gorm:"size:64;not null;uniqueIndex:idx_identity_provider_subject"`
+	Subject  string `gorm:"size:255;not null;uniqueIndex:idx_identity_provider_subject"`
+	// Email — адрес из провайдера на момент привязки, для отображения.
+	Email     string `gorm:"size:255"`
+	CreatedAt time.Time
+}
+
+// OIDCLoginState — незавершённый вход через провайдера, живёт
+// между редиректом на провайдера и возвратом на callback.
+type OIDCLoginState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
@@ -7,8 +7,17 @@ type UserRole string
 const (
 	RoleUser      UserRole = "user"
 	RoleOrganizer UserRole = "organizer"
+	RoleAdmin     UserRole = "admin"
 )
 
+func (r UserRole) Valid() bool {
+	switch r {
+	case RoleUser, RoleOrganizer, RoleAdmin:
+		return true
+	}
+	return false
+}
+
 type User struct {
 	ID           uint   `gorm:"primaryKey"`
 	Email        string `gorm:"uniqueIndex;not null"`
@@ -17,6 +26,14 @@ type User struct {
 	LastName     string
 	Role         UserRole `gorm:"type:varchar(20);not null;default:'user'"`
 	IsActive     bool     `gorm:"not null;default:true"`
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// EmailVerifiedAt — когда пользователь подтвердил почту, nil — не подтверждал.
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	Identities []UserIdentity `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
@@ -0,0 +1,211 @@
+// Package oidc — вход через внешних OpenID Connect провайдеров
+// (authorization code flow с PKCE).
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var ErrUnknownProvider = errors.New("unknown oidc provider")
+
+type ProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// LoadConfigs читает провайдеров из окружения:
+//
+//	OIDC_PROVIDERS=google,keycloak
+//	OIDC_GOOGLE_ISSUER, OIDC_GOOGLE_CLIENT_ID, OIDC_GOOGLE_CLIENT_SECRET,
+//	OIDC_GOOGLE_REDIRECT_URL, OIDC_GOOGLE_SCOPES (через запятую, необязательно)
+func LoadConfigs() ([]ProviderConfig, error) {
+	var configs []ProviderConfig
+
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		cfg := ProviderConfig{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+		}
+		if scopes := os.Getenv(prefix + "SCOPES"); scopes != "" {
+			for _, s := range strings.Split(scopes, ",") {
+				cfg.Scopes = append(cfg.Scopes, strings.TrimSpace(s))
+			}
+		}
+
+		if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+			return nil, fmt.Errorf("oidc provider %q: %sISSUER, %sCLIENT_ID and %sREDIRECT_URL are required", name, prefix, prefix, prefix)
+		}
+		configs = append(configs, cfg)
+	}
+
+	return configs, nil
+}
+
+// Claims — то, что нужно user-service из ID-токена.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+}
+
+// Provider лениво выполняет discovery: недоступный при старте провайдер
+// не мешает запуску сервиса, запрос повторится при следующем входе.
+type Provider struct {
+	cfg ProviderConfig
+
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+func NewProvider(cfg ProviderConfig) *Provider {
+	return &Provider{cfg: cfg}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *Provider) discover() (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+
+	// контекст провайдера используется и для фоновой загрузки JWKS,
+	// поэтому он не должен зависеть от текущего запроса
+	providerCtx := gooidc.ClientContext(context.Background(), &http.Client{Timeout: 10 * time.Second})
+
+	provider, err := gooidc.NewProvider(providerCtx, p.cfg.Issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc discovery %q: %w", p.cfg.Name, err)
+	}
+
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{gooidc.ScopeOpenID, "email", "profile"}
+	}
+
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       scopes,
+	}
+	p.verifier = provider.Verifier(&gooidc.Config{ClientID: p.cfg.ClientID})
+
+	return p.oauth, p.verifier, nil
+}
+
+// AuthURL — адрес страницы входа провайдера.
+func (p *Provider) AuthURL(state, nonce, codeVerifier string) (string, error) {
+	oauth, _, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	return oauth.AuthCodeURL(
+		state,
+		gooidc.Nonce(nonce),
+		oauth2.S256ChallengeOption(codeVerifier),
+	), nil
+}
+
+// Exchange меняет code на токены, проверяет подпись ID-токена и nonce.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Claims, error) {
+	oauth, verifier, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauth.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("oidc code exchange: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("oidc: id_token is missing in token response")
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: invalid id_token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("oidc: nonce mismatch")
+	}
+
+	var claims struct {
+		Email         string       `json:"email"`
+		EmailVerified flexibleBool `json:"email_verified"`
+		GivenName     string       `json:"given_name"`
+		FamilyName    string       `json:"family_name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("oidc: decode claims: %w", err)
+	}
+
+	return &Claims{
+		Subject:       idToken.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(claims.Email)),
+		EmailVerified: bool(claims.EmailVerified),
+		GivenName:     claims.GivenName,
+		FamilyName:    claims.FamilyName,
+	}, nil
+}
+
+// flexibleBool — часть провайдеров отдаёт email_verified строкой "true".
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	*b = flexibleBool(strings.Trim(string(data), `"`) == "true")
+	return nil
+}
+
+// Registry — настроенные провайдеры по имени.
+type Registry map[string]*Provider
+
+func NewRegistry(configs []ProviderConfig) Registry {
+	r := make(Registry, len(configs))
+	for _, cfg := range configs {
+		r[cfg.Name] = NewProvider(cfg)
+	}
+	return r
+}
+
+func (r Registry) Get(name string) (*Provider, error) {
+	p, ok := r[strings.ToLower(name)]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
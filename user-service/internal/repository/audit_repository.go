@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepository interface {
+	Create(entry *models.AuditLog) error
+	// List возвращает записи, новые первыми; targetUserID = 0 — по всем пользователям.
+	List(targetUserID uint, offset, limit int) ([]models.AuditLog, int64, error)
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(entry *models.AuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *auditRepository) List(targetUserID uint, offset, limit int) ([]models.AuditLog, int64, error) {
+	q := r.db.Model(&models.AuditLog{})
+	if targetUserID != 0 {
+		q = q.Where("target_user_id = ?", targetUserID)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []models.AuditLog
+	err := q.
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&entries).Error
+
+	return entries, total, err
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type DataRequestRepository interface {
+	// Create сохраняет запрос вместе с Parts.
+	Create(req *models.DataRequest) error
+	// GetByID возвращает запрос с Parts, включая выгруженные данные.
+	GetByID(id uint) (*models.DataRequest, error)
+	// ListByUser возвращает запросы пользователя, новые первыми; Data в Parts не загружается.
+	ListByUser(userID uint) ([]models.DataRequest, error)
+	// GetPending — незавершённый запрос пользователя этого типа или ErrDataRequestNotFound.
+	GetPending(userID uint, reqType models.DataRequestType) (*models.DataRequest, error)
+	// Acknowledge отмечает часть сервиса выполненной и возвращает запрос
+	// без Data. Повторное подтверждение не меняет сохранённые данные.
+	Acknowledge(requestID uint, service string, data []byte, at time.Time) (*models.DataRequest, error)
+	Complete(id uint, at time.Time) error
+
+	// LoadUserData собирает данные пользователя в user-service для выгрузки.
+	LoadUserData(userID uint) (*UserData, error)
+	// EraseUser обезличивает учётную запись и удаляет связанные с ней данные.
+	EraseUser(userID uint) error
+}
+
+// UserData — всё, что user-service хранит о пользователе.
+type UserData struct {
+	User           models.User
+	Identities     []models.UserIdentity
+	Sessions       []models.Session
+	Memberships    []models.OrganizationMember
+	Applications   []models.OrganizerApplication
+	SecurityEvents []models.SecurityEvent
+}
+
+type dataRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewDataRequestRepository(db *gorm.DB) DataRequestRepository {
+	return &dataRequestRepository{db: db}
+}
+
+func (r *dataRequestRepository) Create(req *models.DataRequest) error {
+	return r.db.Create(req).Error
+}
+
+func (r *dataRequestRepository) GetByID(id uint) (*models.DataRequest, error) {
+	var req models.DataRequest
+
+	err := r.db.
+		Preload("Parts", func(db *gorm.DB) *gorm.DB { return db.Order("service ASC") }).
+		First(&req, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrDataRequestNotFound
+		}
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *dataRequestRepository) ListByUser(userID uint) ([]models.DataRequest, error) {
+	var reqs []models.DataRequest
+
+	err := r.db.
+		Preload("Parts", withoutData).
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Find(&reqs).Error
+
+	return reqs, err
+}
+
+func (r *dataRequestRepository) GetPending(userID uint, reqType models.DataRequestType) (*models.DataRequest, error) {
+	var req models.DataRequest
+
+	err := r.db.
+		Preload("Parts", withoutData).
+		Where("user_id = ? AND type = ? AND status = ?", userID, reqType, models.DataRequestPending).
+		Order("id DESC").
+		First(&req).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrDataRequestNotFound
+		}
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *dataRequestRepository) Acknowledge(requestID uint, service string, data []byte, at time.Time) (*models.DataRequest, error) {
+	var req models.DataRequest
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.DataRequestPart{}).
+			Where("data_request_id = ? AND service = ? AND status = ?", requestID, service, models.DataRequestPending).
+			Updates(map[string]any{
+				"status":          models.DataRequestCompleted,
+				"data":            data,
+				"acknowledged_at": at,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		err := tx.Preload("Parts", withoutData).First(&req, requestID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return e.ErrDataRequestNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, p := range req.Parts {
+			if p.Service == service {
+				return nil
+			}
+		}
+		return e.ErrDataRequestNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *dataRequestRepository) Complete(id uint, at time.Time) error {
+	return r.db.Model(&models.DataRequest{}).
+		Where("id = ? AND status = ?", id, models.DataRequestPending).
+		Updates(map[string]any{
+			"status":       models.DataRequestCompleted,
+			"completed_at": at,
+		}).Error
+}
+
+func (r *dataRequestRepository) LoadUserData(userID uint) (*UserData, error) {
+	var data UserData
+
+	if err := r.db.First(&data.User, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	byUser := func(dest any, order string) error {
+		return r.db.Where("user_id = ?", userID).Order(order).Find(dest).Error
+	}
+	if err := byUser(&data.Identities, "id ASC"); err != nil {
+		return nil, err
+	}
+	if err := byUser(&data.Sessions, "id ASC"); err != nil {
+		return nil, err
+	}
+	if err := r.db.Preload("Organization").Where("user_id = ?", userID).Order("id ASC").Find(&data.Memberships).Error; err != nil {
+		return nil, err
+	}
+	if err := byUser(&data.Applications, "id ASC"); err != nil {
+		return nil, err
+	}
+	if err := byUser(&data.SecurityEvents, "id ASC"); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (r *dataRequestRepository) EraseUser(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// строку пользователя оставляем: на неё ссылаются журнал аудита
+		// и заявки, но ни имени, ни адреса, ни пароля в ней больше нет
+		err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]any{
+			"email":             fmt.Sprintf("deleted-%d@deleted.invalid", userID),
+			"password_hash":     "",
+			"first_name":        "",
+			"last_name":         "",
+			"is_active":         false,
+			"email_verified_at": nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		for _, model := range []any{
+			&models.UserIdentity{},
+			&models.Session{},
+			&models.OneTimeToken{},
+			&models.MFAFactor{},
+			&models.RecoveryCode{},
+			&models.OrganizationMember{},
+			&models.SecurityEvent{},
+		} {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		err = tx.Model(&models.OrganizerApplication{}).Where("user_id = ?", userID).Updates(map[string]any{
+			"contact_name":  "",
+			"contact_email": "",
+			"contact_phone": "",
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		// готовые выгрузки содержат те же персональные данные
+		exports := tx.Model(&models.DataRequest{}).Select("id").
+			Where("user_id = ? AND type = ?", userID, models.DataRequestExport)
+		if err := tx.Where("data_request_id IN (?)", exports).Delete(&models.DataRequestPart{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ? AND type = ?", userID, models.DataRequestExport).Delete(&models.DataRequest{}).Error
+	})
+}
+
+// withoutData — Parts без выгруженных данных, для списков и статуса.
+func withoutData(db *gorm.DB) *gorm.DB {
+	return db.Omit("data").Order("service ASC")
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	loginFailuresPrefix = "login:failures:"
+	loginLockPrefix     = "login:lock:"
+)
+
+// reserveRetries — сколько раз Reserve повторяет транзакцию, которую
+// перебила параллельная попытка по тому же ключу.
+const reserveRetries = 10
+
+// LoginAttempts — неудачные попытки входа по одному ключу
+// (учётная запись или IP-адрес).
+type LoginAttempts struct {
+	Failures    int
+	LastFailure time.Time
+	// LockedUntil — до какого момента вход запрещён, нулевое значение — не заблокирован.
+	LockedUntil time.Time
+}
+
+func (a LoginAttempts) Locked(now time.Time) bool {
+	return now.Before(a.LockedUntil)
+}
+
+// LoginAttemptRepository считает неудачные попытки входа. Счётчик живёт
+// window с последней неудачи, блокировка — до указанного момента.
+type LoginAttemptRepository interface {
+	Get(ctx context.Context, key string) (LoginAttempts, error)
+	// Reserve атомарно учитывает попытку как неудачную ещё до проверки
+	// пароля, если allow пропускает её по состоянию до неё, и возвращает
+	// это состояние: параллельные попытки видят разные счётчики. Отклонённая
+	// попытка не меняет ни счётчик, ни время последней неудачи.
+	Reserve(ctx context.Context, key string, at time.Time, window time.Duration, allow func(LoginAttempts) bool) (LoginAttempts, bool, error)
+	// Release возвращает попытку, учтённую Reserve.
+	Release(ctx context.Context, key string) error
+	// Lock запрещает вход до until и обнуляет счётчик неудач.
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+type redisLoginAttemptRepository struct {
+	client *redis.Client
+}
+
+// NewLoginAttemptRepository хранит счётчики в Redis, чтобы лимит был общим
+// для всех реплик. Без Redis — в памяти процесса.
+func NewLoginAttemptRepository(client *redis.Client) LoginAttemptRepository {
+	if client == nil {
+		return NewMemoryLoginAttemptRepository()
+	}
+	return &redisLoginAttemptRepository{client: client}
+}
+
+func (r *redisLoginAttemptRepository) Get(ctx context.Context, key string) (LoginAttempts, error) {
+	pipe := r.client.Pipeline()
+	failures := pipe.HMGet(ctx, loginFailuresPrefix+key, "n", "last")
+	lock := pipe.Get(ctx, loginLockPrefix+key)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return LoginAttempts{}, err
+	}
+
+	var a LoginAttempts
+	if vals := failures.Val(); len(vals) == 2 {
+		a.Failures = parseInt(vals[0])
+		a.LastFailure = unixNano(parseInt64(vals[1]))
+	}
+	if until, err := lock.Int64(); err == nil {
+		a.LockedUntil = unixNano(until)
+	}
+	return a, nil
+}
+
+func (r *redisLoginAttemptRepository) Reserve(
+	ctx context.Context,
+	key string,
+	at time.Time,
+	window time.Duration,
+	allow func(LoginAttempts) bool,
+) (LoginAttempts, bool, error) {
+	k := loginFailuresPrefix + key
+
+	// WATCH: если ключ изменился между чтением и записью, EXEC не выполнится
+	// и решение принимается заново по свежему состоянию
+	for i := 0; i < reserveRetries; i++ {
+		var prev LoginAttempts
+		granted := false
+		err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+			vals, err := tx.HMGet(ctx, k, "n", "last").Result()
+			if err != nil && !errors.Is(err, redis.Nil) {
+				return err
+			}
+			if len(vals) == 2 {
+				prev.Failures = parseInt(vals[0])
+				prev.LastFailure = unixNano(parseInt64(vals[1]))
+			}
+			if !allow(prev) {
+				return nil
+			}
+
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.HIncrBy(ctx, k, "n", 1)
+				pipe.HSet(ctx, k, "last", at.UnixNano())
+				pipe.PExpire(ctx, k, window)
+				return nil
+			})
+			granted = err == nil
+			return err
+		}, k)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		return prev, granted, err
+	}
+	return LoginAttempts{}, false, redis.TxFailedErr
+}
+
+// releaseScript уменьшает счётчик и удаляет его на нуле, чтобы не
+// оставить ключ без TTL, если он успел истечь.
+var releaseScript = redis.NewScript(`
+local n = redis.call('HINCRBY', KEYS[1], 'n', -1)
+if n <= 0 then
+	redis.call('DEL', KEYS[1])
+end
+return n
+`)
+
+func (r *redisLoginAttemptRepository) Release(ctx context.Context, key string) error {
+	return releaseScript.Run(ctx, r.client, []string{loginFailuresPrefix + key}).Err()
+}
+
+func (r *redisLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, loginLockPrefix+key, until.UnixNano(), ttl)
+	pipe.Del(ctx, loginFailuresPrefix+key)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *redisLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	return r.client.Del(ctx, loginFailuresPrefix+key).Err()
+}
+
+func parseInt64(v any) int64 {
+	s, ok := v.(string)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
+
+func parseInt(v any) int {
+	return int(parseInt64(v))
+}
+
+func unixNano(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+type memoryLoginAttempts struct {
+	attempts  LoginAttempts
+	expiresAt time.Time
+}
+
+// memorySweepInterval — как часто удалять истёкшие счётчики и блокировки.
+const memorySweepInterval = time.Minute
+
+type memoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	failures map[string]memoryLoginAttempts
+	locks    map[string]time.Time
+}
+
+func NewMemoryLoginAttemptRepository() LoginAttemptRepository {
+	r := &memoryLoginAttemptRepository{
+		failures: make(map[string]memoryLoginAttempts),
+		locks:    make(map[string]time.Time),
+	}
+	go r.sweepLoop(memorySweepInterval)
+	return r
+}
+
+// sweepLoop чистит истёкшие записи по таймеру, а не на каждой неудаче:
+// иначе каждая неудача стоила бы O(n) от числа атакуемых ключей.
+func (r *memoryLoginAttemptRepository) sweepLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		r.sweep(time.Now())
+	}
+}
+
+func (r *memoryLoginAttemptRepository) sweep(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for k, v := range r.failures {
+		if now.After(v.expiresAt) {
+			delete(r.failures, k)
+		}
+	}
+	for k, until := range r.locks {
+		if now.After(until) {
+			delete(r.locks, k)
+		}
+	}
+}
+
+func (r *memoryLoginAttemptRepository) Get(_ context.Context, key string) (LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var a LoginAttempts
+	if v, ok := r.failures[key]; ok && now.Before(v.expiresAt) {
+		a = v.attempts
+	}
+	if until, ok := r.locks[key]; ok && now.Before(until) {
+		a.LockedUntil = until
+	}
+	return a, nil
+}
+
+func (r *memoryLoginAttemptRepository) Reserve(
+	_ context.Context,
+	key string,
+	at time.Time,
+	window time.Duration,
+	allow func(LoginAttempts) bool,
+) (LoginAttempts, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	v, ok := r.failures[key]
+	if !ok || now.After(v.expiresAt) {
+		v = memoryLoginAttempts{}
+	}
+	prev := v.attempts
+	if !allow(prev) {
+		return prev, false, nil
+	}
+
+	v.attempts.Failures++
+	v.attempts.LastFailure = at
+	v.expiresAt = now.Add(window)
+	r.failures[key] = v
+
+	return prev, true, nil
+}
+
+func (r *memoryLoginAttemptRepository) Release(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, ok := r.failures[key]
+	if !ok {
+		return nil
+	}
+	v.attempts.Failures--
+	if v.attempts.Failures <= 0 {
+		delete(r.failures, key)
+		return nil
+	}
+	r.failures[key] = v
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) Lock(_ context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.locks[key] = until
+	delete(r.failures, key)
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) Reset(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.failures, key)
+	return nil
+}
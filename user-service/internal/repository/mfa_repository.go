@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MFARepository interface {
+	GetFactor(userID uint) (*models.MFAFactor, error)
+	SaveFactor(factor *models.MFAFactor) error
+	// DeleteFactor удаляет фактор вместе с кодами восстановления.
+	DeleteFactor(userID uint) error
+	// ReplaceRecoveryCodes заменяет все коды восстановления пользователя.
+	ReplaceRecoveryCodes(userID uint, hashes []string) error
+	// UseRecoveryCode гасит неиспользованный код; false — такого кода нет.
+	UseRecoveryCode(userID uint, hash string, at time.Time) (bool, error)
+	// UseTOTPStep запоминает интервал принятого TOTP-кода, если он новее
+	// последнего; false — код этого или более позднего интервала уже принят.
+	UseTOTPStep(userID uint, step int64) (bool, error)
+}
+
+type mfaRepository struct {
+	db *gorm.DB
+}
+
+func NewMFARepository(db *gorm.DB) MFARepository {
+	return &mfaRepository{db: db}
+}
+
+func (r *mfaRepository) GetFactor(userID uint) (*models.MFAFactor, error) {
+	var factor models.MFAFactor
+
+	if err := r.db.First(&factor, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrMFANotEnrolled
+		}
+		return nil, err
+	}
+
+	return &factor, nil
+}
+
+func (r *mfaRepository) SaveFactor(factor *models.MFAFactor) error {
+	return r.db.Save(factor).Error
+}
+
+func (r *mfaRepository) DeleteFactor(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.MFAFactor{}).Error
+	})
+}
+
+func (r *mfaRepository) ReplaceRecoveryCodes(userID uint, hashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]models.RecoveryCode, 0, len(hashes))
+		for _, h := range hashes {
+			codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: h})
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *mfaRepository) UseTOTPStep(userID uint, step int64) (bool, error) {
+	res := r.db.Model(&models.MFAFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *mfaRepository) UseRecoveryCode(userID uint, hash string, at time.Time) (bool, error) {
+	res := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", at)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const oidcStatePrefix = "oidc:state:"
+
+// OIDCStateRepository хранит state входа через провайдера до callback.
+type OIDCStateRepository interface {
+	Save(ctx context.Context, state string, s models.OIDCLoginState, ttl time.Duration) error
+	// Take возвращает state и сразу удаляет его: повторный callback
+	// с тем же state получит ErrInvalidOIDCState.
+	Take(ctx context.Context, state string) (*models.OIDCLoginState, error)
+}
+
+type redisOIDCStateRepository struct {
+	client *redis.Client
+}
+
+// NewOIDCStateRepository хранит state в Redis, чтобы callback мог прийти
+// на любую реплику. Без Redis — в памяти процесса.
+func NewOIDCStateRepository(client *redis.Client) OIDCStateRepository {
+	if client == nil {
+		return NewMemoryOIDCStateRepository()
+	}
+	return &redisOIDCStateRepository{client: client}
+}
+
+func (r *redisOIDCStateRepository) Save(ctx context.Context, state string, s models.OIDCLoginState, ttl time.Duration) error {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, oidcStatePrefix+state, raw, ttl).Err()
+}
+
+func (r *redisOIDCStateRepository) Take(ctx context.Context, state string) (*models.OIDCLoginState, error) {
+	raw, err := r.client.GetDel(ctx, oidcStatePrefix+state).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, e.ErrInvalidOIDCState
+		}
+		return nil, err
+	}
+
+	var s models.OIDCLoginState
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, e.ErrInvalidOIDCState
+	}
+	return &s, nil
+}
+
+type memoryOIDCState struct {
+	state     models.OIDCLoginState
+	expiresAt time.Time
+}
+
+type memoryOIDCStateRepository struct {
+	mu     sync.Mutex
+	states map[string]memoryOIDCState
+}
+
+func NewMemoryOIDCStateRepository() OIDCStateRepository {
+	return &memoryOIDCStateRepository{states: make(map[string]memoryOIDCState)}
+}
+
+func (r *memoryOIDCStateRepository) Save(_ context.Context, state string, s models.OIDCLoginState, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for k, v := range r.states {
+		if now.After(v.expiresAt) {
+			delete(r.states, k)
+		}
+	}
+
+	r.states[state] = memoryOIDCState{state: s, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (r *memoryOIDCStateRepository) Take(_ context.Context, state string) (*models.OIDCLoginState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, ok := r.states[state]
+	delete(r.states, state)
+	if !ok || time.Now().After(v.expiresAt) {
+		return nil, e.ErrInvalidOIDCState
+	}
+	return &v.state, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type OneTimeTokenRepository interface {
+	Create(token *models.OneTimeToken) error
+	GetByHash(purpose models.TokenPurpose, hash string) (*models.OneTimeToken, error)
+	// Consume помечает токен использованным. Возвращает
+	// ErrInvalidOneTimeToken, если его уже использовали.
+	Consume(id uint, at time.Time) error
+	// RegisterAttempt учитывает попытку ввести код, пока их меньше max.
+	// Возвращает ErrInvalidOneTimeToken, если попытки исчерпаны или токен
+	// уже использован.
+	RegisterAttempt(id uint, max int) error
+	// InvalidateForUser гасит все неиспользованные токены пользователя с этим назначением.
+	InvalidateForUser(userID uint, purpose models.TokenPurpose, at time.Time) error
+}
+
+type oneTimeTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewOneTimeTokenRepository(db *gorm.DB) OneTimeTokenRepository {
+	return &oneTimeTokenRepository{db: db}
+}
+
+func (r *oneTimeTokenRepository) Create(token *models.OneTimeToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *oneTimeTokenRepository) GetByHash(purpose models.TokenPurpose, hash string) (*models.OneTimeToken, error) {
+	var token models.OneTimeToken
+
+	err := r.db.
+		Where("purpose = ? AND token_hash = ?", purpose, hash).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrInvalidOneTimeToken
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *oneTimeTokenRepository) Consume(id uint, at time.Time) error {
+	res := r.db.Model(&models.OneTimeToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	// токен успели использовать параллельным запросом
+	if res.RowsAffected == 0 {
+		return e.ErrInvalidOneTimeToken
+	}
+	return nil
+}
+
+func (r *oneTimeTokenRepository) RegisterAttempt(id uint, max int) error {
+	res := r.db.Model(&models.OneTimeToken{}).
+		Where("id = ? AND used_at IS NULL AND attempts < ?", id, max).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return e.ErrInvalidOneTimeToken
+	}
+	return nil
+}
+
+func (r *oneTimeTokenRepository) InvalidateForUser(userID uint, purpose models.TokenPurpose, at time.Time) error {
+	return r.db.Model(&models.OneTimeToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", at).Error
+}
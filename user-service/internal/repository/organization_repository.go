@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"errors"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrganizationRepository interface {
+	// Create создаёт организацию и делает owner её первым участником.
+	Create(org *models.Organization, owner *models.OrganizationMember) error
+	GetByID(id uint) (*models.Organization, error)
+	Update(org *models.Organization) error
+	// ListByUser возвращает членства пользователя вместе с организациями.
+	ListByUser(userID uint) ([]models.OrganizationMember, error)
+
+	GetMember(organizationID, userID uint) (*models.OrganizationMember, error)
+	// ListMembers возвращает участников вместе с пользователями.
+	ListMembers(organizationID uint) ([]models.OrganizationMember, error)
+	AddMember(member *models.OrganizationMember) error
+	// UpdateMemberRole и RemoveMember не дают оставить организацию без
+	// владельца: в этом случае возвращается ErrLastOrganizationOwner.
+	UpdateMemberRole(organizationID, userID uint, role models.OrganizationRole) error
+	RemoveMember(organizationID, userID uint) error
+}
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) Create(org *models.Organization, owner *models.OrganizationMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return err
+		}
+		owner.OrganizationID = org.ID
+		return tx.Create(owner).Error
+	})
+}
+
+func (r *organizationRepository) GetByID(id uint) (*models.Organization, error) {
+	var org models.Organization
+
+	if err := r.db.First(&org, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+func (r *organizationRepository) Update(org *models.Organization) error {
+	return r.db.Model(org).Updates(map[string]any{
+		"name":        org.Name,
+		"description": org.Description,
+	}).Error
+}
+
+func (r *organizationRepository) ListByUser(userID uint) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+
+	err := r.db.
+		Preload("Organization").
+		Where("user_id = ?", userID).
+		Order("organization_id ASC").
+		Find(&members).Error
+
+	return members, err
+}
+
+func (r *organizationRepository) GetMember(organizationID, userID uint) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+
+	err := r.db.
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrNotOrganizationMember
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *organizationRepository) ListMembers(organizationID uint) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+
+	err := r.db.
+		Preload("User").
+		Where("organization_id = ?", organizationID).
+		Order("id ASC").
+		Find(&members).Error
+
+	return members, err
+}
+
+func (r *organizationRepository) AddMember(member *models.OrganizationMember) error {
+	return r.db.Create(member).Error
+}
+
+func (r *organizationRepository) UpdateMemberRole(organizationID, userID uint, role models.OrganizationRole) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		member, err := lockMember(tx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if member.Role == models.OrgRoleOwner && role != models.OrgRoleOwner {
+			if err := ensureAnotherOwner(tx, organizationID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(member).Update("role", role).Error
+	})
+}
+
+func (r *organizationRepository) RemoveMember(organizationID, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		member, err := lockMember(tx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if member.Role == models.OrgRoleOwner {
+			if err := ensureAnotherOwner(tx, organizationID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(member).Error
+	})
+}
+
+// lockMember блокирует всех владельцев организации и возвращает участника,
+// чтобы два параллельных понижения не оставили организацию без владельца.
+func lockMember(tx *gorm.DB, organizationID, userID uint) (*models.OrganizationMember, error) {
+	var owners []models.OrganizationMember
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("organization_id = ? AND role = ?", organizationID, models.OrgRoleOwner).
+		Find(&owners).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var member models.OrganizationMember
+	err = tx.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrNotOrganizationMember
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func ensureAnotherOwner(tx *gorm.DB, organizationID uint) error {
+	var owners int64
+	err := tx.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", organizationID, models.OrgRoleOwner).
+		Count(&owners).Error
+	if err != nil {
+		return err
+	}
+	if owners < 2 {
+		return e.ErrLastOrganizationOwner
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"errors"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type OrganizerApplicationRepository interface {
+	Create(app *models.OrganizerApplication) error
+	GetByID(id uint) (*models.OrganizerApplication, error)
+	// GetLatestByUser возвращает последнюю заявку пользователя.
+	GetLatestByUser(userID uint) (*models.OrganizerApplication, error)
+	// List возвращает заявки, старые первыми; пустой status — все заявки.
+	List(status models.ApplicationStatus, offset, limit int) ([]models.OrganizerApplication, int64, error)
+	// Decide переводит заявку из pending в итоговый статус. Если заявку
+	// уже рассмотрели, возвращает ErrApplicationAlreadyReviewed.
+	Decide(app *models.OrganizerApplication) error
+	// Reopen возвращает одобренную заявку на рассмотрение, если выдать
+	// роль не удалось.
+	Reopen(id uint) error
+}
+
+type organizerApplicationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizerApplicationRepository(db *gorm.DB) OrganizerApplicationRepository {
+	return &organizerApplicationRepository{db: db}
+}
+
+// Create возвращает ErrApplicationPending, если у пользователя уже есть
+// заявка на рассмотрении (частичный уникальный индекс по user_id).
+func (r *organizerApplicationRepository) Create(app *models.OrganizerApplication) error {
+	if err := r.db.Create(app).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return e.ErrApplicationPending
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *organizerApplicationRepository) GetByID(id uint) (*models.OrganizerApplication, error) {
+	var app models.OrganizerApplication
+
+	if err := r.db.First(&app, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrApplicationNotFound
+		}
+		return nil, err
+	}
+
+	return &app, nil
+}
+
+func (r *organizerApplicationRepository) GetLatestByUser(userID uint) (*models.OrganizerApplication, error) {
+	var app models.OrganizerApplication
+
+	err := r.db.
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		First(&app).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrApplicationNotFound
+		}
+		return nil, err
+	}
+
+	return &app, nil
+}
+
+func (r *organizerApplicationRepository) List(status models.ApplicationStatus, offset, limit int) ([]models.OrganizerApplication, int64, error) {
+	q := r.db.Model(&models.OrganizerApplication{})
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var apps []models.OrganizerApplication
+	err := q.
+		Order("id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&apps).Error
+
+	return apps, total, err
+}
+
+func (r *organizerApplicationRepository) Decide(app *models.OrganizerApplication) error {
+	res := r.db.Model(&models.OrganizerApplication{}).
+		Where("id = ? AND status = ?", app.ID, models.ApplicationPending).
+		Updates(map[string]any{
+			"status":          app.Status,
+			"reviewer_id":     app.ReviewerID,
+			"decision_reason": app.DecisionReason,
+			"reviewed_at":     app.ReviewedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return e.ErrApplicationAlreadyReviewed
+	}
+	return nil
+}
+
+func (r *organizerApplicationRepository) Reopen(id uint) error {
+	return r.db.Model(&models.OrganizerApplication{}).
+		Where("id = ? AND status = ?", id, models.ApplicationApproved).
+		Updates(map[string]any{
+			"status":          models.ApplicationPending,
+			"reviewer_id":     nil,
+			"decision_reason": "",
+			"reviewed_at":     nil,
+		}).Error
+}
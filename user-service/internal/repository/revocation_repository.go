@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Формат ключей должен совпадать с проверкой в gateway (gateway/revocation).
+const (
+	revokedTokenPrefix   = "revoked:jti:"
+	revokedSessionPrefix = "revoked:sid:"
+	revokedUserPrefix    = "revoked:user:"
+)
+
+type RevocationRepository interface {
+	// RevokeToken отзывает один токен до его истечения.
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	// RevokeSession отзывает access-токены сессии. ttl — не меньше
+	// срока жизни access-токена.
+	RevokeSession(ctx context.Context, sessionID uint, ttl time.Duration) error
+	// RevokeUserTokens отзывает все токены пользователя, выданные до before
+	// включительно с точностью до секунды (как iat в токене).
+	RevokeUserTokens(ctx context.Context, userID uint, before time.Time, ttl time.Duration) error
+}
+
+type revocationRepository struct {
+	client *redis.Client
+}
+
+func NewRevocationRepository(client *redis.Client) RevocationRepository {
+	if client == nil {
+		return nopRevocationRepository{}
+	}
+	return &revocationRepository{client: client}
+}
+
+func (r *revocationRepository) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if jti == "" || ttl <= 0 {
+		return nil
+	}
+	return r.client.Set(ctx, revokedTokenPrefix+jti, 1, ttl).Err()
+}
+
+func (r *revocationRepository) RevokeUserTokens(ctx context.Context, userID uint, before time.Time, ttl time.Duration) error {
+	return r.client.Set(ctx, revokedUserKey(userID), before.Unix(), ttl).Err()
+}
+
+func (r *revocationRepository) RevokeSession(ctx context.Context, sessionID uint, ttl time.Duration) error {
+	key := revokedSessionPrefix + strconv.FormatUint(uint64(sessionID), 10)
+	return r.client.Set(ctx, key, 1, ttl).Err()
+}
+
+func revokedUserKey(userID uint) string {
+	return revokedUserPrefix + strconv.FormatUint(uint64(userID), 10)
+}
+
+type nopRevocationRepository struct{}
+
+func (nopRevocationRepository) RevokeToken(context.Context, string, time.Time) error { return nil }
+
+func (nopRevocationRepository) RevokeUserTokens(context.Context, uint, time.Time, time.Duration) error {
+	return nil
+}
+
+func (nopRevocationRepository) RevokeSession(context.Context, uint, time.Duration) error { return nil }
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SecurityEventRepository interface {
+	Create(event *models.SecurityEvent) error
+	// List возвращает события, новые первыми; userID = 0 — по всем пользователям.
+	List(userID uint, offset, limit int) ([]models.SecurityEvent, int64, error)
+}
+
+type securityEventRepository struct {
+	db *gorm.DB
+}
+
+func NewSecurityEventRepository(db *gorm.DB) SecurityEventRepository {
+	return &securityEventRepository{db: db}
+}
+
+func (r *securityEventRepository) Create(event *models.SecurityEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *securityEventRepository) List(userID uint, offset, limit int) ([]models.SecurityEvent, int64, error) {
+	q := r.db.Model(&models.SecurityEvent{})
+	if userID != 0 {
+		q = q.Where("user_id = ?", userID)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []models.SecurityEvent
+	err := q.
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&events).Error
+
+	return events, total, err
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	Create(session *models.Session) error
+	GetByID(id uint) (*models.Session, error)
+	// Rotate записывает новый refresh-токен и метаданные сессии, только если
+	// в базе всё ещё previousHash и сессия не отозвана. false — токен уже
+	// заменён параллельным запросом или сессию отозвали.
+	Rotate(session *models.Session, previousHash string) (bool, error)
+	MarkMFA(id uint) error
+	ListActiveByUser(userID uint, now time.Time) ([]models.Session, error)
+	Revoke(id uint, at time.Time) error
+	// RevokeAllByUser отзывает все активные сессии и возвращает их ID.
+	RevokeAllByUser(userID uint, at time.Time) ([]uint, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) Create(session *models.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *sessionRepository) GetByID(id uint) (*models.Session, error) {
+	var session models.Session
+
+	if err := r.db.First(&session, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, e.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *sessionRepository) Rotate(session *models.Session, previousHash string) (bool, error) {
+	res := r.db.Model(&models.Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, previousHash).
+		Updates(map[string]any{
+			"refresh_token_hash": session.RefreshTokenHash,
+			"last_used_at":       session.LastUsedAt,
+			"expires_at":         session.ExpiresAt,
+			"user_agent":         session.UserAgent,
+			"ip":                 session.IP,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *sessionRepository) MarkMFA(id uint) error {
+	return r.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("mfa", true).Error
+}
+
+func (r *sessionRepository) ListActiveByUser(userID uint, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+
+	err := r.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+
+	return sessions, err
+}
+
+func (r *sessionRepository) Revoke(id uint, at time.Time) error {
+	return r.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+func (r *sessionRepository) RevokeAllByUser(userID uint, at time.Time) ([]uint, error) {
+	var ids []uint
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&models.Session{}).
+			Where("id IN ?", ids).
+			Update("revoked_at", at).Error
+	})
+
+	return ids, err
+}
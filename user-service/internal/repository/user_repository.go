@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"strings"
 
 	e "user-service/internal/errors"
 	"user-service/internal/models"
@@ -13,7 +14,20 @@ type UserRepository interface {
 	Create(user *models.User) error
 	GetByID(id uint) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
+	// GetByIDs возвращает найденных пользователей, отсутствующие ID пропускает.
+	GetByIDs(ids []uint) ([]models.User, error)
 	Update(user *models.User) error
+	// List ищет пользователей для админки, возвращает страницу и общее число.
+	List(filter UserFilter) ([]models.User, int64, error)
+}
+
+type UserFilter struct {
+	// Query — подстрока email, имени или фамилии.
+	Query    string
+	Role     models.UserRole
+	IsActive *bool
+	Offset   int
+	Limit    int
 }
 
 type userRepository struct {
@@ -56,6 +70,45 @@ func (r *userRepository) GetByEmail(email string) (*models.User, error) {
 }
 
 
+func (r *userRepository) GetByIDs(ids []uint) ([]models.User, error) {
+	var users []models.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	err := r.db.Where("id IN ?", ids).Order("id").Find(&users).Error
+	return users, err
+}
+
 func (r *userRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
 }
+
+func (r *userRepository) List(filter UserFilter) ([]models.User, int64, error) {
+	q := r.db.Model(&models.User{})
+
+	if filter.Query != "" {
+		like := "%" + strings.ToLower(filter.Query) + "%"
+		q = q.Where("LOWER(email) LIKE ? OR LOWER(first_name) LIKE ? OR LOWER(last_name) LIKE ?", like, like, like)
+	}
+	if filter.Role != "" {
+		q = q.Where("role = ?", filter.Role)
+	}
+	if filter.IsActive != nil {
+		q = q.Where("is_active = ?", *filter.IsActive)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	err := q.
+		Order("id DESC").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&users).Error
+
+	return users, total, err
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+	"user-service/userclient"
+	"user-service/userclient/requestid"
+)
+
+// AuditActor — кто выполняет действие в админке.
+type AuditActor struct {
+	UserID uint
+	IP     string
+}
+
+// AdminService — управление пользователями администраторами.
+// Каждое изменение пишется в журнал аудита.
+type AdminService struct {
+	userRepo    repository.UserRepository
+	auditRepo   repository.AuditRepository
+	authService *AuthService
+	events      *UserEvents
+}
+
+func NewAdminService(
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditRepository,
+	authService *AuthService,
+	events *UserEvents,
+) *AdminService {
+	return &AdminService{
+		userRepo:    userRepo,
+		auditRepo:   auditRepo,
+		authService: authService,
+		events:      events,
+	}
+}
+
+func (s *AdminService) ListUsers(filter repository.UserFilter) ([]models.User, int64, error) {
+	return s.userRepo.List(filter)
+}
+
+func (s *AdminService) GetUser(id uint) (*models.User, error) {
+	return s.userRepo.GetByID(id)
+}
+
+// SetActive включает или выключает учётную запись. При выключении все
+// сессии пользователя завершаются, а выданные токены отзываются.
+func (s *AdminService) SetActive(ctx context.Context, actor AuditActor, userID uint, active bool) (*models.User, error) {
+	if actor.UserID == userID {
+		return nil, e.ErrCannotModifySelf
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsActive == active {
+		return user, nil
+	}
+
+	user.IsActive = active
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	action := models.AuditUserActivated
+	if !active {
+		action = models.AuditUserDeactivated
+		if err := s.authService.RevokeAllSessions(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.audit(ctx, actor, action, user.ID, nil); err != nil {
+		return nil, err
+	}
+
+	if active {
+		s.events.Updated(ctx, user)
+	} else {
+		s.events.Deactivated(ctx, user, userclient.DeactivatedByAdmin)
+	}
+	return user, nil
+}
+
+// ChangeRole меняет роль пользователя. Access-токены со старой ролью
+// отзываются, новая роль появится в токене после /auth/refresh.
+func (s *AdminService) ChangeRole(ctx context.Context, actor AuditActor, userID uint, role models.UserRole) (*models.User, error) {
+	if !role.Valid() {
+		return nil, e.ErrInvalidRole
+	}
+	if actor.UserID == userID {
+		return nil, e.ErrCannotModifySelf
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := user.Role
+	if previous == role {
+		return user, nil
+	}
+
+	user.Role = role
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	if err := s.authService.RevokeUserTokens(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, actor, models.AuditUserRoleChanged, user.ID, map[string]any{
+		"from": previous,
+		"to":   role,
+	}); err != nil {
+		return nil, err
+	}
+
+	s.events.RoleChanged(ctx, user, previous)
+	return user, nil
+}
+
+func (s *AdminService) ListAuditLogs(targetUserID uint, offset, limit int) ([]models.AuditLog, int64, error) {
+	return s.auditRepo.List(targetUserID, offset, limit)
+}
+
+// BootstrapAdmin назначает администратором существующего пользователя
+// с указанным email. Нужен, чтобы в системе появился первый администратор.
+func (s *AdminService) BootstrapAdmin(ctx context.Context, email string) (*models.User, error) {
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == models.RoleAdmin {
+		return user, nil
+	}
+	return s.ChangeRole(ctx, AuditActor{}, user.ID, models.RoleAdmin)
+}
+
+func (s *AdminService) audit(ctx context.Context, actor AuditActor, action models.AuditAction, targetUserID uint, details map[string]any) error {
+	raw := []byte("{}")
+	if details != nil {
+		var err error
+		if raw, err = json.Marshal(details); err != nil {
+			return err
+		}
+	}
+
+	return s.auditRepo.Create(&models.AuditLog{
+		ActorID:      actor.UserID,
+		Action:       action,
+		TargetUserID: targetUserID,
+		Details:      string(raw),
+		RequestID:    requestid.FromContext(ctx),
+		IP:           truncate(actor.IP, 64),
+		CreatedAt:    time.Now(),
+	})
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/userclient"
+)
+
+type mockAuditRepo struct {
+	entries []models.AuditLog
+}
+
+func (m *mockAuditRepo) Create(entry *models.AuditLog) error {
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+
+func (m *mockAuditRepo) List(uint, int, int) ([]models.AuditLog, int64, error) {
+	return m.entries, int64(len(m.entries)), nil
+}
+
+func newAdminService(t *testing.T) (*AdminService, *AuthService, *mockSessionRepo, *mockRevocations, *mockAuditRepo) {
+	t.Helper()
+
+	auth, sessions, revocations := newAuthService(t)
+	users := auth.userRepo.(*mockUserRepo)
+	users.users[2] = &models.User{ID: 2, Email: "admin@b.c", Role: models.RoleAdmin, IsActive: true}
+
+	audit := &mockAuditRepo{}
+	return NewAdminService(users, audit, auth, nil), auth, sessions, revocations, audit
+}
+
+func TestAdmin_DeactivateRevokesSessions(t *testing.T) {
+	svc, auth, sessions, revocations, audit := newAdminService(t)
+	ctx := context.Background()
+	admin := AuditActor{UserID: 2, IP: "10.0.0.1"}
+
+	if _, err := auth.Login(context.Background(), "a@b.c", "secret", SessionMeta{}); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	user, err := svc.SetActive(ctx, admin, 1, false)
+	if err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	if user.IsActive {
+		t.Fatalf("user must be inactive")
+	}
+	if sessions.sessions[1].RevokedAt == nil || len(revocations.users) != 1 {
+		t.Fatalf("sessions and tokens must be revoked")
+	}
+
+	if _, err := auth.Login(context.Background(), "a@b.c", "secret", SessionMeta{}); !errors.Is(err, e.ErrUserInactive) {
+		t.Fatalf("inactive user must not log in, got %v", err)
+	}
+
+	if len(audit.entries) != 1 {
+		t.Fatalf("expected one audit entry, got %d", len(audit.entries))
+	}
+	entry := audit.entries[0]
+	if entry.Action != models.AuditUserDeactivated || entry.ActorID != 2 || entry.TargetUserID != 1 || entry.IP != "10.0.0.1" {
+		t.Fatalf("unexpected audit entry: %+v", entry)
+	}
+}
+
+func TestAdmin_ChangeRole(t *testing.T) {
+	svc, _, _, revocations, audit := newAdminService(t)
+	ctx := context.Background()
+	admin := AuditActor{UserID: 2}
+
+	if _, err := svc.ChangeRole(ctx, admin, 1, "superuser"); !errors.Is(err, e.ErrInvalidRole) {
+		t.Fatalf("expected ErrInvalidRole, got %v", err)
+	}
+
+	user, err := svc.ChangeRole(ctx, admin, 1, models.RoleOrganizer)
+	if err != nil {
+		t.Fatalf("change role: %v", err)
+	}
+	if user.Role != models.RoleOrganizer {
+		t.Fatalf("role not changed: %s", user.Role)
+	}
+	if len(revocations.users) != 1 {
+		t.Fatalf("tokens with old role must be revoked")
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Details != `{"from":"user","to":"organizer"}` {
+		t.Fatalf("unexpected audit entries: %+v", audit.entries)
+	}
+}
+
+func TestAdmin_CannotModifySelf(t *testing.T) {
+	svc, _, _, _, audit := newAdminService(t)
+	ctx := context.Background()
+	admin := AuditActor{UserID: 2}
+
+	if _, err := svc.SetActive(ctx, admin, 2, false); !errors.Is(err, e.ErrCannotModifySelf) {
+		t.Fatalf("expected ErrCannotModifySelf, got %v", err)
+	}
+	if _, err := svc.ChangeRole(ctx, admin, 2, models.RoleUser); !errors.Is(err, e.ErrCannotModifySelf) {
+		t.Fatalf("expected ErrCannotModifySelf, got %v", err)
+	}
+	if len(audit.entries) != 0 {
+		t.Fatalf("rejected actions must not be audited")
+	}
+}
+
+type mockUserEventPublisher struct {
+	events []userclient.UserEvent
+}
+
+func (m *mockUserEventPublisher) SendUserEvent(_ context.Context, msg userclient.UserEvent) error {
+	m.events = append(m.events, msg)
+	return nil
+}
+
+func TestAdmin_PublishesUserEvents(t *testing.T) {
+	svc, _, _, _, _ := newAdminService(t)
+	publisher := &mockUserEventPublisher{}
+	svc.events = NewUserEvents(publisher, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+	admin := AuditActor{UserID: 2}
+
+	if _, err := svc.ChangeRole(ctx, admin, 1, models.RoleOrganizer); err != nil {
+		t.Fatalf("change role: %v", err)
+	}
+	if _, err := svc.SetActive(ctx, admin, 1, false); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	// повторная деактивация ничего не меняет и не публикуется
+	if _, err := svc.SetActive(ctx, admin, 1, false); err != nil {
+		t.Fatalf("deactivate again: %v", err)
+	}
+
+	svc.events.Close()
+	if len(publisher.events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", publisher.events)
+	}
+	changed, deactivated := publisher.events[0], publisher.events[1]
+	if changed.Type != userclient.TopicUserRoleChanged || changed.Role != string(models.RoleOrganizer) ||
+		changed.PreviousRole != string(models.RoleUser) || changed.Version != userclient.UserEventVersion {
+		t.Fatalf("unexpected role change event: %+v", changed)
+	}
+	if deactivated.Type != userclient.TopicUserDeactivated || deactivated.IsActive ||
+		deactivated.Reason != userclient.DeactivatedByAdmin || deactivated.UserID != 1 {
+		t.Fatalf("unexpected deactivation event: %+v", deactivated)
+	}
+}
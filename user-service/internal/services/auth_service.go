@@ -1,7 +1,12 @@
 package services
 
 import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -11,23 +16,68 @@ import (
 	"user-service/internal/utils"
 )
 
+// SessionMeta — данные устройства, с которого пришёл запрос.
+type SessionMeta struct {
+	UserAgent string
+	IP        string
+}
+
+// mfaChallengeTTL — сколько ждём код второго фактора после пароля.
+const mfaChallengeTTL = 5 * time.Minute
+
+// mfaChallengeAttempts — сколько кодов можно ввести к одному challenge,
+// дальше нужно снова войти по паролю.
+const mfaChallengeAttempts = 5
+
+// LoginResult — итог входа по паролю. Если у пользователя включена 2FA,
+// токены не выдаются: вместо них MFAToken для POST /auth/mfa/verify.
+type LoginResult struct {
+	User         *models.User
+	AccessToken  string
+	RefreshToken string
+	MFAToken     string
+}
+
+func (r *LoginResult) MFARequired() bool {
+	return r.MFAToken != ""
+}
+
 type AuthService struct {
 	userRepo     repository.UserRepository
+	sessionRepo  repository.SessionRepository
 	tokenManager *utils.TokenManager
+	revocations  repository.RevocationRepository
+	mfa          *MFAService
+	challenges   repository.OneTimeTokenRepository
+	security     *SecurityService
+	events       *UserEvents
+	now          func() time.Time
 }
 
 func NewAuthService(
 	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
 	tokenManager *utils.TokenManager,
+	revocations repository.RevocationRepository,
+	mfa *MFAService,
+	challenges repository.OneTimeTokenRepository,
+	security *SecurityService,
+	events *UserEvents,
 ) *AuthService {
 	return &AuthService{
 		userRepo:     userRepo,
+		sessionRepo:  sessionRepo,
 		tokenManager: tokenManager,
+		revocations:  revocations,
+		mfa:          mfa,
+		challenges:   challenges,
+		security:     security,
+		events:       events,
+		now:          time.Now,
 	}
 }
 
-
-func (s *AuthService) Register(email string,password string,firstName string,lastName string,) (*models.User, string, string, error) {
+func (s *AuthService) Register(ctx context.Context, email string, password string, firstName string, lastName string, meta SessionMeta) (*models.User, string, string, error) {
 
 	if _, err := s.userRepo.GetByEmail(email); err == nil {
 		return nil, "", "", e.ErrEmailAlreadyExists
@@ -53,15 +103,9 @@ func (s *AuthService) Register(email string,password string,firstName string,las
 	if err := s.userRepo.Create(user); err != nil {
 		return nil, "", "", err
 	}
+	s.events.Registered(ctx, user)
 
-	accessToken, err :=
-		s.tokenManager.GenerateAccessToken(user.ID, string(user.Role))
-	if err != nil {
-		return nil, "", "", err
-	}
-
-	refreshToken, err :=
-		s.tokenManager.GenerateRefreshToken(user.ID)
+	accessToken, refreshToken, err := s.startSession(user, meta, false)
 	if err != nil {
 		return nil, "", "", err
 	}
@@ -69,49 +113,206 @@ func (s *AuthService) Register(email string,password string,firstName string,las
 	return user, accessToken, refreshToken, nil
 }
 
-
-func (s *AuthService) Login(email string,password string,) (*models.User, string, string, error) {
+// Login проверяет пароль. Пока учётная запись или IP заблокированы после
+// неверных попыток, пароль не проверяется и возвращается *LoginBlockedError.
+func (s *AuthService) Login(ctx context.Context, email string, password string, meta SessionMeta) (*LoginResult, error) {
+	attempt, err := s.security.BeginLogin(ctx, email, meta.IP)
+	if err != nil {
+		return nil, err
+	}
 
 	user, err := s.userRepo.GetByEmail(email)
 	if err != nil {
-		return nil, "", "", e.ErrInvalidCredentials
+		if !errors.Is(err, e.ErrUserNotFound) {
+			_ = s.security.LoginCancelled(ctx, attempt)
+			return nil, err
+		}
+		if err := s.security.LoginFailed(ctx, attempt, 0, meta); err != nil {
+			return nil, err
+		}
+		return nil, e.ErrInvalidCredentials
 	}
 
 	if !user.IsActive {
-		return nil, "", "", e.ErrUserInactive
+		if err := s.security.LoginCancelled(ctx, attempt); err != nil {
+			return nil, err
+		}
+		return nil, e.ErrUserInactive
 	}
 
 	if err := bcrypt.CompareHashAndPassword(
 		[]byte(user.PasswordHash),
 		[]byte(password),
 	); err != nil {
-		return nil, "", "", e.ErrInvalidCredentials
+		if err := s.security.LoginFailed(ctx, attempt, user.ID, meta); err != nil {
+			return nil, err
+		}
+		return nil, e.ErrInvalidCredentials
 	}
 
-	accessToken, err :=
-		s.tokenManager.GenerateAccessToken(user.ID, string(user.Role))
+	if err := s.security.LoginSucceeded(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	return s.LoginUser(user, meta)
+}
+
+// LoginUser завершает вход уже опознанного пользователя — по паролю или
+// через внешнего провайдера: выдаёт токены или challenge второго фактора.
+func (s *AuthService) LoginUser(user *models.User, meta SessionMeta) (*LoginResult, error) {
+	if !user.IsActive {
+		return nil, e.ErrUserInactive
+	}
+
+	mfaEnabled, err := s.mfa.Enabled(user.ID)
 	if err != nil {
-		return nil, "", "", err
+		return nil, err
+	}
+	if mfaEnabled {
+		challenge, err := issueOneTimeToken(s.challenges, user.ID, models.PurposeMFAChallenge, s.now(), mfaChallengeTTL)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{User: user, MFAToken: challenge}, nil
 	}
 
-	refreshToken, err :=
-		s.tokenManager.GenerateRefreshToken(user.ID)
+	accessToken, refreshToken, err := s.startSession(user, meta, false)
 	if err != nil {
-		return nil, "", "", err
+		return nil, err
 	}
 
-	return user, accessToken, refreshToken, nil
+	return &LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken}, nil
 }
 
-func (s *AuthService) RefreshTokens(refreshToken string,) (string, string, error) {
+// VerifyMFA завершает вход: проверяет код второго фактора для
+// challenge-токена, выданного Login, и открывает сессию.
+func (s *AuthService) VerifyMFA(mfaToken, code string, meta SessionMeta) (*LoginResult, error) {
+	challenge, err := s.challenges.GetByHash(models.PurposeMFAChallenge, hashToken(mfaToken))
+	if err != nil || !challenge.Usable(s.now()) {
+		return nil, e.ErrInvalidMFAChallenge
+	}
+
+	// попытка учитывается до проверки кода, чтобы параллельные запросы
+	// не обошли лимит
+	if err := s.challenges.RegisterAttempt(challenge.ID, mfaChallengeAttempts); err != nil {
+		if errors.Is(err, e.ErrInvalidOneTimeToken) {
+			return nil, e.ErrInvalidMFAChallenge
+		}
+		return nil, err
+	}
+
+	if err := s.mfa.VerifyCode(challenge.UserID, code); err != nil {
+		return nil, err
+	}
+
+	// challenge гасится только после верного кода, чтобы опечатка не
+	// заставляла вводить пароль заново; перебор ограничен mfaChallengeAttempts
+	if err := s.challenges.Consume(challenge.ID, s.now()); err != nil {
+		return nil, e.ErrInvalidMFAChallenge
+	}
+
+	user, err := s.userRepo.GetByID(challenge.UserID)
+	if err != nil {
+		return nil, e.ErrUserNotFound
+	}
+	if !user.IsActive {
+		return nil, e.ErrUserInactive
+	}
+
+	accessToken, refreshToken, err := s.startSession(user, meta, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// MarkSessionMFA отмечает текущую сессию подтверждённой вторым фактором,
+// например сразу после включения 2FA, и выдаёт для неё новый access-токен.
+func (s *AuthService) MarkSessionMFA(user *models.User, sessionID uint) (string, error) {
+	if sessionID != 0 {
+		session, err := s.sessionRepo.GetByID(sessionID)
+		if err != nil {
+			return "", err
+		}
+		if session.UserID != user.ID {
+			return "", e.ErrSessionNotFound
+		}
+		if err := s.sessionRepo.MarkMFA(session.ID); err != nil {
+			return "", err
+		}
+	}
+	return s.IssueAccessTokenForUser(user, sessionID)
+}
+
+// startSession заводит сессию для нового устройства и выдаёт первую пару токенов.
+func (s *AuthService) startSession(user *models.User, meta SessionMeta, mfa bool) (string, string, error) {
+	now := s.now()
+
+	session := &models.Session{
+		UserID:     user.ID,
+		UserAgent:  truncate(meta.UserAgent, 512),
+		IP:         truncate(meta.IP, 64),
+		MFA:        mfa,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.tokenManager.RefreshTTL()),
+	}
+	if err := s.sessionRepo.Create(session); err != nil {
+		return "", "", err
+	}
+
+	accessToken, err := s.issueAccessToken(user, session)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, err := s.tokenManager.GenerateRefreshToken(user.ID, session.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	session.RefreshTokenHash = hashToken(refreshToken)
+	if ok, err := s.sessionRepo.Rotate(session, ""); err != nil {
+		return "", "", err
+	} else if !ok {
+		return "", "", e.ErrSessionNotFound
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// RefreshTokens ротирует refresh-токен сессии. Повторное предъявление
+// уже заменённого токена означает утечку: сессия отзывается целиком.
+func (s *AuthService) RefreshTokens(ctx context.Context, refreshToken string, meta SessionMeta) (string, string, error) {
 
 	claims, err := s.tokenManager.ParseToken(refreshToken)
 	if err != nil {
 		return "", "", err
 	}
 
-	if claims.Type != utils.RefreshToken {
-		return "", "", errors.New("invalid refresh token")
+	if claims.Type != utils.RefreshToken || claims.SessionID == 0 {
+		return "", "", utils.ErrInvalidToken
+	}
+
+	session, err := s.sessionRepo.GetByID(claims.SessionID)
+	if err != nil {
+		if errors.Is(err, e.ErrSessionNotFound) {
+			return "", "", utils.ErrInvalidToken
+		}
+		return "", "", err
+	}
+
+	now := s.now()
+	if session.UserID != claims.UserID || !session.Active(now) {
+		return "", "", utils.ErrInvalidToken
+	}
+
+	if subtle.ConstantTimeCompare([]byte(session.RefreshTokenHash), []byte(hashToken(refreshToken))) != 1 {
+		if err := s.revokeSession(ctx, session.ID); err != nil {
+			return "", "", err
+		}
+		return "", "", e.ErrRefreshTokenReused
 	}
 
 	user, err := s.userRepo.GetByID(claims.UserID)
@@ -123,25 +324,160 @@ func (s *AuthService) RefreshTokens(refreshToken string,) (string, string, error
 		return "", "", e.ErrUserInactive
 	}
 
-	newAccessToken, err :=
-		s.tokenManager.GenerateAccessToken(user.ID, string(user.Role))
+	newAccessToken, err := s.issueAccessToken(user, session)
 	if err != nil {
 		return "", "", err
 	}
 
 	newRefreshToken, err :=
-		s.tokenManager.GenerateRefreshToken(user.ID)
+		s.tokenManager.GenerateRefreshToken(user.ID, session.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	previousHash := session.RefreshTokenHash
+	session.RefreshTokenHash = hashToken(newRefreshToken)
+	session.LastUsedAt = now
+	session.ExpiresAt = now.Add(s.tokenManager.RefreshTTL())
+	if meta.UserAgent != "" {
+		session.UserAgent = truncate(meta.UserAgent, 512)
+	}
+	if meta.IP != "" {
+		session.IP = truncate(meta.IP, 64)
+	}
+	// ротация условная: проигравший параллельный refresh с тем же токеном
+	// или refresh после отзыва сессии считается повторным предъявлением
+	ok, err := s.sessionRepo.Rotate(session, previousHash)
 	if err != nil {
 		return "", "", err
 	}
+	if !ok {
+		if err := s.revokeSession(ctx, session.ID); err != nil {
+			return "", "", err
+		}
+		return "", "", e.ErrRefreshTokenReused
+	}
 
 	return newAccessToken, newRefreshToken, nil
 }
 
-	func (s *AuthService) IssueAccessTokenForUser(	user *models.User,) (string, error) {
-		return s.tokenManager.GenerateAccessToken(
-			user.ID,
-			string(user.Role),
-		)
+// ParseAccessToken проверяет access-токен, выданный этим сервисом.
+func (s *AuthService) ParseAccessToken(accessToken string) (*utils.TokenClaims, error) {
+	claims, err := s.tokenManager.ParseToken(accessToken)
+	if err != nil || claims.Type != utils.AccessToken {
+		return nil, utils.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// Logout завершает сессию, которой выдан access-токен.
+func (s *AuthService) Logout(ctx context.Context, accessToken string) error {
+	access, err := s.ParseAccessToken(accessToken)
+	if err != nil {
+		return err
+	}
+
+	if err := s.revocations.RevokeToken(ctx, access.ID, access.ExpiresAt.Time); err != nil {
+		return err
+	}
+
+	if access.SessionID == 0 {
+		return nil
+	}
+	return s.revokeSession(ctx, access.SessionID)
+}
+
+// LogoutAll завершает все сессии пользователя на всех устройствах.
+func (s *AuthService) LogoutAll(ctx context.Context, accessToken string) error {
+	access, err := s.ParseAccessToken(accessToken)
+	if err != nil {
+		return err
+	}
+
+	return s.RevokeAllSessions(ctx, access.UserID)
+}
+
+// RevokeAllSessions завершает все сессии пользователя и отзывает
+// выданные ему access-токены, например после сброса пароля.
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID uint) error {
+	if _, err := s.sessionRepo.RevokeAllByUser(userID, s.now()); err != nil {
+		return err
+	}
+	return s.RevokeUserTokens(ctx, userID)
+}
+
+func (s *AuthService) ListSessions(userID uint) ([]models.Session, error) {
+	return s.sessionRepo.ListActiveByUser(userID, s.now())
+}
+
+// RevokeSession завершает одну сессию пользователя, например потерянное устройство.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uint) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID || session.RevokedAt != nil {
+		return e.ErrSessionNotFound
+	}
+
+	return s.revokeSession(ctx, session.ID)
+}
+
+// revokeSession отзывает refresh-токен сессии в базе и её
+// access-токены в gateway.
+func (s *AuthService) revokeSession(ctx context.Context, sessionID uint) error {
+	if err := s.sessionRepo.Revoke(sessionID, s.now()); err != nil {
+		return err
 	}
+	return s.revocations.RevokeSession(ctx, sessionID, s.tokenManager.AccessTTL())
+}
+
+// RevokeUserTokens отзывает все ранее выданные access-токены
+// пользователя: при деактивации, смене роли и т.п.
+func (s *AuthService) RevokeUserTokens(ctx context.Context, userID uint) error {
+	return s.revocations.RevokeUserTokens(ctx, userID, s.now(), s.tokenManager.AccessTTL())
+}
 
+func (s *AuthService) JWKS() utils.JWKSet {
+	return s.tokenManager.JWKS()
+}
+
+// IssueAccessTokenForUser выдаёт access-токен в рамках существующей
+// сессии, например после смены роли. Чужая, завершённая или пропавшая
+// сессия — ErrSessionNotFound; sessionID = 0 — токен без сессии.
+func (s *AuthService) IssueAccessTokenForUser(user *models.User, sessionID uint) (string, error) {
+	session := &models.Session{}
+	if sessionID != 0 {
+		found, err := s.sessionRepo.GetByID(sessionID)
+		if err != nil {
+			return "", err
+		}
+		if found.UserID != user.ID || !found.Active(s.now()) {
+			return "", e.ErrSessionNotFound
+		}
+		session = found
+	}
+	return s.issueAccessToken(user, session)
+}
+
+func (s *AuthService) issueAccessToken(user *models.User, session *models.Session) (string, error) {
+	return s.tokenManager.GenerateAccessToken(utils.AccessParams{
+		UserID:        user.ID,
+		Role:          string(user.Role),
+		SessionID:     session.ID,
+		EmailVerified: user.EmailVerified(),
+		MFA:           session.MFA,
+	})
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
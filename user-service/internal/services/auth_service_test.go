@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+	"user-service/internal/utils"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type mockUserRepo struct {
+	users map[uint]*models.User
+}
+
+func (m *mockUserRepo) Create(user *models.User) error {
+	user.ID = uint(len(m.users) + 1)
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *mockUserRepo) GetByID(id uint) (*models.User, error) {
+	if u, ok := m.users[id]; ok {
+		return u, nil
+	}
+	return nil, e.ErrUserNotFound
+}
+
+func (m *mockUserRepo) GetByEmail(email string) (*models.User, error) {
+	for _, u := range m.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, e.ErrUserNotFound
+}
+
+func (m *mockUserRepo) GetByIDs(ids []uint) ([]models.User, error) {
+	var out []models.User
+	for _, id := range ids {
+		if u, ok := m.users[id]; ok {
+			out = append(out, *u)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockUserRepo) Update(user *models.User) error {
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *mockUserRepo) List(repository.UserFilter) ([]models.User, int64, error) {
+	var out []models.User
+	for _, u := range m.users {
+		out = append(out, *u)
+	}
+	return out, int64(len(out)), nil
+}
+
+type mockSessionRepo struct {
+	sessions map[uint]*models.Session
+	// beforeRotate имитирует запрос, успевший между чтением сессии и ротацией
+	beforeRotate func()
+}
+
+func (m *mockSessionRepo) Create(s *models.Session) error {
+	s.ID = uint(len(m.sessions) + 1)
+	cp := *s
+	m.sessions[s.ID] = &cp
+	return nil
+}
+
+func (m *mockSessionRepo) GetByID(id uint) (*models.Session, error) {
+	if s, ok := m.sessions[id]; ok {
+		cp := *s
+		return &cp, nil
+	}
+	return nil, e.ErrSessionNotFound
+}
+
+func (m *mockSessionRepo) Rotate(s *models.Session, previousHash string) (bool, error) {
+	if hook := m.beforeRotate; hook != nil {
+		m.beforeRotate = nil
+		hook()
+	}
+	stored, ok := m.sessions[s.ID]
+	if !ok || stored.RevokedAt != nil || stored.RefreshTokenHash != previousHash {
+		return false, nil
+	}
+	cp := *s
+	cp.RevokedAt = nil
+	m.sessions[s.ID] = &cp
+	return true, nil
+}
+
+func (m *mockSessionRepo) MarkMFA(id uint) error {
+	if s, ok := m.sessions[id]; ok && s.RevokedAt == nil {
+		s.MFA = true
+	}
+	return nil
+}
+
+func (m *mockSessionRepo) ListActiveByUser(userID uint, now time.Time) ([]models.Session, error) {
+	var out []models.Session
+	for _, s := range m.sessions {
+		if s.UserID == userID && s.Active(now) {
+			out = append(out, *s)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockSessionRepo) Revoke(id uint, at time.Time) error {
+	if s, ok := m.sessions[id]; ok && s.RevokedAt == nil {
+		s.RevokedAt = &at
+	}
+	return nil
+}
+
+func (m *mockSessionRepo) RevokeAllByUser(userID uint, at time.Time) ([]uint, error) {
+	var ids []uint
+	for _, s := range m.sessions {
+		if s.UserID == userID && s.RevokedAt == nil {
+			s.RevokedAt = &at
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids, nil
+}
+
+type mockRevocations struct {
+	sessions []uint
+	users    []uint
+	err      error
+}
+
+func (m *mockRevocations) RevokeToken(context.Context, string, time.Time) error { return nil }
+
+func (m *mockRevocations) RevokeSession(_ context.Context, id uint, _ time.Duration) error {
+	m.sessions = append(m.sessions, id)
+	return nil
+}
+
+func (m *mockRevocations) RevokeUserTokens(_ context.Context, id uint, _ time.Time, _ time.Duration) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.users = append(m.users, id)
+	return nil
+}
+
+func newAuthService(t *testing.T) (*AuthService, *mockSessionRepo, *mockRevocations) {
+	t.Helper()
+
+	keys, err := utils.NewEphemeralKeyStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+
+	users := &mockUserRepo{users: map[uint]*models.User{
+		1: {ID: 1, Email: "a@b.c", PasswordHash: string(hash), Role: models.RoleUser, IsActive: true},
+	}}
+	sessions := &mockSessionRepo{sessions: map[uint]*models.Session{}}
+	revocations := &mockRevocations{}
+
+	secrets, err := utils.NewEphemeralSecretBox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfa := NewMFAService(users, &mockMFARepo{}, secrets, "test")
+	challenges := &mockTokenRepo{tokens: map[uint]*models.OneTimeToken{}}
+
+	security := NewSecurityService(repository.NewMemoryLoginAttemptRepository(), &mockSecurityEventRepo{}, DefaultLoginPolicy())
+
+	tm := utils.NewTokenManager(keys, time.Minute, time.Hour, "test")
+	return NewAuthService(users, sessions, tm, revocations, mfa, challenges, security, nil), sessions, revocations
+}
+
+func TestAuthService_RefreshRotatesToken(t *testing.T) {
+	svc, sessions, _ := newAuthService(t)
+	ctx := context.Background()
+
+	res, err := svc.Login(context.Background(), "a@b.c", "secret", SessionMeta{UserAgent: "phone"})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	refresh := res.RefreshToken
+
+	_, rotated, err := svc.RefreshTokens(ctx, refresh, SessionMeta{IP: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if rotated == refresh {
+		t.Fatalf("refresh token must be rotated")
+	}
+
+	s := sessions.sessions[1]
+	if s.RefreshTokenHash != hashToken(rotated) || s.IP != "10.0.0.1" || s.UserAgent != "phone" {
+		t.Fatalf("session not updated: %+v", s)
+	}
+}
+
+func TestAuthService_RefreshReuseRevokesSession(t *testing.T) {
+	svc, sessions, revocations := newAuthService(t)
+	ctx := context.Background()
+
+	res, _ := svc.Login(context.Background(), "a@b.c", "secret", SessionMeta{})
+	stolen := res.RefreshToken
+	_, rotated, err := svc.RefreshTokens(ctx, stolen, SessionMeta{})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+
+	if _, _, err := svc.RefreshTokens(ctx, stolen, SessionMeta{}); !errors.Is(err, e.ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+	if sessions.sessions[1].RevokedAt == nil {
+		t.Fatalf("session must be revoked after reuse")
+	}
+	if len(revocations.sessions) != 1 || revocations.sessions[0] != 1 {
+		t.Fatalf("session access tokens must be revoked, got %v", revocations.sessions)
+	}
+
+	// легитимный владелец тоже теряет сессию
+	if _, _, err := svc.RefreshTokens(ctx, rotated, SessionMeta{}); !errors.Is(err, utils.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken for revoked session, got %v", err)
+	}
+}
+
+func TestAuthService_RefreshRacingLogoutKeepsSessionRevoked(t *testing.T) {
+	svc, sessions, _ := newAuthService(t)
+	ctx := context.Background()
+
+	res, _ := svc.Login(ctx, "a@b.c", "secret", SessionMeta{})
+	sessions.beforeRotate = func() {
+		if err := svc.LogoutAll(ctx, res.AccessToken); err != nil {
+			t.Fatalf("logout all: %v", err)
+		}
+	}
+
+	if _, _, err := svc.RefreshTokens(ctx, res.RefreshToken, SessionMeta{}); !errors.Is(err, e.ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+	if sessions.sessions[1].RevokedAt == nil {
+		t.Fatalf("refresh must not bring a revoked session back")
+	}
+}
+
+func TestAuthService_ParallelRefreshOnlyOneWins(t *testing.T) {
+	svc, sessions, _ := newAuthService(t)
+	ctx := context.Background()
+
+	res, _ := svc.Login(ctx, "a@b.c", "secret", SessionMeta{})
+	var winner string
+	sessions.beforeRotate = func() {
+		_, rotated, err := svc.RefreshTokens(ctx, res.RefreshToken, SessionMeta{})
+		if err != nil {
+			t.Fatalf("first refresh: %v", err)
+		}
+		winner = rotated
+	}
+
+	if _, _, err := svc.RefreshTokens(ctx, res.RefreshToken, SessionMeta{}); !errors.Is(err, e.ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused for the second refresh, got %v", err)
+	}
+	if _, _, err := svc.RefreshTokens(ctx, winner, SessionMeta{}); !errors.Is(err, utils.ErrInvalidToken) {
+		t.Fatalf("reuse must revoke the session, got %v", err)
+	}
+}
+
+func TestAuthService_LogoutAll(t *testing.T) {
+	svc, sessions, revocations := newAuthService(t)
+	ctx := context.Background()
+
+	res, _ := svc.Login(context.Background(), "a@b.c", "secret", SessionMeta{UserAgent: "laptop"})
+	access := res.AccessToken
+	_, _ = svc.Login(context.Background(), "a@b.c", "secret", SessionMeta{UserAgent: "phone"})
+
+	if err := svc.LogoutAll(ctx, access); err != nil {
+		t.Fatalf("logout all: %v", err)
+	}
+
+	active, _ := sessions.ListActiveByUser(1, time.Now())
+	if len(active) != 0 {
+		t.Fatalf("expected no active sessions, got %d", len(active))
+	}
+	if len(revocations.users) != 1 {
+		t.Fatalf("expected user access tokens to be revoked")
+	}
+}
+
+func TestAuthService_IssueAccessTokenRequiresOwnSession(t *testing.T) {
+	svc, sessions, _ := newAuthService(t)
+	ctx := context.Background()
+
+	res, err := svc.Login(ctx, "a@b.c", "secret", SessionMeta{})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	other := &models.User{ID: 2, Role: models.RoleUser, IsActive: true}
+
+	if _, err := svc.IssueAccessTokenForUser(res.User, 1); err != nil {
+		t.Fatalf("own session: %v", err)
+	}
+	if _, err := svc.IssueAccessTokenForUser(other, 1); !errors.Is(err, e.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound for a foreign session, got %v", err)
+	}
+	if _, err := svc.IssueAccessTokenForUser(res.User, 99); !errors.Is(err, e.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound for a missing session, got %v", err)
+	}
+
+	_ = sessions.Revoke(1, time.Now())
+	if _, err := svc.IssueAccessTokenForUser(res.User, 1); !errors.Is(err, e.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound for a revoked session, got %v", err)
+	}
+}
@@ -0,0 +1,481 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+	"user-service/userclient"
+)
+
+// ServiceName — имя user-service в частях запроса на выгрузку.
+const ServiceName = "user-service"
+
+// DataRequestPublisher рассылает запросы на выгрузку и удаление данных.
+// Реализуется kafka.Producer.
+type DataRequestPublisher interface {
+	SendDataRequest(ctx context.Context, topic string, msg userclient.DataRequestMessage) error
+}
+
+// SessionRevoker завершает сессии пользователя. Реализуется AuthService.
+type SessionRevoker interface {
+	RevokeAllSessions(ctx context.Context, userID uint) error
+}
+
+// DataRequestService — выгрузка и удаление данных пользователя во всех
+// сервисах. Запрос публикуется в Kafka, каждый сервис из services
+// обрабатывает свои данные и подтверждает через внутренний API. Удаление
+// завершается обезличиванием учётной записи, когда подтвердили все.
+type DataRequestService struct {
+	repo      repository.DataRequestRepository
+	userRepo  repository.UserRepository
+	orgs      repository.OrganizationRepository
+	sessions  SessionRevoker
+	publisher DataRequestPublisher
+	events    *UserEvents
+	services  []string
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+func NewDataRequestService(
+	repo repository.DataRequestRepository,
+	userRepo repository.UserRepository,
+	orgs repository.OrganizationRepository,
+	sessions SessionRevoker,
+	publisher DataRequestPublisher,
+	events *UserEvents,
+	services []string,
+	logger *slog.Logger,
+) *DataRequestService {
+	return &DataRequestService{
+		repo:      repo,
+		userRepo:  userRepo,
+		orgs:      orgs,
+		sessions:  sessions,
+		publisher: publisher,
+		events:    events,
+		services:  services,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// RequestExport запускает выгрузку. Пока предыдущая не готова, новая не
+// создаётся: сервисам повторно отправляется та же.
+func (s *DataRequestService) RequestExport(ctx context.Context, userID uint) (*models.DataRequest, error) {
+	if pending, err := s.repo.GetPending(userID, models.DataRequestExport); err == nil {
+		return pending, s.publish(ctx, pending)
+	} else if !errors.Is(err, e.ErrDataRequestNotFound) {
+		return nil, err
+	}
+
+	data, err := s.repo.LoadUserData(userID)
+	if err != nil {
+		return nil, err
+	}
+	own, err := json.Marshal(toUserExport(data))
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	req := s.newRequest(userID, models.DataRequestExport)
+	req.Parts = append(req.Parts, models.DataRequestPart{
+		Service:        ServiceName,
+		Status:         models.DataRequestCompleted,
+		Data:           own,
+		AcknowledgedAt: &now,
+	})
+	if err := s.repo.Create(req); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "data export requested", "request_id", req.ID, "user_id", userID)
+	if err := s.publish(ctx, req); err != nil {
+		return nil, err
+	}
+	return req, s.finishIfDone(ctx, req)
+}
+
+// RequestDeletion запускает удаление учётной записи. confirmEmail должен
+// совпадать с адресом пользователя без учёта регистра. После рассылки запроса вход
+// блокируется, а данные удаляются, когда все сервисы подтвердят обработку.
+func (s *DataRequestService) RequestDeletion(ctx context.Context, userID uint, confirmEmail string) (*models.DataRequest, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(confirmEmail), strings.TrimSpace(user.Email)) {
+		return nil, e.ErrDeletionNotConfirmed
+	}
+	if err := s.ensureNotLastOwner(userID); err != nil {
+		return nil, err
+	}
+
+	req, err := s.repo.GetPending(userID, models.DataRequestDeletion)
+	if errors.Is(err, e.ErrDataRequestNotFound) {
+		req = s.newRequest(userID, models.DataRequestDeletion)
+		if err := s.repo.Create(req); err != nil {
+			return nil, err
+		}
+		s.logger.InfoContext(ctx, "account deletion requested", "request_id", req.ID, "user_id", userID)
+	} else if err != nil {
+		return nil, err
+	}
+
+	// учётную запись выключаем только после рассылки: иначе при сбое
+	// Kafka пользователь не сможет войти и повторить запрос
+	if err := s.publish(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if user.IsActive {
+		user.IsActive = false
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+		s.events.Deactivated(ctx, user, userclient.DeactivatedByDeletion)
+	}
+	if err := s.sessions.RevokeAllSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return req, s.finishIfDone(ctx, req)
+}
+
+// Acknowledge принимает подтверждение сервиса service.
+func (s *DataRequestService) Acknowledge(ctx context.Context, requestID uint, service string, data json.RawMessage) error {
+	if len(data) == 0 {
+		data = json.RawMessage("null")
+	}
+
+	req, err := s.repo.Acknowledge(requestID, service, data, s.now())
+	if err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "data request acknowledged",
+		"request_id", requestID, "service", service, "type", req.Type)
+	return s.finishIfDone(ctx, req)
+}
+
+func (s *DataRequestService) List(userID uint) ([]models.DataRequest, error) {
+	return s.repo.ListByUser(userID)
+}
+
+// Get возвращает запрос пользователя; чужие запросы не видны.
+func (s *DataRequestService) Get(userID, requestID uint) (*models.DataRequest, error) {
+	req, err := s.repo.GetByID(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserID != userID {
+		return nil, e.ErrDataRequestNotFound
+	}
+	return req, nil
+}
+
+// Export возвращает готовую выгрузку пользователя вместе с данными сервисов.
+func (s *DataRequestService) Export(userID, requestID uint) (*models.DataRequest, error) {
+	req, err := s.Get(userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Type != models.DataRequestExport {
+		return nil, e.ErrDataRequestNotFound
+	}
+	if req.Status != models.DataRequestCompleted {
+		return nil, e.ErrDataExportNotReady
+	}
+	return req, nil
+}
+
+// WriteExportJSON пишет выгрузку одним JSON-документом.
+func WriteExportJSON(w io.Writer, req *models.DataRequest) error {
+	doc := struct {
+		exportManifest
+		Services map[string]json.RawMessage `json:"services"`
+	}{
+		exportManifest: newExportManifest(req),
+		Services:       make(map[string]json.RawMessage, len(req.Parts)),
+	}
+	for _, p := range req.Parts {
+		doc.Services[p.Service] = partData(p)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// WriteExportZip пишет выгрузку архивом: manifest.json и по файлу на сервис.
+func WriteExportZip(w io.Writer, req *models.DataRequest) error {
+	zw := zip.NewWriter(w)
+
+	manifest, err := json.MarshalIndent(newExportManifest(req), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, "manifest.json", manifest); err != nil {
+		return err
+	}
+	for _, p := range req.Parts {
+		if err := writeZipFile(zw, p.Service+".json", partData(p)); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func (s *DataRequestService) newRequest(userID uint, reqType models.DataRequestType) *models.DataRequest {
+	req := &models.DataRequest{
+		UserID: userID,
+		Type:   reqType,
+		Status: models.DataRequestPending,
+	}
+	for _, name := range s.services {
+		req.Parts = append(req.Parts, models.DataRequestPart{
+			Service: name,
+			Status:  models.DataRequestPending,
+		})
+	}
+	return req
+}
+
+func (s *DataRequestService) publish(ctx context.Context, req *models.DataRequest) error {
+	if req.Done() {
+		return nil
+	}
+
+	topic := userclient.TopicExportRequested
+	if req.Type == models.DataRequestDeletion {
+		topic = userclient.TopicDeletionRequested
+	}
+
+	err := s.publisher.SendDataRequest(ctx, topic, userclient.DataRequestMessage{
+		RequestID:   req.ID,
+		UserID:      req.UserID,
+		Type:        userclient.DataRequestType(req.Type),
+		RequestedAt: req.CreatedAt,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to publish data request", "request_id", req.ID, "error", err)
+		return fmt.Errorf("%w: %v", e.ErrDataRequestNotDeliverable, err)
+	}
+	return nil
+}
+
+// finishIfDone завершает запрос, когда подтвердили все сервисы. При сбое
+// запрос остаётся незавершённым и доделывается при следующем подтверждении.
+func (s *DataRequestService) finishIfDone(ctx context.Context, req *models.DataRequest) error {
+	if req.Status != models.DataRequestPending || !req.Done() {
+		return nil
+	}
+
+	if req.Type == models.DataRequestDeletion {
+		if err := s.repo.EraseUser(req.UserID); err != nil {
+			return err
+		}
+	}
+
+	now := s.now()
+	if err := s.repo.Complete(req.ID, now); err != nil {
+		return err
+	}
+	req.Status = models.DataRequestCompleted
+	req.CompletedAt = &now
+
+	s.logger.InfoContext(ctx, "data request completed", "request_id", req.ID, "user_id", req.UserID, "type", req.Type)
+	return nil
+}
+
+// ensureNotLastOwner — организацию нельзя оставить без владельца.
+func (s *DataRequestService) ensureNotLastOwner(userID uint) error {
+	memberships, err := s.orgs.ListByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range memberships {
+		if m.Role != models.OrgRoleOwner {
+			continue
+		}
+		members, err := s.orgs.ListMembers(m.OrganizationID)
+		if err != nil {
+			return err
+		}
+		owners := 0
+		for _, other := range members {
+			if other.Role == models.OrgRoleOwner {
+				owners++
+			}
+		}
+		if owners < 2 {
+			return e.ErrLastOrganizationOwner
+		}
+	}
+	return nil
+}
+
+type exportManifest struct {
+	RequestID   uint       `json:"request_id"`
+	UserID      uint       `json:"user_id"`
+	RequestedAt time.Time  `json:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+func newExportManifest(req *models.DataRequest) exportManifest {
+	return exportManifest{
+		RequestID:   req.ID,
+		UserID:      req.UserID,
+		RequestedAt: req.CreatedAt,
+		CompletedAt: req.CompletedAt,
+	}
+}
+
+func partData(p models.DataRequestPart) json.RawMessage {
+	if len(p.Data) == 0 {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(p.Data)
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// userExport — данные пользователя в user-service. Хэши паролей и
+// токенов в выгрузку не попадают.
+type userExport struct {
+	Profile struct {
+		ID              uint            `json:"id"`
+		Email           string          `json:"email"`
+		FirstName       string          `json:"first_name"`
+		LastName        string          `json:"last_name"`
+		Role            models.UserRole `json:"role"`
+		EmailVerifiedAt *time.Time      `json:"email_verified_at"`
+		CreatedAt       time.Time       `json:"created_at"`
+	} `json:"profile"`
+	Identities []exportIdentity `json:"identities"`
+	Sessions   []exportSession  `json:"sessions"`
+	// Organizations — членство в организациях.
+	Organizations         []exportMembership  `json:"organizations"`
+	OrganizerApplications []exportApplication `json:"organizer_applications"`
+	SecurityEvents        []exportSecurity    `json:"security_events"`
+}
+
+type exportIdentity struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type exportSession struct {
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+type exportMembership struct {
+	OrganizationID uint                    `json:"organization_id"`
+	Name           string                  `json:"name"`
+	Role           models.OrganizationRole `json:"role"`
+	JoinedAt       time.Time               `json:"joined_at"`
+}
+
+type exportApplication struct {
+	OrganizationName string                   `json:"organization_name"`
+	ContactName      string                   `json:"contact_name"`
+	ContactEmail     string                   `json:"contact_email"`
+	ContactPhone     string                   `json:"contact_phone"`
+	Website          string                   `json:"website"`
+	Description      string                   `json:"description"`
+	Status           models.ApplicationStatus `json:"status"`
+	DecisionReason   string                   `json:"decision_reason,omitempty"`
+	CreatedAt        time.Time                `json:"created_at"`
+	ReviewedAt       *time.Time               `json:"reviewed_at"`
+}
+
+type exportSecurity struct {
+	Type      models.SecurityEventType `json:"type"`
+	IP        string                   `json:"ip"`
+	UserAgent string                   `json:"user_agent"`
+	CreatedAt time.Time                `json:"created_at"`
+}
+
+func toUserExport(d *repository.UserData) userExport {
+	var out userExport
+	out.Profile.ID = d.User.ID
+	out.Profile.Email = d.User.Email
+	out.Profile.FirstName = d.User.FirstName
+	out.Profile.LastName = d.User.LastName
+	out.Profile.Role = d.User.Role
+	out.Profile.EmailVerifiedAt = d.User.EmailVerifiedAt
+	out.Profile.CreatedAt = d.User.CreatedAt
+
+	out.Identities = make([]exportIdentity, 0, len(d.Identities))
+	for _, i := range d.Identities {
+		out.Identities = append(out.Identities, exportIdentity{Provider: i.Provider, Email: i.Email, CreatedAt: i.CreatedAt})
+	}
+	out.Sessions = make([]exportSession, 0, len(d.Sessions))
+	for _, s := range d.Sessions {
+		out.Sessions = append(out.Sessions, exportSession{
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			RevokedAt:  s.RevokedAt,
+		})
+	}
+	out.Organizations = make([]exportMembership, 0, len(d.Memberships))
+	for _, m := range d.Memberships {
+		item := exportMembership{OrganizationID: m.OrganizationID, Role: m.Role, JoinedAt: m.CreatedAt}
+		if m.Organization != nil {
+			item.Name = m.Organization.Name
+		}
+		out.Organizations = append(out.Organizations, item)
+	}
+	out.OrganizerApplications = make([]exportApplication, 0, len(d.Applications))
+	for _, a := range d.Applications {
+		out.OrganizerApplications = append(out.OrganizerApplications, exportApplication{
+			OrganizationName: a.OrganizationName,
+			ContactName:      a.ContactName,
+			ContactEmail:     a.ContactEmail,
+			ContactPhone:     a.ContactPhone,
+			Website:          a.Website,
+			Description:      a.Description,
+			Status:           a.Status,
+			DecisionReason:   a.DecisionReason,
+			CreatedAt:        a.CreatedAt,
+			ReviewedAt:       a.ReviewedAt,
+		})
+	}
+	out.SecurityEvents = make([]exportSecurity, 0, len(d.SecurityEvents))
+	for _, ev := range d.SecurityEvents {
+		out.SecurityEvents = append(out.SecurityEvents, exportSecurity{
+			Type:      ev.Type,
+			IP:        ev.IP,
+			UserAgent: ev.UserAgent,
+			CreatedAt: ev.CreatedAt,
+		})
+	}
+	return out
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"sort"
+	"testing"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+	"user-service/userclient"
+)
+
+type mockDataRequestRepo struct {
+	reqs   map[uint]*models.DataRequest
+	users  *mockUserRepo
+	erased []uint
+}
+
+func (m *mockDataRequestRepo) Create(req *models.DataRequest) error {
+	req.ID = uint(len(m.reqs) + 1)
+	req.CreatedAt = time.Now()
+	m.reqs[req.ID] = req
+	return nil
+}
+
+func (m *mockDataRequestRepo) GetByID(id uint) (*models.DataRequest, error) {
+	if req, ok := m.reqs[id]; ok {
+		return req, nil
+	}
+	return nil, e.ErrDataRequestNotFound
+}
+
+func (m *mockDataRequestRepo) ListByUser(userID uint) ([]models.DataRequest, error) {
+	var out []models.DataRequest
+	for _, req := range m.reqs {
+		if req.UserID == userID {
+			out = append(out, *req)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockDataRequestRepo) GetPending(userID uint, reqType models.DataRequestType) (*models.DataRequest, error) {
+	for _, req := range m.reqs {
+		if req.UserID == userID && req.Type == reqType && req.Status == models.DataRequestPending {
+			return req, nil
+		}
+	}
+	return nil, e.ErrDataRequestNotFound
+}
+
+func (m *mockDataRequestRepo) Acknowledge(requestID uint, service string, data []byte, at time.Time) (*models.DataRequest, error) {
+	req, ok := m.reqs[requestID]
+	if !ok {
+		return nil, e.ErrDataRequestNotFound
+	}
+	for i := range req.Parts {
+		p := &req.Parts[i]
+		if p.Service != service {
+			continue
+		}
+		if p.Status == models.DataRequestPending {
+			p.Status = models.DataRequestCompleted
+			p.Data = data
+			p.AcknowledgedAt = &at
+		}
+		return req, nil
+	}
+	return nil, e.ErrDataRequestNotFound
+}
+
+func (m *mockDataRequestRepo) Complete(id uint, at time.Time) error {
+	m.reqs[id].Status = models.DataRequestCompleted
+	m.reqs[id].CompletedAt = &at
+	return nil
+}
+
+func (m *mockDataRequestRepo) LoadUserData(userID uint) (*repository.UserData, error) {
+	user, err := m.users.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.UserData{User: *user}, nil
+}
+
+func (m *mockDataRequestRepo) EraseUser(userID uint) error {
+	m.erased = append(m.erased, userID)
+	return nil
+}
+
+type mockDataRequestPublisher struct {
+	topics []string
+	err    error
+}
+
+func (m *mockDataRequestPublisher) SendDataRequest(_ context.Context, topic string, _ userclient.DataRequestMessage) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.topics = append(m.topics, topic)
+	return nil
+}
+
+type mockSessionRevoker struct {
+	revoked []uint
+}
+
+func (m *mockSessionRevoker) RevokeAllSessions(_ context.Context, userID uint) error {
+	m.revoked = append(m.revoked, userID)
+	return nil
+}
+
+type dataRequestFixture struct {
+	svc       *DataRequestService
+	repo      *mockDataRequestRepo
+	users     *mockUserRepo
+	orgs      *mockOrganizationRepo
+	publisher *mockDataRequestPublisher
+	sessions  *mockSessionRevoker
+}
+
+func newDataRequestFixture(t *testing.T) *dataRequestFixture {
+	t.Helper()
+
+	f := &dataRequestFixture{
+		users: &mockUserRepo{users: map[uint]*models.User{
+			1: {ID: 1, Email: "anna@b.c", FirstName: "Анна", Role: models.RoleUser, IsActive: true},
+			2: {ID: 2, Email: "boss@b.c", Role: models.RoleOrganizer, IsActive: true},
+		}},
+		orgs:      &mockOrganizationRepo{orgs: map[uint]*models.Organization{}},
+		publisher: &mockDataRequestPublisher{},
+		sessions:  &mockSessionRevoker{},
+	}
+	f.repo = &mockDataRequestRepo{reqs: map[uint]*models.DataRequest{}, users: f.users}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	f.svc = NewDataRequestService(f.repo, f.users, f.orgs, f.sessions, f.publisher, nil,
+		[]string{"ticket-service", "notification-service"}, logger)
+	return f
+}
+
+func TestDataRequest_ExportCollectsAllServices(t *testing.T) {
+	f := newDataRequestFixture(t)
+	ctx := context.Background()
+
+	req, err := f.svc.RequestExport(ctx, 1)
+	if err != nil {
+		t.Fatalf("request export: %v", err)
+	}
+	if len(f.publisher.topics) != 1 || f.publisher.topics[0] != userclient.TopicExportRequested {
+		t.Fatalf("unexpected topics %v", f.publisher.topics)
+	}
+	if _, err := f.svc.Export(1, req.ID); !errors.Is(err, e.ErrDataExportNotReady) {
+		t.Fatalf("expected ErrDataExportNotReady, got %v", err)
+	}
+
+	// повторный запрос не создаёт новую выгрузку
+	again, err := f.svc.RequestExport(ctx, 1)
+	if err != nil || again.ID != req.ID {
+		t.Fatalf("expected pending request %d, got %+v, %v", req.ID, again, err)
+	}
+
+	if err := f.svc.Acknowledge(ctx, req.ID, "ticket-service", json.RawMessage(`{"tickets":[]}`)); err != nil {
+		t.Fatalf("ack: %v", err)
+	}
+	if err := f.svc.Acknowledge(ctx, req.ID, "event-service", nil); !errors.Is(err, e.ErrDataRequestNotFound) {
+		t.Fatalf("unexpected service must be rejected, got %v", err)
+	}
+	if err := f.svc.Acknowledge(ctx, req.ID, "notification-service", json.RawMessage(`{"notifications":[]}`)); err != nil {
+		t.Fatalf("ack: %v", err)
+	}
+
+	// чужую выгрузку не отдаём
+	if _, err := f.svc.Export(2, req.ID); !errors.Is(err, e.ErrDataRequestNotFound) {
+		t.Fatalf("expected ErrDataRequestNotFound, got %v", err)
+	}
+	done, err := f.svc.Export(1, req.ID)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteExportZip(&buf, done); err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("read zip: %v", err)
+	}
+	var names []string
+	for _, zf := range zr.File {
+		names = append(names, zf.Name)
+	}
+	sort.Strings(names)
+	want := []string{"manifest.json", "notification-service.json", "ticket-service.json", "user-service.json"}
+	if len(names) != len(want) {
+		t.Fatalf("unexpected files %v", names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("unexpected files %v", names)
+		}
+	}
+	if len(f.repo.erased) != 0 {
+		t.Fatalf("export must not erase data")
+	}
+}
+
+func TestDataRequest_DeletionConfirmIgnoresCase(t *testing.T) {
+	f := newDataRequestFixture(t)
+	// адрес, сохранённый до приведения к нижнему регистру
+	f.users.users[1].Email = "Anna.Smith@B.c"
+
+	if _, err := f.svc.RequestDeletion(context.Background(), 1, "anna.smith@b.C"); err != nil {
+		t.Fatalf("request deletion: %v", err)
+	}
+}
+
+func TestDataRequest_DeletionErasesAfterAllAcks(t *testing.T) {
+	f := newDataRequestFixture(t)
+	ctx := context.Background()
+
+	if _, err := f.svc.RequestDeletion(ctx, 1, "other@b.c"); !errors.Is(err, e.ErrDeletionNotConfirmed) {
+		t.Fatalf("expected ErrDeletionNotConfirmed, got %v", err)
+	}
+
+	req, err := f.svc.RequestDeletion(ctx, 1, " Anna@B.c ")
+	if err != nil {
+		t.Fatalf("request deletion: %v", err)
+	}
+	if f.users.users[1].IsActive {
+		t.Fatalf("account must be deactivated")
+	}
+	if len(f.sessions.revoked) != 1 {
+		t.Fatalf("sessions must be revoked")
+	}
+	if f.publisher.topics[0] != userclient.TopicDeletionRequested {
+		t.Fatalf("unexpected topic %s", f.publisher.topics[0])
+	}
+
+	if err := f.svc.Acknowledge(ctx, req.ID, "ticket-service", nil); err != nil {
+		t.Fatalf("ack: %v", err)
+	}
+	if len(f.repo.erased) != 0 {
+		t.Fatalf("account must be erased only after all services")
+	}
+	if err := f.svc.Acknowledge(ctx, req.ID, "notification-service", nil); err != nil {
+		t.Fatalf("ack: %v", err)
+	}
+	// повторная доставка подтверждения ничего не меняет
+	if err := f.svc.Acknowledge(ctx, req.ID, "notification-service", nil); err != nil {
+		t.Fatalf("repeated ack: %v", err)
+	}
+
+	if len(f.repo.erased) != 1 || f.repo.erased[0] != 1 {
+		t.Fatalf("expected user 1 erased once, got %v", f.repo.erased)
+	}
+	if f.repo.reqs[req.ID].Status != models.DataRequestCompleted {
+		t.Fatalf("request must be completed")
+	}
+}
+
+func TestDataRequest_DeletionGuards(t *testing.T) {
+	f := newDataRequestFixture(t)
+	ctx := context.Background()
+
+	orgSvc := NewOrganizationService(f.orgs, f.users, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if _, err := orgSvc.Create(ctx, 2, OrganizationInput{Name: "Club"}); err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	if _, err := f.svc.RequestDeletion(ctx, 2, "boss@b.c"); !errors.Is(err, e.ErrLastOrganizationOwner) {
+		t.Fatalf("expected ErrLastOrganizationOwner, got %v", err)
+	}
+
+	// без рассылки учётная запись остаётся активной
+	f.publisher.err = errors.New("kafka is down")
+	if _, err := f.svc.RequestDeletion(ctx, 1, "anna@b.c"); !errors.Is(err, e.ErrDataRequestNotDeliverable) {
+		t.Fatalf("expected ErrDataRequestNotDeliverable, got %v", err)
+	}
+	if !f.users.users[1].IsActive || len(f.sessions.revoked) != 0 {
+		t.Fatalf("account must stay active when the request was not delivered")
+	}
+
+	f.publisher.err = nil
+	if _, err := f.svc.RequestDeletion(ctx, 1, "anna@b.c"); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if len(f.repo.reqs) != 1 {
+		t.Fatalf("retry must reuse the pending request, got %d", len(f.repo.reqs))
+	}
+}
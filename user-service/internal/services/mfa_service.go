@@ -0,0 +1,231 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+	"user-service/internal/utils"
+)
+
+const recoveryCodeCount = 10
+
+// MFAService управляет TOTP-аутентификатором пользователя и кодами
+// восстановления.
+type MFAService struct {
+	userRepo repository.UserRepository
+	mfaRepo  repository.MFARepository
+	secrets  *utils.SecretBox
+	issuer   string
+	now      func() time.Time
+}
+
+func NewMFAService(
+	userRepo repository.UserRepository,
+	mfaRepo repository.MFARepository,
+	secrets *utils.SecretBox,
+	issuer string,
+) *MFAService {
+	return &MFAService{
+		userRepo: userRepo,
+		mfaRepo:  mfaRepo,
+		secrets:  secrets,
+		issuer:   issuer,
+		now:      time.Now,
+	}
+}
+
+// MFAEnrollment — данные для добавления аккаунта в приложение-аутентификатор.
+type MFAEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// Enroll выпускает новый секрет. Пока он не подтверждён кодом через
+// Confirm, вход по-прежнему работает без второго фактора.
+func (s *MFAService) Enroll(userID uint) (*MFAEnrollment, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, e.ErrUserNotFound
+	}
+
+	factor, err := s.mfaRepo.GetFactor(userID)
+	switch {
+	case err == nil && factor.Enabled():
+		return nil, e.ErrMFAAlreadyEnabled
+	case err != nil && !errors.Is(err, e.ErrMFANotEnrolled):
+		return nil, err
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.secrets.Seal(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepo.SaveFactor(&models.MFAFactor{
+		UserID: userID,
+		Secret: sealed,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &MFAEnrollment{
+		Secret: secret,
+		URI:    utils.TOTPURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm включает 2FA после первого верного кода и возвращает коды
+// восстановления. Они показываются пользователю один раз.
+func (s *MFAService) Confirm(userID uint, code string) ([]string, error) {
+	factor, err := s.mfaRepo.GetFactor(userID)
+	if err != nil {
+		return nil, err
+	}
+	if factor.Enabled() {
+		return nil, e.ErrMFAAlreadyEnabled
+	}
+
+	if err := s.checkTOTP(factor, code); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	factor.ConfirmedAt = &now
+	if err := s.mfaRepo.SaveFactor(factor); err != nil {
+		return nil, err
+	}
+
+	return s.issueRecoveryCodes(userID)
+}
+
+// Disable выключает 2FA; нужен действующий код или код восстановления.
+func (s *MFAService) Disable(userID uint, code string) error {
+	if err := s.VerifyCode(userID, code); err != nil {
+		return err
+	}
+	return s.mfaRepo.DeleteFactor(userID)
+}
+
+// RegenerateRecoveryCodes заменяет коды восстановления новыми.
+func (s *MFAService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	if err := s.VerifyCode(userID, code); err != nil {
+		return nil, err
+	}
+	return s.issueRecoveryCodes(userID)
+}
+
+// Enabled — включена ли у пользователя 2FA.
+func (s *MFAService) Enabled(userID uint) (bool, error) {
+	factor, err := s.mfaRepo.GetFactor(userID)
+	if err != nil {
+		if errors.Is(err, e.ErrMFANotEnrolled) {
+			return false, nil
+		}
+		return false, err
+	}
+	return factor.Enabled(), nil
+}
+
+// VerifyCode принимает TOTP-код или одноразовый код восстановления.
+func (s *MFAService) VerifyCode(userID uint, code string) error {
+	factor, err := s.mfaRepo.GetFactor(userID)
+	if err != nil {
+		return err
+	}
+	if !factor.Enabled() {
+		return e.ErrMFANotEnrolled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) != 6 {
+		return s.useRecoveryCode(userID, code)
+	}
+	return s.checkTOTP(factor, code)
+}
+
+func (s *MFAService) checkTOTP(factor *models.MFAFactor, code string) error {
+	secret, err := s.secrets.Open(factor.Secret)
+	if err != nil {
+		return err
+	}
+
+	step, ok := utils.ValidateTOTP(secret, strings.TrimSpace(code), s.now(), factor.LastUsedStep)
+	if !ok {
+		return e.ErrInvalidMFACode
+	}
+
+	// factor мог устареть: параллельная проверка того же кода успеет раньше,
+	// поэтому интервал занимается условным UPDATE
+	used, err := s.mfaRepo.UseTOTPStep(factor.UserID, step)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return e.ErrInvalidMFACode
+	}
+	factor.LastUsedStep = step
+	return nil
+}
+
+func (s *MFAService) useRecoveryCode(userID uint, code string) error {
+	ok, err := s.mfaRepo.UseRecoveryCode(userID, hashToken(normalizeRecoveryCode(code)), s.now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return e.ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (s *MFAService) issueRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// алфавит без похожих символов (0/O, 1/I/L)
+const recoveryAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// newRecoveryCode — код вида XXXXX-XXXXX.
+func newRecoveryCode() (string, error) {
+	var b strings.Builder
+	for i := 0; i < 10; i++ {
+		if i == 5 {
+			b.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(recoveryAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/utils"
+)
+
+type mockMFARepo struct {
+	factor *models.MFAFactor
+	codes  []models.RecoveryCode
+}
+
+func (m *mockMFARepo) GetFactor(userID uint) (*models.MFAFactor, error) {
+	if m.factor == nil || m.factor.UserID != userID {
+		return nil, e.ErrMFANotEnrolled
+	}
+	cp := *m.factor
+	return &cp, nil
+}
+
+func (m *mockMFARepo) SaveFactor(f *models.MFAFactor) error {
+	cp := *f
+	m.factor = &cp
+	return nil
+}
+
+func (m *mockMFARepo) DeleteFactor(uint) error {
+	m.factor, m.codes = nil, nil
+	return nil
+}
+
+func (m *mockMFARepo) ReplaceRecoveryCodes(userID uint, hashes []string) error {
+	m.codes = nil
+	for _, h := range hashes {
+		m.codes = append(m.codes, models.RecoveryCode{UserID: userID, CodeHash: h})
+	}
+	return nil
+}
+
+func (m *mockMFARepo) UseRecoveryCode(userID uint, hash string, at time.Time) (bool, error) {
+	for i := range m.codes {
+		c := &m.codes[i]
+		if c.UserID == userID && c.CodeHash == hash && c.UsedAt == nil {
+			c.UsedAt = &at
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockMFARepo) UseTOTPStep(userID uint, step int64) (bool, error) {
+	if m.factor == nil || m.factor.UserID != userID || m.factor.LastUsedStep >= step {
+		return false, nil
+	}
+	m.factor.LastUsedStep = step
+	return true, nil
+}
+
+// enableMFA включает 2FA пользователю 1 и возвращает секрет и коды восстановления.
+func enableMFA(t *testing.T, svc *AuthService) (string, []string) {
+	t.Helper()
+
+	enrollment, err := svc.mfa.Enroll(1)
+	if err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	code, _ := utils.TOTPCode(enrollment.Secret, time.Now().Add(-30*time.Second))
+	codes, err := svc.mfa.Confirm(1, code)
+	if err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	return enrollment.Secret, codes
+}
+
+func TestMFA_LoginRequiresSecondFactor(t *testing.T) {
+	svc, _, _ := newAuthService(t)
+	secret, _ := enableMFA(t, svc)
+
+	res, err := svc.Login(context.Background(), "a@b.c", "secret", SessionMeta{})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if !res.MFARequired() || res.AccessToken != "" || res.RefreshToken != "" {
+		t.Fatalf("password step must not issue tokens: %+v", res)
+	}
+
+	if _, err := svc.VerifyMFA(res.MFAToken, "000000", SessionMeta{}); !errors.Is(err, e.ErrInvalidMFACode) {
+		t.Fatalf("expected ErrInvalidMFACode, got %v", err)
+	}
+
+	code, _ := utils.TOTPCode(secret, time.Now())
+	verified, err := svc.VerifyMFA(res.MFAToken, code, SessionMeta{})
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	claims, err := svc.ParseAccessToken(verified.AccessToken)
+	if err != nil || !claims.MFA {
+		t.Fatalf("access token must carry mfa claim: %+v %v", claims, err)
+	}
+
+	if _, err := svc.VerifyMFA(res.MFAToken, code, SessionMeta{}); !errors.Is(err, e.ErrInvalidMFAChallenge) {
+		t.Fatalf("challenge must be single-use, got %v", err)
+	}
+}
+
+func TestMFA_ChallengeLimitsWrongCodes(t *testing.T) {
+	svc, _, _ := newAuthService(t)
+	secret, _ := enableMFA(t, svc)
+
+	res, _ := svc.Login(context.Background(), "a@b.c", "secret", SessionMeta{})
+	for i := 0; i < mfaChallengeAttempts; i++ {
+		if _, err := svc.VerifyMFA(res.MFAToken, "000000", SessionMeta{}); !errors.Is(err, e.ErrInvalidMFACode) {
+			t.Fatalf("attempt %d: expected ErrInvalidMFACode, got %v", i+1, err)
+		}
+	}
+
+	// после исчерпания попыток не проходит и верный код
+	code, _ := utils.TOTPCode(secret, time.Now())
+	if _, err := svc.VerifyMFA(res.MFAToken, code, SessionMeta{}); !errors.Is(err, e.ErrInvalidMFAChallenge) {
+		t.Fatalf("expected ErrInvalidMFAChallenge after %d wrong codes, got %v", mfaChallengeAttempts, err)
+	}
+}
+
+func TestMFA_RefreshKeepsMFAClaim(t *testing.T) {
+	svc, _, _ := newAuthService(t)
+	secret, _ := enableMFA(t, svc)
+
+	res, _ := svc.Login(context.Background(), "a@b.c", "secret", SessionMeta{})
+	code, _ := utils.TOTPCode(secret, time.Now())
+	verified, err := svc.VerifyMFA(res.MFAToken, code, SessionMeta{})
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	access, _, err := svc.RefreshTokens(context.Background(), verified.RefreshToken, SessionMeta{})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if claims, _ := svc.ParseAccessToken(access); claims == nil || !claims.MFA {
+		t.Fatalf("refreshed access token must keep mfa claim")
+	}
+}
+
+func TestMFA_RecoveryCodeIsSingleUse(t *testing.T) {
+	svc, _, _ := newAuthService(t)
+	_, codes := enableMFA(t, svc)
+
+	if len(codes) != recoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %d", recoveryCodeCount, len(codes))
+	}
+
+	res, _ := svc.Login(context.Background(), "a@b.c", "secret", SessionMeta{})
+	if _, err := svc.VerifyMFA(res.MFAToken, codes[0], SessionMeta{}); err != nil {
+		t.Fatalf("recovery code must be accepted: %v", err)
+	}
+
+	res, _ = svc.Login(context.Background(), "a@b.c", "secret", SessionMeta{})
+	if _, err := svc.VerifyMFA(res.MFAToken, codes[0], SessionMeta{}); !errors.Is(err, e.ErrInvalidMFACode) {
+		t.Fatalf("used recovery code must be rejected, got %v", err)
+	}
+}
+
+func TestMFA_UnconfirmedEnrollmentDoesNotAffectLogin(t *testing.T) {
+	svc, _, _ := newAuthService(t)
+
+	if _, err := svc.mfa.Enroll(1); err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+
+	res, err := svc.Login(context.Background(), "a@b.c", "secret", SessionMeta{})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if res.MFARequired() {
+		t.Fatalf("mfa must not be required before confirmation")
+	}
+}
+
+func TestMFA_ParallelCodeReplayRejected(t *testing.T) {
+	svc, _, _ := newAuthService(t)
+	secret, _ := enableMFA(t, svc)
+
+	// обе проверки прочитали фактор до того, как какая-то из них сохранила интервал
+	first, _ := svc.mfa.mfaRepo.GetFactor(1)
+	second, _ := svc.mfa.mfaRepo.GetFactor(1)
+	code, _ := utils.TOTPCode(secret, time.Now())
+
+	if err := svc.mfa.checkTOTP(first, code); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := svc.mfa.checkTOTP(second, code); !errors.Is(err, e.ErrInvalidMFACode) {
+		t.Fatalf("expected replay to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/oidc"
+	"user-service/internal/repository"
+)
+
+// oidcStateTTL — сколько пользователь может провести на странице провайдера.
+const oidcStateTTL = 10 * time.Minute
+
+// OIDCService — вход через внешних OpenID Connect провайдеров.
+type OIDCService struct {
+	providers   oidc.Registry
+	states      repository.OIDCStateRepository
+	userRepo    repository.UserRepository
+	identities  repository.UserIdentityRepository
+	authService *AuthService
+	events      *UserEvents
+	now         func() time.Time
+}
+
+func NewOIDCService(
+	providers oidc.Registry,
+	states repository.OIDCStateRepository,
+	userRepo repository.UserRepository,
+	identities repository.UserIdentityRepository,
+	authService *AuthService,
+	events *UserEvents,
+) *OIDCService {
+	return &OIDCService{
+		providers:   providers,
+		states:      states,
+		userRepo:    userRepo,
+		identities:  identities,
+		authService: authService,
+		events:      events,
+		now:         time.Now,
+	}
+}
+
+// Start готовит вход и возвращает адрес страницы провайдера и state,
+// который нужно привязать к браузеру (cookie) и сверить в Callback.
+func (s *OIDCService) Start(ctx context.Context, providerName string) (string, string, error) {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return "", "", e.ErrUnknownOIDCProvider
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	if err := s.states.Save(ctx, state, models.OIDCLoginState{
+		Provider:     provider.Name(),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+	}, oidcStateTTL); err != nil {
+		return "", "", err
+	}
+
+	authURL, err := provider.AuthURL(state, nonce, verifier)
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+// Callback завершает вход по code от провайдера. browserState — state из
+// cookie браузера: вход, начатый в другом браузере, не принимается.
+// Внешний аккаунт привязывается к пользователю с тем же подтверждённым
+// адресом почты, а если такого нет — создаётся новый пользователь без пароля.
+func (s *OIDCService) Callback(ctx context.Context, providerName, state, browserState, code string, meta SessionMeta) (*LoginResult, error) {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return nil, e.ErrUnknownOIDCProvider
+	}
+
+	if state == "" || code == "" || subtle.ConstantTimeCompare([]byte(state), []byte(browserState)) != 1 {
+		return nil, e.ErrInvalidOIDCState
+	}
+
+	pending, err := s.states.Take(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	// state, выданный для другого провайдера, не принимаем
+	if pending.Provider != provider.Name() {
+		return nil, e.ErrInvalidOIDCState
+	}
+
+	claims, err := provider.Exchange(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.resolveUser(ctx, provider.Name(), claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authService.LoginUser(user, meta)
+}
+
+func (s *OIDCService) resolveUser(ctx context.Context, provider string, claims *oidc.Claims) (*models.User, error) {
+	identity, err := s.identities.GetByProviderSubject(provider, claims.Subject)
+	if err == nil {
+		return s.userRepo.GetByID(identity.UserID)
+	}
+	if !errors.Is(err, e.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	// без подтверждённой провайдером почты нельзя ни найти
+	// существующий аккаунт, ни завести новый
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, e.ErrOIDCEmailMissing
+	}
+
+	identity = &models.UserIdentity{
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+	now := s.now()
+
+	user, err := s.userRepo.GetByEmail(claims.Email)
+	if err != nil && !errors.Is(err, e.ErrUserNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		// неподтверждённый адрес мог зарегистрировать кто угодно: привязка
+		// отдала бы аккаунт вместе с чужим паролем владельцу почты
+		if user.EmailVerifiedAt == nil {
+			return nil, e.ErrOIDCEmailUnverified
+		}
+		identity.UserID = user.ID
+		if err := s.identities.Create(identity); err != nil {
+			return nil, fmt.Errorf("link identity: %w", err)
+		}
+		return user, nil
+	}
+
+	user = &models.User{
+		Email:           claims.Email,
+		FirstName:       claims.GivenName,
+		LastName:        claims.FamilyName,
+		Role:            models.RoleUser,
+		IsActive:        true,
+		EmailVerifiedAt: &now,
+	}
+	if err := s.identities.CreateWithUser(user, identity); err != nil {
+		return nil, fmt.Errorf("create user from identity: %w", err)
+	}
+	s.events.Registered(ctx, user)
+	return user, nil
+}
+
+func randomString() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/oidc"
+	"user-service/internal/repository"
+)
+
+// mockIssuer — минимальный OIDC-провайдер: discovery, JWKS и token
+// endpoint с проверкой PKCE.
+type mockIssuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]mockGrant
+}
+
+type mockGrant struct {
+	challenge string
+	nonce     string
+	claims    jwt.MapClaims
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &mockIssuer{key: key, codes: map[string]mockGrant{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                m.URL,
+			"authorization_endpoint":                m.URL + "/authorize",
+			"token_endpoint":                        m.URL + "/token",
+			"jwks_uri":                              m.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+
+		m.mu.Lock()
+		grant, ok := m.codes[r.PostForm.Get("code")]
+		delete(m.codes, r.PostForm.Get("code"))
+		m.mu.Unlock()
+
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		claims := jwt.MapClaims{
+			"iss":   m.URL,
+			"aud":   "client",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"iat":   time.Now().Unix(),
+			"nonce": grant.nonce,
+		}
+		for k, v := range grant.claims {
+			claims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test"
+		idToken, _ := token.SignedString(key)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "at",
+			"token_type":   "Bearer",
+			"expires_in":   60,
+			"id_token":     idToken,
+		})
+	})
+
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+// authorize имитирует вход пользователя на странице провайдера
+// и возвращает state и code для callback.
+func (m *mockIssuer) authorize(t *testing.T, authURL string, claims jwt.MapClaims) (string, string) {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("nonce") == "" {
+		t.Fatalf("auth url must carry PKCE challenge and nonce: %s", authURL)
+	}
+
+	code := "code-" + q.Get("state")
+	m.mu.Lock()
+	m.codes[code] = mockGrant{challenge: q.Get("code_challenge"), nonce: q.Get("nonce"), claims: claims}
+	m.mu.Unlock()
+
+	return q.Get("state"), code
+}
+
+type mockIdentityRepo struct {
+	users      *mockUserRepo
+	identities []models.UserIdentity
+}
+
+func (m *mockIdentityRepo) GetByProviderSubject(provider, subject string) (*models.UserIdentity, error) {
+	for _, i := range m.identities {
+		if i.Provider == provider && i.Subject == subject {
+			return &i, nil
+		}
+	}
+	return nil, e.ErrIdentityNotFound
+}
+
+func (m *mockIdentityRepo) Create(i *models.UserIdentity) error {
+	m.identities = append(m.identities, *i)
+	return nil
+}
+
+func (m *mockIdentityRepo) CreateWithUser(u *models.User, i *models.UserIdentity) error {
+	if err := m.users.Create(u); err != nil {
+		return err
+	}
+	i.UserID = u.ID
+	return m.Create(i)
+}
+
+func newOIDCService(t *testing.T) (*OIDCService, *mockIssuer, *mockIdentityRepo) {
+	t.Helper()
+
+	issuer := newMockIssuer(t)
+	auth, _, _ := newAuthService(t)
+	users := auth.userRepo.(*mockUserRepo)
+	identities := &mockIdentityRepo{users: users}
+
+	providers := oidc.NewRegistry([]oidc.ProviderConfig{{
+		Name:        "mock",
+		Issuer:      issuer.URL,
+		ClientID:    "client",
+		RedirectURL: "http://app/callback",
+	}})
+
+	svc := NewOIDCService(providers, repository.NewMemoryOIDCStateRepository(), users, identities, auth, nil)
+	return svc, issuer, identities
+}
+
+func TestOIDC_LinksExistingUserByVerifiedEmail(t *testing.T) {
+	svc, issuer, identities := newOIDCService(t)
+	ctx := context.Background()
+	verified := time.Now()
+	svc.userRepo.(*mockUserRepo).users[1].EmailVerifiedAt = &verified
+
+	authURL, _, err := svc.Start(ctx, "mock")
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	state, code := issuer.authorize(t, authURL, jwt.MapClaims{
+		"sub": "ext-1", "email": "A@b.c", "email_verified": true,
+	})
+
+	res, err := svc.Callback(ctx, "mock", state, state, code, SessionMeta{})
+	if err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+	if res.User.ID != 1 || res.AccessToken == "" {
+		t.Fatalf("expected tokens for existing user 1, got %+v", res)
+	}
+	if len(identities.identities) != 1 || identities.identities[0].UserID != 1 {
+		t.Fatalf("identity must be linked to user 1: %+v", identities.identities)
+	}
+
+	// state одноразовый
+	if _, err := svc.Callback(ctx, "mock", state, state, code, SessionMeta{}); !errors.Is(err, e.ErrInvalidOIDCState) {
+		t.Fatalf("expected ErrInvalidOIDCState on replay, got %v", err)
+	}
+}
+
+func TestOIDC_CreatesUserForNewIdentity(t *testing.T) {
+	svc, issuer, _ := newOIDCService(t)
+	ctx := context.Background()
+
+	authURL, _, _ := svc.Start(ctx, "mock")
+	state, code := issuer.authorize(t, authURL, jwt.MapClaims{
+		"sub": "ext-2", "email": "new@b.c", "email_verified": "true", "given_name": "Ann",
+	})
+
+	res, err := svc.Callback(ctx, "mock", state, state, code, SessionMeta{})
+	if err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+	if res.User.Email != "new@b.c" || res.User.FirstName != "Ann" || res.User.PasswordHash != "" {
+		t.Fatalf("unexpected user: %+v", res.User)
+	}
+
+	// повторный вход находит пользователя по identity
+	authURL, _, _ = svc.Start(ctx, "mock")
+	state, code = issuer.authorize(t, authURL, jwt.MapClaims{"sub": "ext-2"})
+	again, err := svc.Callback(ctx, "mock", state, state, code, SessionMeta{})
+	if err != nil {
+		t.Fatalf("second callback: %v", err)
+	}
+	if again.User.ID != res.User.ID {
+		t.Fatalf("expected same user, got %d and %d", res.User.ID, again.User.ID)
+	}
+}
+
+func TestOIDC_RejectsUnverifiedEmail(t *testing.T) {
+	svc, issuer, identities := newOIDCService(t)
+	ctx := context.Background()
+
+	authURL, _, _ := svc.Start(ctx, "mock")
+	state, code := issuer.authorize(t, authURL, jwt.MapClaims{
+		"sub": "ext-3", "email": "a@b.c", "email_verified": false,
+	})
+
+	if _, err := svc.Callback(ctx, "mock", state, state, code, SessionMeta{}); !errors.Is(err, e.ErrOIDCEmailMissing) {
+		t.Fatalf("expected ErrOIDCEmailMissing, got %v", err)
+	}
+	if len(identities.identities) != 0 {
+		t.Fatalf("unverified email must not be linked")
+	}
+}
+
+func TestOIDC_RejectsUnverifiedLocalAccount(t *testing.T) {
+	svc, issuer, identities := newOIDCService(t)
+	ctx := context.Background()
+
+	// a@b.c зарегистрирован с паролем, но почта не подтверждена
+	authURL, _, _ := svc.Start(ctx, "mock")
+	state, code := issuer.authorize(t, authURL, jwt.MapClaims{
+		"sub": "ext-4", "email": "a@b.c", "email_verified": true,
+	})
+
+	if _, err := svc.Callback(ctx, "mock", state, state, code, SessionMeta{}); !errors.Is(err, e.ErrOIDCEmailUnverified) {
+		t.Fatalf("expected ErrOIDCEmailUnverified, got %v", err)
+	}
+	if len(identities.identities) != 0 {
+		t.Fatalf("unverified account must not be linked")
+	}
+}
+
+func TestOIDC_RejectsStateFromAnotherBrowser(t *testing.T) {
+	svc, issuer, _ := newOIDCService(t)
+	ctx := context.Background()
+
+	authURL, _, _ := svc.Start(ctx, "mock")
+	state, code := issuer.authorize(t, authURL, jwt.MapClaims{
+		"sub": "ext-5", "email": "new@b.c", "email_verified": true,
+	})
+
+	for _, browserState := range []string{"", "other"} {
+		if _, err := svc.Callback(ctx, "mock", state, browserState, code, SessionMeta{}); !errors.Is(err, e.ErrInvalidOIDCState) {
+			t.Fatalf("expected ErrInvalidOIDCState for cookie %q, got %v", browserState, err)
+		}
+	}
+}
+
+func TestOIDC_UnknownProvider(t *testing.T) {
+	svc, _, _ := newOIDCService(t)
+
+	if _, _, err := svc.Start(context.Background(), "nope"); !errors.Is(err, e.ErrUnknownOIDCProvider) {
+		t.Fatalf("expected ErrUnknownOIDCProvider, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+)
+
+// issueOneTimeToken выпускает токен для ссылки из письма. Прежние
+// неиспользованные токены с тем же назначением гасятся.
+func issueOneTimeToken(
+	tokens repository.OneTimeTokenRepository,
+	userID uint,
+	purpose models.TokenPurpose,
+	now time.Time,
+	ttl time.Duration,
+) (string, error) {
+	if err := tokens.InvalidateForUser(userID, purpose, now); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	if err := tokens.Create(&models.OneTimeToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// consumeOneTimeToken проверяет токен и помечает его использованным.
+func consumeOneTimeToken(
+	tokens repository.OneTimeTokenRepository,
+	purpose models.TokenPurpose,
+	token string,
+	now time.Time,
+) (*models.OneTimeToken, error) {
+	if token == "" {
+		return nil, e.ErrInvalidOneTimeToken
+	}
+
+	record, err := tokens.GetByHash(purpose, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if !record.Usable(now) {
+		return nil, e.ErrInvalidOneTimeToken
+	}
+
+	if err := tokens.Consume(record.ID, now); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+)
+
+// OrganizationInput — редактируемые поля организации.
+type OrganizationInput struct {
+	Name        string
+	Description string
+}
+
+// OrganizationService — организации и их участники. Создать организацию
+// может организатор, он становится её владельцем. Участниками управляют
+// только владельцы; права участника на мероприятия другие сервисы
+// получают через внутренний API (Membership).
+type OrganizationService struct {
+	orgs     repository.OrganizationRepository
+	userRepo repository.UserRepository
+	logger   *slog.Logger
+}
+
+func NewOrganizationService(
+	orgs repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	logger *slog.Logger,
+) *OrganizationService {
+	return &OrganizationService{
+		orgs:     orgs,
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+func (s *OrganizationService) Create(ctx context.Context, userID uint, in OrganizationInput) (*models.Organization, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != models.RoleOrganizer && user.Role != models.RoleAdmin {
+		return nil, e.ErrNotOrganizer
+	}
+
+	org := &models.Organization{
+		Name:        strings.TrimSpace(in.Name),
+		Description: strings.TrimSpace(in.Description),
+		CreatedBy:   userID,
+	}
+	owner := &models.OrganizationMember{
+		UserID: userID,
+		Role:   models.OrgRoleOwner,
+	}
+	if err := s.orgs.Create(org, owner); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "organization created", "organization_id", org.ID, "user_id", userID)
+	return org, nil
+}
+
+// Get возвращает организацию и роль в ней userID. Для посторонних
+// организации как будто нет.
+func (s *OrganizationService) Get(userID, organizationID uint) (*models.Organization, models.OrganizationRole, error) {
+	member, err := s.member(organizationID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	org, err := s.orgs.GetByID(organizationID)
+	if err != nil {
+		return nil, "", err
+	}
+	return org, member.Role, nil
+}
+
+func (s *OrganizationService) ListMine(userID uint) ([]models.OrganizationMember, error) {
+	return s.orgs.ListByUser(userID)
+}
+
+func (s *OrganizationService) Update(ctx context.Context, userID, organizationID uint, in OrganizationInput) (*models.Organization, error) {
+	if _, err := s.requireOwner(organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgs.GetByID(organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if name := strings.TrimSpace(in.Name); name != "" {
+		org.Name = name
+	}
+	org.Description = strings.TrimSpace(in.Description)
+
+	if err := s.orgs.Update(org); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "organization updated", "organization_id", org.ID, "user_id", userID)
+	return org, nil
+}
+
+func (s *OrganizationService) Members(userID, organizationID uint) ([]models.OrganizationMember, error) {
+	if _, err := s.member(organizationID, userID); err != nil {
+		return nil, err
+	}
+	return s.orgs.ListMembers(organizationID)
+}
+
+// AddMember добавляет зарегистрированного пользователя по email.
+func (s *OrganizationService) AddMember(
+	ctx context.Context,
+	actorID, organizationID uint,
+	email string,
+	role models.OrganizationRole,
+) (*models.OrganizationMember, error) {
+	if !role.Valid() {
+		return nil, e.ErrInvalidOrganizationRole
+	}
+	if _, err := s.requireOwner(organizationID, actorID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(normalizeEmail(email))
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, e.ErrUserInactive
+	}
+
+	if _, err := s.orgs.GetMember(organizationID, user.ID); err == nil {
+		return nil, e.ErrAlreadyOrganizationMember
+	} else if !errors.Is(err, e.ErrNotOrganizationMember) {
+		return nil, err
+	}
+
+	member := &models.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Role:           role,
+	}
+	if err := s.orgs.AddMember(member); err != nil {
+		return nil, err
+	}
+	member.User = user
+
+	s.logger.InfoContext(ctx, "organization member added",
+		"organization_id", organizationID, "user_id", user.ID, "role", role, "actor_id", actorID)
+	return member, nil
+}
+
+func (s *OrganizationService) ChangeMemberRole(
+	ctx context.Context,
+	actorID, organizationID, userID uint,
+	role models.OrganizationRole,
+) error {
+	if !role.Valid() {
+		return e.ErrInvalidOrganizationRole
+	}
+	if _, err := s.requireOwner(organizationID, actorID); err != nil {
+		return err
+	}
+
+	if err := s.orgs.UpdateMemberRole(organizationID, userID, role); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "organization member role changed",
+		"organization_id", organizationID, "user_id", userID, "role", role, "actor_id", actorID)
+	return nil
+}
+
+// RemoveMember исключает участника. Без прав владельца можно только выйти самому.
+func (s *OrganizationService) RemoveMember(ctx context.Context, actorID, organizationID, userID uint) error {
+	if actorID == userID {
+		if _, err := s.member(organizationID, actorID); err != nil {
+			return err
+		}
+	} else if _, err := s.requireOwner(organizationID, actorID); err != nil {
+		return err
+	}
+
+	if err := s.orgs.RemoveMember(organizationID, userID); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "organization member removed",
+		"organization_id", organizationID, "user_id", userID, "actor_id", actorID)
+	return nil
+}
+
+// Membership — роль пользователя в организации для других сервисов.
+func (s *OrganizationService) Membership(organizationID, userID uint) (*models.OrganizationMember, error) {
+	return s.orgs.GetMember(organizationID, userID)
+}
+
+// member проверяет членство; посторонним отвечаем, что организации нет.
+func (s *OrganizationService) member(organizationID, userID uint) (*models.OrganizationMember, error) {
+	m, err := s.orgs.GetMember(organizationID, userID)
+	if errors.Is(err, e.ErrNotOrganizationMember) {
+		return nil, e.ErrOrganizationNotFound
+	}
+	return m, err
+}
+
+func (s *OrganizationService) requireOwner(organizationID, userID uint) (*models.OrganizationMember, error) {
+	m, err := s.member(organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if m.Role != models.OrgRoleOwner {
+		return nil, e.ErrInsufficientOrganizationRole
+	}
+	return m, nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+)
+
+type mockOrganizationRepo struct {
+	orgs    map[uint]*models.Organization
+	members []*models.OrganizationMember
+}
+
+func (m *mockOrganizationRepo) Create(org *models.Organization, owner *models.OrganizationMember) error {
+	org.ID = uint(len(m.orgs) + 1)
+	m.orgs[org.ID] = org
+	owner.OrganizationID = org.ID
+	m.members = append(m.members, owner)
+	return nil
+}
+
+func (m *mockOrganizationRepo) GetByID(id uint) (*models.Organization, error) {
+	if org, ok := m.orgs[id]; ok {
+		return org, nil
+	}
+	return nil, e.ErrOrganizationNotFound
+}
+
+func (m *mockOrganizationRepo) Update(org *models.Organization) error {
+	m.orgs[org.ID] = org
+	return nil
+}
+
+func (m *mockOrganizationRepo) ListByUser(userID uint) ([]models.OrganizationMember, error) {
+	var out []models.OrganizationMember
+	for _, mem := range m.members {
+		if mem.UserID == userID {
+			cp := *mem
+			cp.Organization = m.orgs[mem.OrganizationID]
+			out = append(out, cp)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockOrganizationRepo) GetMember(organizationID, userID uint) (*models.OrganizationMember, error) {
+	for _, mem := range m.members {
+		if mem.OrganizationID == organizationID && mem.UserID == userID {
+			cp := *mem
+			return &cp, nil
+		}
+	}
+	return nil, e.ErrNotOrganizationMember
+}
+
+func (m *mockOrganizationRepo) ListMembers(organizationID uint) ([]models.OrganizationMember, error) {
+	var out []models.OrganizationMember
+	for _, mem := range m.members {
+		if mem.OrganizationID == organizationID {
+			out = append(out, *mem)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockOrganizationRepo) AddMember(member *models.OrganizationMember) error {
+	m.members = append(m.members, member)
+	return nil
+}
+
+func (m *mockOrganizationRepo) UpdateMemberRole(organizationID, userID uint, role models.OrganizationRole) error {
+	idx, err := m.find(organizationID, userID)
+	if err != nil {
+		return err
+	}
+	if m.members[idx].Role == models.OrgRoleOwner && role != models.OrgRoleOwner && m.owners(organizationID) < 2 {
+		return e.ErrLastOrganizationOwner
+	}
+	m.members[idx].Role = role
+	return nil
+}
+
+func (m *mockOrganizationRepo) RemoveMember(organizationID, userID uint) error {
+	idx, err := m.find(organizationID, userID)
+	if err != nil {
+		return err
+	}
+	if m.members[idx].Role == models.OrgRoleOwner && m.owners(organizationID) < 2 {
+		return e.ErrLastOrganizationOwner
+	}
+	m.members = append(m.members[:idx], m.members[idx+1:]...)
+	return nil
+}
+
+func (m *mockOrganizationRepo) find(organizationID, userID uint) (int, error) {
+	for i, mem := range m.members {
+		if mem.OrganizationID == organizationID && mem.UserID == userID {
+			return i, nil
+		}
+	}
+	return 0, e.ErrNotOrganizationMember
+}
+
+func (m *mockOrganizationRepo) owners(organizationID uint) int {
+	n := 0
+	for _, mem := range m.members {
+		if mem.OrganizationID == organizationID && mem.Role == models.OrgRoleOwner {
+			n++
+		}
+	}
+	return n
+}
+
+// newOrganizationService: 1 — организатор, 2 — обычный пользователь,
+// 3 — ещё один пользователь.
+func newOrganizationService(t *testing.T) (*OrganizationService, *mockOrganizationRepo) {
+	t.Helper()
+
+	users := &mockUserRepo{users: map[uint]*models.User{
+		1: {ID: 1, Email: "owner@b.c", Role: models.RoleOrganizer, IsActive: true},
+		2: {ID: 2, Email: "staff@b.c", Role: models.RoleUser, IsActive: true},
+		3: {ID: 3, Email: "other@b.c", Role: models.RoleUser, IsActive: true},
+	}}
+	orgs := &mockOrganizationRepo{orgs: map[uint]*models.Organization{}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewOrganizationService(orgs, users, logger), orgs
+}
+
+func TestOrganization_CreateRequiresOrganizer(t *testing.T) {
+	svc, _ := newOrganizationService(t)
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, 2, OrganizationInput{Name: "Club"}); !errors.Is(err, e.ErrNotOrganizer) {
+		t.Fatalf("expected ErrNotOrganizer, got %v", err)
+	}
+
+	org, err := svc.Create(ctx, 1, OrganizationInput{Name: "  Club  "})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if org.Name != "Club" {
+		t.Fatalf("name must be trimmed: %q", org.Name)
+	}
+
+	m, err := svc.Membership(org.ID, 1)
+	if err != nil || m.Role != models.OrgRoleOwner {
+		t.Fatalf("creator must be owner: %+v, %v", m, err)
+	}
+}
+
+func TestOrganization_MembersManagedByOwner(t *testing.T) {
+	svc, _ := newOrganizationService(t)
+	ctx := context.Background()
+
+	org, err := svc.Create(ctx, 1, OrganizationInput{Name: "Club"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if _, err := svc.AddMember(ctx, 1, org.ID, "Staff@B.c", models.OrgRoleCheckin); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	if _, err := svc.AddMember(ctx, 1, org.ID, "staff@b.c", models.OrgRoleManager); !errors.Is(err, e.ErrAlreadyOrganizationMember) {
+		t.Fatalf("expected ErrAlreadyOrganizationMember, got %v", err)
+	}
+	if _, err := svc.AddMember(ctx, 1, org.ID, "other@b.c", "janitor"); !errors.Is(err, e.ErrInvalidOrganizationRole) {
+		t.Fatalf("expected ErrInvalidOrganizationRole, got %v", err)
+	}
+
+	// сотрудник на входе не управляет составом
+	if _, err := svc.AddMember(ctx, 2, org.ID, "other@b.c", models.OrgRoleManager); !errors.Is(err, e.ErrInsufficientOrganizationRole) {
+		t.Fatalf("expected ErrInsufficientOrganizationRole, got %v", err)
+	}
+	// посторонний не видит организацию
+	if _, _, err := svc.Get(3, org.ID); !errors.Is(err, e.ErrOrganizationNotFound) {
+		t.Fatalf("expected ErrOrganizationNotFound, got %v", err)
+	}
+
+	if err := svc.ChangeMemberRole(ctx, 1, org.ID, 2, models.OrgRoleManager); err != nil {
+		t.Fatalf("change role: %v", err)
+	}
+	if _, role, err := svc.Get(2, org.ID); err != nil || role != models.OrgRoleManager {
+		t.Fatalf("expected manager, got %s, %v", role, err)
+	}
+
+	if err := svc.RemoveMember(ctx, 2, org.ID, 2); err != nil {
+		t.Fatalf("member must be able to leave: %v", err)
+	}
+	if _, err := svc.Membership(org.ID, 2); !errors.Is(err, e.ErrNotOrganizationMember) {
+		t.Fatalf("expected ErrNotOrganizationMember, got %v", err)
+	}
+}
+
+func TestOrganization_KeepsLastOwner(t *testing.T) {
+	svc, _ := newOrganizationService(t)
+	ctx := context.Background()
+
+	org, err := svc.Create(ctx, 1, OrganizationInput{Name: "Club"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if err := svc.ChangeMemberRole(ctx, 1, org.ID, 1, models.OrgRoleManager); !errors.Is(err, e.ErrLastOrganizationOwner) {
+		t.Fatalf("expected ErrLastOrganizationOwner, got %v", err)
+	}
+	if err := svc.RemoveMember(ctx, 1, org.ID, 1); !errors.Is(err, e.ErrLastOrganizationOwner) {
+		t.Fatalf("expected ErrLastOrganizationOwner, got %v", err)
+	}
+
+	if _, err := svc.AddMember(ctx, 1, org.ID, "staff@b.c", models.OrgRoleOwner); err != nil {
+		t.Fatalf("add owner: %v", err)
+	}
+	if err := svc.RemoveMember(ctx, 1, org.ID, 1); err != nil {
+		t.Fatalf("owner must be able to leave when another owner exists: %v", err)
+	}
+}
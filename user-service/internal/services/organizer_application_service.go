@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/kafka"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+)
+
+// ApplicationInput — данные заявки, которые заполняет пользователь.
+type ApplicationInput struct {
+	OrganizationName string
+	ContactName      string
+	ContactEmail     string
+	ContactPhone     string
+	Website          string
+	Description      string
+}
+
+// OrganizerApplicationService — заявки на роль организатора. Пользователь
+// подаёт заявку, администратор одобряет или отклоняет её с причиной.
+// Каждое действие публикуется в Kafka для notification-service.
+type OrganizerApplicationService struct {
+	apps         repository.OrganizerApplicationRepository
+	userRepo     repository.UserRepository
+	adminService *AdminService
+	producer     kafka.EventProducer
+	logger       *slog.Logger
+}
+
+func NewOrganizerApplicationService(
+	apps repository.OrganizerApplicationRepository,
+	userRepo repository.UserRepository,
+	adminService *AdminService,
+	producer kafka.EventProducer,
+	logger *slog.Logger,
+) *OrganizerApplicationService {
+	return &OrganizerApplicationService{
+		apps:         apps,
+		userRepo:     userRepo,
+		adminService: adminService,
+		producer:     producer,
+		logger:       logger,
+	}
+}
+
+// Submit создаёт заявку. Подать её может только пользователь с ролью user
+// и подтверждённой почтой, у которого нет заявки на рассмотрении. Проверка
+// ниже даёт понятную ошибку, а от параллельной подачи защищает уникальный
+// индекс: вторая заявка получит ту же ErrApplicationPending.
+func (s *OrganizerApplicationService) Submit(ctx context.Context, userID uint, in ApplicationInput) (*models.OrganizerApplication, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != models.RoleUser {
+		return nil, e.ErrAlreadyOrganizer
+	}
+	if !user.EmailVerified() {
+		return nil, e.ErrEmailNotVerified
+	}
+
+	latest, err := s.apps.GetLatestByUser(userID)
+	if err != nil && !errors.Is(err, e.ErrApplicationNotFound) {
+		return nil, err
+	}
+	if latest != nil && latest.Pending() {
+		return nil, e.ErrApplicationPending
+	}
+
+	app := &models.OrganizerApplication{
+		UserID:           userID,
+		OrganizationName: strings.TrimSpace(in.OrganizationName),
+		ContactName:      strings.TrimSpace(in.ContactName),
+		ContactEmail:     strings.ToLower(strings.TrimSpace(in.ContactEmail)),
+		ContactPhone:     strings.TrimSpace(in.ContactPhone),
+		Website:          strings.TrimSpace(in.Website),
+		Description:      strings.TrimSpace(in.Description),
+		Status:           models.ApplicationPending,
+	}
+	if err := s.apps.Create(app); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, kafka.TopicApplicationSubmitted, app)
+	return app, nil
+}
+
+// Latest возвращает последнюю заявку пользователя.
+func (s *OrganizerApplicationService) Latest(userID uint) (*models.OrganizerApplication, error) {
+	return s.apps.GetLatestByUser(userID)
+}
+
+func (s *OrganizerApplicationService) List(status models.ApplicationStatus, offset, limit int) ([]models.OrganizerApplication, int64, error) {
+	return s.apps.List(status, offset, limit)
+}
+
+func (s *OrganizerApplicationService) Get(id uint) (*models.OrganizerApplication, error) {
+	return s.apps.GetByID(id)
+}
+
+// Approve одобряет заявку и выдаёт пользователю роль организатора.
+// Смена роли идёт через AdminService: токены со старой ролью отзываются,
+// в журнал пишется и смена роли, и само решение. Смена роли затрагивает
+// и Redis, поэтому в одну транзакцию с решением не входит: если она не
+// удалась, заявка возвращается на рассмотрение и её можно одобрить снова.
+func (s *OrganizerApplicationService) Approve(ctx context.Context, actor AuditActor, id uint, reason string) (*models.OrganizerApplication, error) {
+	app, err := s.decide(ctx, actor, id, models.ApplicationApproved, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.promote(ctx, actor, app.UserID); err != nil {
+		if rerr := s.apps.Reopen(app.ID); rerr != nil {
+			s.logger.ErrorContext(ctx, "failed to reopen application after failed approval",
+				"error", rerr,
+				"application_id", app.ID)
+		}
+		return nil, err
+	}
+
+	s.publish(ctx, kafka.TopicApplicationApproved, app)
+	return app, nil
+}
+
+// promote выдаёт роль организатора. Администратора, подавшего заявку до
+// повышения, не понижаем. Старые токены отзываются при каждом одобрении:
+// повторное после сбоя застаёт роль уже выданной, а отзыв — не прошедшим.
+func (s *OrganizerApplicationService) promote(ctx context.Context, actor AuditActor, userID uint) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Role == models.RoleUser {
+		_, err = s.adminService.ChangeRole(ctx, actor, user.ID, models.RoleOrganizer)
+		return err
+	}
+	return s.adminService.authService.RevokeUserTokens(ctx, user.ID)
+}
+
+// Reject отклоняет заявку, причина обязательна — её увидит пользователь.
+func (s *OrganizerApplicationService) Reject(ctx context.Context, actor AuditActor, id uint, reason string) (*models.OrganizerApplication, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, e.ErrDecisionReasonRequired
+	}
+
+	app, err := s.decide(ctx, actor, id, models.ApplicationRejected, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, kafka.TopicApplicationRejected, app)
+	return app, nil
+}
+
+func (s *OrganizerApplicationService) decide(
+	ctx context.Context,
+	actor AuditActor,
+	id uint,
+	status models.ApplicationStatus,
+	reason string,
+) (*models.OrganizerApplication, error) {
+	app, err := s.apps.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !app.Pending() {
+		return nil, e.ErrApplicationAlreadyReviewed
+	}
+	if app.UserID == actor.UserID {
+		return nil, e.ErrCannotModifySelf
+	}
+
+	now := time.Now()
+	reviewerID := actor.UserID
+	app.Status = status
+	app.ReviewerID = &reviewerID
+	app.DecisionReason = strings.TrimSpace(reason)
+	app.ReviewedAt = &now
+
+	// статус меняется только из pending, поэтому два администратора
+	// не смогут одновременно принять разные решения
+	if err := s.apps.Decide(app); err != nil {
+		return nil, err
+	}
+
+	action := models.AuditApplicationApproved
+	if status == models.ApplicationRejected {
+		action = models.AuditApplicationRejected
+	}
+	if err := s.adminService.audit(ctx, actor, action, app.UserID, map[string]any{
+		"application_id": app.ID,
+		"reason":         app.DecisionReason,
+	}); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// publish отправляет событие в Kafka. Ошибка только логируется: решение
+// уже сохранено, а уведомление — не критичная часть процесса.
+func (s *OrganizerApplicationService) publish(ctx context.Context, topic string, app *models.OrganizerApplication) {
+	msg := kafka.OrganizerApplicationMessage{
+		ApplicationID:    app.ID,
+		UserID:           app.UserID,
+		OrganizationName: app.OrganizationName,
+		Status:           string(app.Status),
+		Reason:           app.DecisionReason,
+		OccurredAt:       time.Now(),
+	}
+	if app.ReviewerID != nil {
+		msg.ReviewerID = *app.ReviewerID
+	}
+
+	if err := s.producer.SendOrganizerApplication(ctx, topic, msg); err != nil {
+		s.logger.ErrorContext(ctx, "failed to publish organizer application event",
+			"error", err,
+			"topic", topic,
+			"application_id", app.ID)
+	}
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/kafka"
+	"user-service/internal/models"
+)
+
+type mockApplicationRepo struct {
+	apps []*models.OrganizerApplication
+}
+
+func (m *mockApplicationRepo) Create(app *models.OrganizerApplication) error {
+	app.ID = uint(len(m.apps) + 1)
+	m.apps = append(m.apps, app)
+	return nil
+}
+
+func (m *mockApplicationRepo) GetByID(id uint) (*models.OrganizerApplication, error) {
+	if id == 0 || int(id) > len(m.apps) {
+		return nil, e.ErrApplicationNotFound
+	}
+	cp := *m.apps[id-1]
+	return &cp, nil
+}
+
+func (m *mockApplicationRepo) GetLatestByUser(userID uint) (*models.OrganizerApplication, error) {
+	for i := len(m.apps) - 1; i >= 0; i-- {
+		if m.apps[i].UserID == userID {
+			cp := *m.apps[i]
+			return &cp, nil
+		}
+	}
+	return nil, e.ErrApplicationNotFound
+}
+
+func (m *mockApplicationRepo) List(status models.ApplicationStatus, _, _ int) ([]models.OrganizerApplication, int64, error) {
+	var out []models.OrganizerApplication
+	for _, a := range m.apps {
+		if status == "" || a.Status == status {
+			out = append(out, *a)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+func (m *mockApplicationRepo) Decide(app *models.OrganizerApplication) error {
+	stored := m.apps[app.ID-1]
+	if !stored.Pending() {
+		return e.ErrApplicationAlreadyReviewed
+	}
+	*stored = *app
+	return nil
+}
+
+func (m *mockApplicationRepo) Reopen(id uint) error {
+	stored := m.apps[id-1]
+	if stored.Status == models.ApplicationApproved {
+		stored.Status = models.ApplicationPending
+		stored.ReviewerID, stored.ReviewedAt, stored.DecisionReason = nil, nil, ""
+	}
+	return nil
+}
+
+type sentMessage struct {
+	topic string
+	msg   kafka.OrganizerApplicationMessage
+}
+
+type mockProducer struct {
+	sent []sentMessage
+}
+
+func (m *mockProducer) SendOrganizerApplication(_ context.Context, topic string, msg kafka.OrganizerApplicationMessage) error {
+	m.sent = append(m.sent, sentMessage{topic: topic, msg: msg})
+	return nil
+}
+
+func (m *mockProducer) Close() error { return nil }
+
+func newApplicationService(t *testing.T) (*OrganizerApplicationService, *mockUserRepo, *mockRevocations, *mockAuditRepo, *mockProducer) {
+	t.Helper()
+
+	admin, auth, _, revocations, audit := newAdminService(t)
+	users := auth.userRepo.(*mockUserRepo)
+	verified := time.Now()
+	users.users[1].EmailVerifiedAt = &verified
+
+	producer := &mockProducer{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := NewOrganizerApplicationService(&mockApplicationRepo{}, users, admin, producer, logger)
+	return svc, users, revocations, audit, producer
+}
+
+var testApplication = ApplicationInput{
+	OrganizationName: "Meetup Club",
+	ContactName:      "Anna",
+	ContactEmail:     " Anna@Club.Example ",
+	Description:      "Weekly meetups",
+}
+
+func TestApplication_ApprovePromotesUser(t *testing.T) {
+	svc, users, revocations, audit, producer := newApplicationService(t)
+	ctx := context.Background()
+
+	app, err := svc.Submit(ctx, 1, testApplication)
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if app.Status != models.ApplicationPending || app.ContactEmail != "anna@club.example" {
+		t.Fatalf("unexpected application: %+v", app)
+	}
+	if users.users[1].Role != models.RoleUser {
+		t.Fatalf("submitting must not change role")
+	}
+
+	if _, err := svc.Submit(ctx, 1, testApplication); !errors.Is(err, e.ErrApplicationPending) {
+		t.Fatalf("expected ErrApplicationPending, got %v", err)
+	}
+
+	approved, err := svc.Approve(ctx, AuditActor{UserID: 2}, app.ID, "")
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if approved.Status != models.ApplicationApproved || approved.ReviewerID == nil || *approved.ReviewerID != 2 {
+		t.Fatalf("unexpected decision: %+v", approved)
+	}
+	if users.users[1].Role != models.RoleOrganizer {
+		t.Fatalf("user must become organizer, got %s", users.users[1].Role)
+	}
+	if len(revocations.users) != 1 {
+		t.Fatalf("tokens with old role must be revoked")
+	}
+	if len(audit.entries) != 2 ||
+		audit.entries[0].Action != models.AuditApplicationApproved ||
+		audit.entries[1].Action != models.AuditUserRoleChanged {
+		t.Fatalf("unexpected audit entries: %+v", audit.entries)
+	}
+
+	if len(producer.sent) != 2 ||
+		producer.sent[0].topic != kafka.TopicApplicationSubmitted ||
+		producer.sent[1].topic != kafka.TopicApplicationApproved {
+		t.Fatalf("unexpected kafka messages: %+v", producer.sent)
+	}
+
+	if _, err := svc.Reject(ctx, AuditActor{UserID: 2}, app.ID, "late"); !errors.Is(err, e.ErrApplicationAlreadyReviewed) {
+		t.Fatalf("expected ErrApplicationAlreadyReviewed, got %v", err)
+	}
+}
+
+func TestApplication_ApproveReopensOnRoleChangeFailure(t *testing.T) {
+	svc, users, revocations, _, producer := newApplicationService(t)
+	ctx := context.Background()
+
+	app, _ := svc.Submit(ctx, 1, testApplication)
+	revocations.err = errors.New("redis is down")
+
+	if _, err := svc.Approve(ctx, AuditActor{UserID: 2}, app.ID, ""); !errors.Is(err, revocations.err) {
+		t.Fatalf("expected revocation error, got %v", err)
+	}
+	if stored, _ := svc.Get(app.ID); !stored.Pending() || stored.ReviewerID != nil {
+		t.Fatalf("failed approval must return application to review: %+v", stored)
+	}
+	if last := producer.sent[len(producer.sent)-1]; last.topic == kafka.TopicApplicationApproved {
+		t.Fatalf("failed approval must not be published")
+	}
+
+	revocations.err = nil
+	if _, err := svc.Approve(ctx, AuditActor{UserID: 2}, app.ID, ""); err != nil {
+		t.Fatalf("retry approve: %v", err)
+	}
+	if users.users[1].Role != models.RoleOrganizer {
+		t.Fatalf("user must become organizer, got %s", users.users[1].Role)
+	}
+	// роль выдана ещё при первой попытке, но токены с ролью user отзываются при повторной
+	if len(revocations.users) != 1 || revocations.users[0] != 1 {
+		t.Fatalf("retry must revoke old tokens, got %v", revocations.users)
+	}
+}
+
+func TestApplication_RejectRequiresReason(t *testing.T) {
+	svc, users, _, audit, producer := newApplicationService(t)
+	ctx := context.Background()
+
+	app, err := svc.Submit(ctx, 1, testApplication)
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	if _, err := svc.Reject(ctx, AuditActor{UserID: 2}, app.ID, "  "); !errors.Is(err, e.ErrDecisionReasonRequired) {
+		t.Fatalf("expected ErrDecisionReasonRequired, got %v", err)
+	}
+
+	rejected, err := svc.Reject(ctx, AuditActor{UserID: 2}, app.ID, "Нет сайта организации")
+	if err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if rejected.Status != models.ApplicationRejected || users.users[1].Role != models.RoleUser {
+		t.Fatalf("rejection must keep role: %+v", rejected)
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Action != models.AuditApplicationRejected {
+		t.Fatalf("unexpected audit entries: %+v", audit.entries)
+	}
+
+	last := producer.sent[len(producer.sent)-1]
+	if last.topic != kafka.TopicApplicationRejected || last.msg.Reason != "Нет сайта организации" {
+		t.Fatalf("unexpected kafka message: %+v", last)
+	}
+
+	// после отказа можно подать новую заявку
+	if _, err := svc.Submit(ctx, 1, testApplication); err != nil {
+		t.Fatalf("resubmit: %v", err)
+	}
+}
+
+func TestApplication_RequiresVerifiedEmail(t *testing.T) {
+	svc, users, _, _, producer := newApplicationService(t)
+	users.users[1].EmailVerifiedAt = nil
+
+	if _, err := svc.Submit(context.Background(), 1, testApplication); !errors.Is(err, e.ErrEmailNotVerified) {
+		t.Fatalf("expected ErrEmailNotVerified, got %v", err)
+	}
+	if len(producer.sent) != 0 {
+		t.Fatalf("nothing must be published")
+	}
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	e "user-service/internal/errors"
+	"user-service/internal/mailer"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+)
+
+// PasswordResetService восстанавливает доступ к аккаунту по ссылке из письма.
+type PasswordResetService struct {
+	userRepo    repository.UserRepository
+	tokens      repository.OneTimeTokenRepository
+	authService *AuthService
+	mailer      mailer.Mailer
+	baseURL     string
+	ttl         time.Duration
+	now         func() time.Time
+}
+
+func NewPasswordResetService(
+	userRepo repository.UserRepository,
+	tokens repository.OneTimeTokenRepository,
+	authService *AuthService,
+	m mailer.Mailer,
+	baseURL string,
+	ttl time.Duration,
+) *PasswordResetService {
+	return &PasswordResetService{
+		userRepo:    userRepo,
+		tokens:      tokens,
+		authService: authService,
+		mailer:      m,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		ttl:         ttl,
+		now:         time.Now,
+	}
+}
+
+// ForgotPassword отправляет ссылку для сброса пароля. Для неизвестного
+// или неактивного адреса молча ничего не делает, чтобы ответ не выдавал,
+// зарегистрирован ли адрес. Письмо уходит через mailer.Queue: ожидание
+// SMTP по времени ответа тоже выдало бы адрес.
+func (s *PasswordResetService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil || !user.IsActive {
+		return nil
+	}
+
+	token, err := issueOneTimeToken(s.tokens, user.ID, models.PurposePasswordReset, s.now(), s.ttl)
+	if err != nil {
+		return err
+	}
+
+	link := s.baseURL + "/reset-password?token=" + url.QueryEscape(token)
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Сброс пароля",
+		Body: fmt.Sprintf(
+			"Здравствуйте, %s!\n\nЧтобы задать новый пароль, перейдите по ссылке:\n%s\n\nСсылка действует %s. Если вы не запрашивали сброс, просто проигнорируйте письмо.\n",
+			user.FirstName, link, s.ttl,
+		),
+	})
+}
+
+// ResetPassword задаёт новый пароль по токену из письма и завершает
+// все сессии пользователя.
+func (s *PasswordResetService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	now := s.now()
+	record, err := consumeOneTimeToken(s.tokens, models.PurposePasswordReset, token, now)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(record.UserID)
+	if err != nil {
+		return e.ErrInvalidOneTimeToken
+	}
+	if !user.IsActive {
+		return e.ErrUserInactive
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	user.PasswordHash = string(passwordHash)
+	// ссылка пришла на этот адрес — значит, почта принадлежит пользователю
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	return s.authService.RevokeAllSessions(ctx, user.ID)
+}
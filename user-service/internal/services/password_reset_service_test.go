@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+)
+
+func newPasswordResetService(t *testing.T) (*PasswordResetService, *AuthService, *mockSessionRepo, *mockRevocations, *mockMailer) {
+	t.Helper()
+
+	auth, sessions, revocations := newAuthService(t)
+	mail := &mockMailer{}
+	svc := NewPasswordResetService(
+		auth.userRepo,
+		&mockTokenRepo{tokens: map[uint]*models.OneTimeToken{}},
+		auth,
+		mail,
+		"http://app",
+		time.Hour,
+	)
+	return svc, auth, sessions, revocations, mail
+}
+
+func TestPasswordReset_ChangesPasswordAndRevokesSessions(t *testing.T) {
+	svc, auth, sessions, revocations, mail := newPasswordResetService(t)
+	ctx := context.Background()
+
+	res, err := auth.Login(context.Background(), "a@b.c", "secret", SessionMeta{})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	refresh := res.RefreshToken
+
+	if err := svc.ForgotPassword(ctx, "a@b.c"); err != nil {
+		t.Fatalf("forgot: %v", err)
+	}
+	token := tokenFromMail(t, mail.sent[0])
+
+	if err := svc.ResetPassword(ctx, token, "new-secret"); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	if _, err := auth.Login(context.Background(), "a@b.c", "secret", SessionMeta{}); !errors.Is(err, e.ErrInvalidCredentials) {
+		t.Fatalf("old password must stop working, got %v", err)
+	}
+	if _, err := auth.Login(context.Background(), "a@b.c", "new-secret", SessionMeta{}); err != nil {
+		t.Fatalf("login with new password: %v", err)
+	}
+
+	if sessions.sessions[1].RevokedAt == nil {
+		t.Fatalf("existing session must be revoked")
+	}
+	if _, _, err := auth.RefreshTokens(ctx, refresh, SessionMeta{}); err == nil {
+		t.Fatalf("old refresh token must be rejected")
+	}
+	if len(revocations.users) != 1 {
+		t.Fatalf("access tokens must be revoked")
+	}
+
+	if err := svc.ResetPassword(ctx, token, "third-secret"); !errors.Is(err, e.ErrInvalidOneTimeToken) {
+		t.Fatalf("reset token must be single-use, got %v", err)
+	}
+}
+
+func TestPasswordReset_UnknownEmail(t *testing.T) {
+	svc, _, _, _, mail := newPasswordResetService(t)
+
+	if err := svc.ForgotPassword(context.Background(), "nobody@b.c"); err != nil {
+		t.Fatalf("unknown email must not be an error, got %v", err)
+	}
+	if len(mail.sent) != 0 {
+		t.Fatalf("no mail expected for unknown email")
+	}
+}
+
+func TestPasswordReset_ExpiredToken(t *testing.T) {
+	svc, _, _, _, mail := newPasswordResetService(t)
+	ctx := context.Background()
+
+	_ = svc.ForgotPassword(ctx, "a@b.c")
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	if err := svc.ResetPassword(ctx, tokenFromMail(t, mail.sent[0]), "new-secret"); !errors.Is(err, e.ErrInvalidOneTimeToken) {
+		t.Fatalf("expected expired token to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+)
+
+// LoginPolicy — ограничения на неверные пароли.
+type LoginPolicy struct {
+	// MaxFailures — неудач подряд по учётной записи до блокировки.
+	MaxFailures int
+	// IPMaxFailures — неудач с одного IP по любым учётным записям до блокировки.
+	IPMaxFailures int
+	// Window — сколько помним неудачу, если за ней не последовало новых.
+	Window time.Duration
+	// Lockout — длительность блокировки.
+	Lockout time.Duration
+	// BaseDelay — пауза после второй неудачи подряд, дальше удваивается
+	// до MaxDelay. Одна опечатка в пароле к паузе не приводит.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+func DefaultLoginPolicy() LoginPolicy {
+	return LoginPolicy{
+		MaxFailures:   5,
+		IPMaxFailures: 50,
+		Window:        15 * time.Minute,
+		Lockout:       15 * time.Minute,
+		BaseDelay:     time.Second,
+		MaxDelay:      30 * time.Second,
+	}
+}
+
+// delay — сколько ждать следующей попытки после failures неудач подряд.
+func (p LoginPolicy) delay(failures int) time.Duration {
+	if failures < 2 || p.BaseDelay <= 0 {
+		return 0
+	}
+	d := p.BaseDelay
+	for i := 2; i < failures && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// LoginBlockedError — вход отклонён без проверки пароля. Err —
+// ErrAccountLocked или ErrTooManyLoginAttempts.
+type LoginBlockedError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (b *LoginBlockedError) Error() string { return b.Err.Error() }
+func (b *LoginBlockedError) Unwrap() error { return b.Err }
+
+// SecurityService защищает вход по паролю от перебора: считает неудачи
+// по учётной записи и по IP, замедляет повторные попытки и временно
+// блокирует вход. Блокировки пишутся в security_events.
+type SecurityService struct {
+	attempts repository.LoginAttemptRepository
+	events   repository.SecurityEventRepository
+	policy   LoginPolicy
+	now      func() time.Time
+}
+
+func NewSecurityService(
+	attempts repository.LoginAttemptRepository,
+	events repository.SecurityEventRepository,
+	policy LoginPolicy,
+) *SecurityService {
+	return &SecurityService{
+		attempts: attempts,
+		events:   events,
+		policy:   policy,
+		now:      time.Now,
+	}
+}
+
+// LoginAttempt — попытка входа, заранее учтённая как неудачная. Её нужно
+// завершить: LoginFailed, LoginSucceeded или LoginCancelled.
+type LoginAttempt struct {
+	email      string
+	ip         string
+	failures   int
+	ipFailures int
+}
+
+// BeginLogin решает, можно ли сейчас проверять пароль, и резервирует
+// попытку до проверки: параллельные догадки не проскакивают мимо паузы и
+// блокировки. Счётчик ведётся и для несуществующих адресов, чтобы ответ
+// не выдавал, зарегистрирован ли email.
+func (s *SecurityService) BeginLogin(ctx context.Context, email, ip string) (*LoginAttempt, error) {
+	now := s.now()
+
+	if ip != "" {
+		byIP, err := s.attempts.Get(ctx, ipKey(ip))
+		if err != nil {
+			return nil, err
+		}
+		if byIP.Locked(now) {
+			return nil, &LoginBlockedError{Err: e.ErrAccountLocked, RetryAfter: byIP.LockedUntil.Sub(now)}
+		}
+	}
+
+	account, err := s.attempts.Get(ctx, accountKey(email))
+	if err != nil {
+		return nil, err
+	}
+	if account.Locked(now) {
+		return nil, &LoginBlockedError{Err: e.ErrAccountLocked, RetryAfter: account.LockedUntil.Sub(now)}
+	}
+
+	var retryAfter time.Duration
+	prev, ok, err := s.attempts.Reserve(ctx, accountKey(email), now, s.policy.Window, func(prev repository.LoginAttempts) bool {
+		if prev.Failures > 0 {
+			if next := prev.LastFailure.Add(s.policy.delay(prev.Failures)); now.Before(next) {
+				retryAfter = next.Sub(now)
+				return false
+			}
+		}
+		// порог уже занят параллельными попытками: блокировку поставит та,
+		// что его достигла
+		if s.policy.MaxFailures > 0 && prev.Failures >= s.policy.MaxFailures {
+			retryAfter = s.policy.delay(prev.Failures + 1)
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, tooManyAttempts(retryAfter)
+	}
+	attempt := &LoginAttempt{email: email, ip: ip, failures: prev.Failures + 1}
+
+	if ip != "" {
+		prevIP, ok, err := s.attempts.Reserve(ctx, ipKey(ip), now, s.policy.Window, func(prev repository.LoginAttempts) bool {
+			return s.policy.IPMaxFailures <= 0 || prev.Failures < s.policy.IPMaxFailures
+		})
+		if err != nil {
+			// резерв учётной записи без резерва IP не завершится: возвращаем его
+			return nil, errors.Join(err, s.LoginCancelled(ctx, attempt))
+		}
+		if !ok {
+			return nil, s.refuse(ctx, attempt, s.policy.delay(attempt.failures))
+		}
+		attempt.ipFailures = prevIP.Failures + 1
+	}
+	return attempt, nil
+}
+
+// refuse отклоняет попытку без проверки пароля и возвращает резерв.
+func (s *SecurityService) refuse(ctx context.Context, attempt *LoginAttempt, retryAfter time.Duration) error {
+	if err := s.LoginCancelled(ctx, attempt); err != nil {
+		return err
+	}
+	return tooManyAttempts(retryAfter)
+}
+
+func tooManyAttempts(retryAfter time.Duration) error {
+	if retryAfter <= 0 {
+		retryAfter = time.Second
+	}
+	return &LoginBlockedError{Err: e.ErrTooManyLoginAttempts, RetryAfter: retryAfter}
+}
+
+// LoginFailed завершает попытку с неверным паролем: резерв остаётся
+// неудачей, при достижении порога ставится блокировка. userID = 0 — адрес
+// не зарегистрирован.
+func (s *SecurityService) LoginFailed(ctx context.Context, attempt *LoginAttempt, userID uint, meta SessionMeta) error {
+	if s.policy.MaxFailures > 0 && attempt.failures >= s.policy.MaxFailures {
+		if err := s.lock(ctx, accountKey(attempt.email), models.SecurityAccountLocked, userID, meta, map[string]any{
+			"email":    normalizeEmail(attempt.email),
+			"failures": attempt.failures,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if attempt.ip == "" {
+		return nil
+	}
+	if s.policy.IPMaxFailures > 0 && attempt.ipFailures >= s.policy.IPMaxFailures {
+		return s.lock(ctx, ipKey(attempt.ip), models.SecurityIPLocked, userID, meta, map[string]any{
+			"failures": attempt.ipFailures,
+		})
+	}
+	return nil
+}
+
+// LoginSucceeded сбрасывает счётчик по учётной записи и возвращает резерв
+// по IP. Остальные неудачи с IP не сбрасываем: иначе перебор можно
+// разбавлять входами в свой аккаунт.
+func (s *SecurityService) LoginSucceeded(ctx context.Context, attempt *LoginAttempt) error {
+	if err := s.attempts.Reset(ctx, accountKey(attempt.email)); err != nil {
+		return err
+	}
+	if attempt.ip == "" || attempt.ipFailures == 0 {
+		return nil
+	}
+	return s.attempts.Release(ctx, ipKey(attempt.ip))
+}
+
+// LoginCancelled возвращает резерв попытки, которая не дошла до проверки пароля.
+func (s *SecurityService) LoginCancelled(ctx context.Context, attempt *LoginAttempt) error {
+	if err := s.attempts.Release(ctx, accountKey(attempt.email)); err != nil {
+		return err
+	}
+	if attempt.ip == "" || attempt.ipFailures == 0 {
+		return nil
+	}
+	return s.attempts.Release(ctx, ipKey(attempt.ip))
+}
+
+func (s *SecurityService) ListEvents(userID uint, offset, limit int) ([]models.SecurityEvent, int64, error) {
+	return s.events.List(userID, offset, limit)
+}
+
+func (s *SecurityService) lock(
+	ctx context.Context,
+	key string,
+	eventType models.SecurityEventType,
+	userID uint,
+	meta SessionMeta,
+	details map[string]any,
+) error {
+	until := s.now().Add(s.policy.Lockout)
+	if err := s.attempts.Lock(ctx, key, until); err != nil {
+		return err
+	}
+
+	details["locked_until"] = until.UTC()
+	raw, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	return s.events.Create(&models.SecurityEvent{
+		UserID:    userID,
+		Type:      eventType,
+		IP:        truncate(meta.IP, 64),
+		UserAgent: truncate(meta.UserAgent, 512),
+		Details:   string(raw),
+		CreatedAt: s.now(),
+	})
+}
+
+func accountKey(email string) string {
+	return "account:" + normalizeEmail(email)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+)
+
+type mockSecurityEventRepo struct {
+	events []models.SecurityEvent
+}
+
+func (m *mockSecurityEventRepo) Create(event *models.SecurityEvent) error {
+	m.events = append(m.events, *event)
+	return nil
+}
+
+func (m *mockSecurityEventRepo) List(uint, int, int) ([]models.SecurityEvent, int64, error) {
+	return m.events, int64(len(m.events)), nil
+}
+
+// newGuardedAuthService — AuthService с заданной политикой входа и
+// управляемыми часами защиты от перебора.
+func newGuardedAuthService(t *testing.T, policy LoginPolicy) (*AuthService, *mockSecurityEventRepo, *time.Time) {
+	t.Helper()
+
+	svc, _, _ := newAuthService(t)
+	events := &mockSecurityEventRepo{}
+	clock := time.Now()
+
+	svc.security = NewSecurityService(repository.NewMemoryLoginAttemptRepository(), events, policy)
+	svc.security.now = func() time.Time { return clock }
+	return svc, events, &clock
+}
+
+func TestLoginPolicy_Delay(t *testing.T) {
+	p := LoginPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	cases := map[int]time.Duration{
+		0: 0,
+		1: 0,
+		2: time.Second,
+		3: 2 * time.Second,
+		4: 4 * time.Second,
+		5: 5 * time.Second,
+		9: 5 * time.Second,
+	}
+	for failures, want := range cases {
+		if got := p.delay(failures); got != want {
+			t.Errorf("delay(%d) = %s, want %s", failures, got, want)
+		}
+	}
+}
+
+func TestSecurity_LocksAccountAfterThreshold(t *testing.T) {
+	svc, events, _ := newGuardedAuthService(t, LoginPolicy{
+		MaxFailures: 3,
+		Window:      time.Minute,
+		Lockout:     time.Minute,
+	})
+	ctx := context.Background()
+	meta := SessionMeta{IP: "10.0.0.1", UserAgent: "curl"}
+
+	for i := 0; i < 3; i++ {
+		if _, err := svc.Login(ctx, "a@b.c", "wrong", meta); !errors.Is(err, e.ErrInvalidCredentials) {
+			t.Fatalf("attempt %d: expected ErrInvalidCredentials, got %v", i+1, err)
+		}
+	}
+
+	_, err := svc.Login(ctx, "A@B.c", "secret", meta)
+	var blocked *LoginBlockedError
+	if !errors.As(err, &blocked) || !errors.Is(err, e.ErrAccountLocked) {
+		t.Fatalf("expected account lock, got %v", err)
+	}
+	if blocked.RetryAfter <= 0 || blocked.RetryAfter > time.Minute {
+		t.Fatalf("unexpected retry after: %s", blocked.RetryAfter)
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("expected one security event, got %d", len(events.events))
+	}
+	ev := events.events[0]
+	if ev.Type != models.SecurityAccountLocked || ev.UserID != 1 || ev.IP != "10.0.0.1" {
+		t.Fatalf("unexpected security event: %+v", ev)
+	}
+}
+
+func TestSecurity_DelaysRepeatedFailures(t *testing.T) {
+	svc, _, clock := newGuardedAuthService(t, LoginPolicy{
+		MaxFailures: 10,
+		Window:      time.Minute,
+		Lockout:     time.Minute,
+		BaseDelay:   time.Second,
+		MaxDelay:    10 * time.Second,
+	})
+	ctx := context.Background()
+
+	_, _ = svc.Login(ctx, "a@b.c", "wrong", SessionMeta{})
+	_, _ = svc.Login(ctx, "a@b.c", "wrong", SessionMeta{})
+
+	_, err := svc.Login(ctx, "a@b.c", "secret", SessionMeta{})
+	var blocked *LoginBlockedError
+	if !errors.As(err, &blocked) || !errors.Is(err, e.ErrTooManyLoginAttempts) || blocked.RetryAfter != time.Second {
+		t.Fatalf("expected 1s throttle, got %v", err)
+	}
+
+	*clock = clock.Add(time.Second)
+	if _, err := svc.Login(ctx, "a@b.c", "secret", SessionMeta{}); err != nil {
+		t.Fatalf("login after delay: %v", err)
+	}
+
+	// успешный вход обнуляет счётчик
+	_, _ = svc.Login(ctx, "a@b.c", "wrong", SessionMeta{})
+	if _, err := svc.Login(ctx, "a@b.c", "secret", SessionMeta{}); err != nil {
+		t.Fatalf("single failure must not delay login: %v", err)
+	}
+}
+
+func TestSecurity_RefusedAttemptDoesNotExtendDelay(t *testing.T) {
+	svc, _, clock := newGuardedAuthService(t, LoginPolicy{
+		MaxFailures: 10,
+		Window:      time.Minute,
+		Lockout:     time.Minute,
+		BaseDelay:   time.Second,
+		MaxDelay:    10 * time.Second,
+	})
+	ctx := context.Background()
+
+	_, _ = svc.Login(ctx, "a@b.c", "wrong", SessionMeta{})
+	_, _ = svc.Login(ctx, "a@b.c", "wrong", SessionMeta{})
+
+	// отказ во время паузы не сдвигает её конец
+	*clock = clock.Add(500 * time.Millisecond)
+	var blocked *LoginBlockedError
+	if _, err := svc.Login(ctx, "a@b.c", "secret", SessionMeta{}); !errors.As(err, &blocked) || blocked.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("expected 500ms throttle, got %v", err)
+	}
+
+	*clock = clock.Add(500 * time.Millisecond)
+	if _, err := svc.Login(ctx, "a@b.c", "secret", SessionMeta{}); err != nil {
+		t.Fatalf("login after delay: %v", err)
+	}
+}
+
+// failingIPAttempts не может учесть попытку по IP.
+type failingIPAttempts struct {
+	repository.LoginAttemptRepository
+}
+
+func (f failingIPAttempts) Reserve(
+	ctx context.Context,
+	key string,
+	at time.Time,
+	window time.Duration,
+	allow func(repository.LoginAttempts) bool,
+) (repository.LoginAttempts, bool, error) {
+	if strings.HasPrefix(key, "ip:") {
+		return repository.LoginAttempts{}, false, errors.New("redis down")
+	}
+	return f.LoginAttemptRepository.Reserve(ctx, key, at, window, allow)
+}
+
+func TestSecurity_BeginLoginReleasesAccountOnIPError(t *testing.T) {
+	attempts := repository.NewMemoryLoginAttemptRepository()
+	security := NewSecurityService(failingIPAttempts{attempts}, &mockSecurityEventRepo{}, LoginPolicy{
+		MaxFailures: 3,
+		Window:      time.Minute,
+		Lockout:     time.Minute,
+	})
+	ctx := context.Background()
+
+	if _, err := security.BeginLogin(ctx, "a@b.c", "10.0.0.1"); err == nil {
+		t.Fatalf("expected error")
+	}
+	got, err := attempts.Get(ctx, accountKey("a@b.c"))
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Failures != 0 {
+		t.Fatalf("account reservation must be released, got %d failures", got.Failures)
+	}
+}
+
+func TestSecurity_LocksIPAcrossAccounts(t *testing.T) {
+	svc, events, _ := newGuardedAuthService(t, LoginPolicy{
+		MaxFailures:   10,
+		IPMaxFailures: 3,
+		Window:        time.Minute,
+		Lockout:       time.Minute,
+	})
+	ctx := context.Background()
+	meta := SessionMeta{IP: "10.0.0.9"}
+
+	for _, email := range []string{"x@b.c", "y@b.c", "z@b.c"} {
+		if _, err := svc.Login(ctx, email, "guess", meta); !errors.Is(err, e.ErrInvalidCredentials) {
+			t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+		}
+	}
+
+	if _, err := svc.Login(ctx, "a@b.c", "secret", meta); !errors.Is(err, e.ErrAccountLocked) {
+		t.Fatalf("expected ip lock, got %v", err)
+	}
+	if _, err := svc.Login(ctx, "a@b.c", "secret", SessionMeta{IP: "10.0.0.10"}); err != nil {
+		t.Fatalf("other ip must not be locked: %v", err)
+	}
+
+	if len(events.events) != 1 || events.events[0].Type != models.SecurityIPLocked || events.events[0].UserID != 0 {
+		t.Fatalf("unexpected security events: %+v", events.events)
+	}
+}
+
+func TestSecurity_ReservesAttemptsBeforePasswordCheck(t *testing.T) {
+	security := NewSecurityService(repository.NewMemoryLoginAttemptRepository(), &mockSecurityEventRepo{}, LoginPolicy{
+		MaxFailures: 3,
+		Window:      time.Minute,
+		Lockout:     time.Minute,
+	})
+	ctx := context.Background()
+
+	// параллельные догадки: пароль ещё ни разу не проверен
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	admitted := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := security.BeginLogin(ctx, "a@b.c", "10.0.0.1"); err == nil {
+				mu.Lock()
+				admitted++
+				mu.Unlock()
+			} else if !errors.Is(err, e.ErrTooManyLoginAttempts) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if admitted != 3 {
+		t.Fatalf("expected 3 attempts admitted before lockout, got %d", admitted)
+	}
+}
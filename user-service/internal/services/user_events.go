@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"user-service/internal/models"
+	"user-service/userclient"
+)
+
+type UserEventPublisher interface {
+	SendUserEvent(ctx context.Context, msg userclient.UserEvent) error
+}
+
+// userEventQueueSize — сколько событий ждут отправки, пока Kafka недоступна.
+const userEventQueueSize = 1024
+
+type queuedUserEvent struct {
+	ctx context.Context
+	msg userclient.UserEvent
+}
+
+// UserEvents публикует события жизненного цикла пользователя. Отправка идёт
+// в фоне одной горутиной — по порядку и без ожидания Kafka в запросе;
+// ошибка только логируется: изменение уже сохранено. nil ничего не
+// публикует — так сервисы собираются в тестах.
+type UserEvents struct {
+	publisher UserEventPublisher
+	logger    *slog.Logger
+	now       func() time.Time
+	queue     chan queuedUserEvent
+	done      chan struct{}
+}
+
+func NewUserEvents(publisher UserEventPublisher, logger *slog.Logger) *UserEvents {
+	p := &UserEvents{
+		publisher: publisher,
+		logger:    logger,
+		now:       time.Now,
+		queue:     make(chan queuedUserEvent, userEventQueueSize),
+		done:      make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// Close дожидается отправки событий из очереди.
+func (p *UserEvents) Close() {
+	if p == nil {
+		return
+	}
+	close(p.queue)
+	<-p.done
+}
+
+func (p *UserEvents) run() {
+	defer close(p.done)
+
+	for ev := range p.queue {
+		if err := p.publisher.SendUserEvent(ev.ctx, ev.msg); err != nil {
+			p.logger.ErrorContext(ev.ctx, "failed to publish user event",
+				"error", err,
+				"topic", ev.msg.Type,
+				"user_id", ev.msg.UserID)
+		}
+	}
+}
+
+func (p *UserEvents) Registered(ctx context.Context, user *models.User) {
+	p.publish(ctx, userclient.TopicUserRegistered, user, func(*userclient.UserEvent) {})
+}
+
+func (p *UserEvents) Updated(ctx context.Context, user *models.User) {
+	p.publish(ctx, userclient.TopicUserUpdated, user, func(*userclient.UserEvent) {})
+}
+
+func (p *UserEvents) RoleChanged(ctx context.Context, user *models.User, previous models.UserRole) {
+	p.publish(ctx, userclient.TopicUserRoleChanged, user, func(msg *userclient.UserEvent) {
+		msg.PreviousRole = string(previous)
+	})
+}
+
+// Deactivated — учётная запись выключена; reason — DeactivatedByAdmin
+// или DeactivatedByDeletion.
+func (p *UserEvents) Deactivated(ctx context.Context, user *models.User, reason string) {
+	p.publish(ctx, userclient.TopicUserDeactivated, user, func(msg *userclient.UserEvent) {
+		msg.Reason = reason
+	})
+}
+
+func (p *UserEvents) publish(ctx context.Context, topic string, user *models.User, fill func(*userclient.UserEvent)) {
+	if p == nil {
+		return
+	}
+
+	msg := userclient.UserEvent{
+		Version:       userclient.UserEventVersion,
+		Type:          topic,
+		UserID:        user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Role:          string(user.Role),
+		IsActive:      user.IsActive,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		OccurredAt:    p.now(),
+	}
+	fill(&msg)
+
+	// запрос закончится раньше отправки, ID запроса в контексте сохраняем
+	select {
+	case p.queue <- queuedUserEvent{ctx: context.WithoutCancel(ctx), msg: msg}:
+	default:
+		p.logger.ErrorContext(ctx, "user event queue is full, event dropped",
+			"topic", topic,
+			"user_id", user.ID)
+	}
+}
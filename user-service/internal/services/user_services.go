@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	e "user-service/internal/errors"
 	"user-service/internal/models"
 	"user-service/internal/repository"
@@ -8,18 +10,21 @@ import (
 
 type UserService interface {
 	GetByID(id uint) (*models.User, error)
-	UpdateProfile(id uint, firstName, lastName string) (*models.User, error)
-	BecomeOrganizer(id uint) (*models.User, error)
+	UpdateProfile(ctx context.Context, id uint, firstName, lastName string) (*models.User, error)
 	GetByIDs(id uint) (*models.User, error)
+	// GetMany — пакетный поиск для внутреннего API других сервисов.
+	GetMany(ids []uint) ([]models.User, error)
 }
 
 type userService struct {
-	repo repository.UserRepository
+	repo   repository.UserRepository
+	events *UserEvents
 }
 
-func NewUserService(repo repository.UserRepository) UserService {
+func NewUserService(repo repository.UserRepository, events *UserEvents) UserService {
 	return &userService{
-		repo: repo,
+		repo:   repo,
+		events: events,
 	}
 }
 
@@ -53,6 +58,7 @@ func (s *userService) GetByIDs(id uint) (*models.User, error) {
 }
 
 func (s *userService) UpdateProfile(
+	ctx context.Context,
 	id uint,
 	firstName string,
 	lastName string,
@@ -69,25 +75,11 @@ func (s *userService) UpdateProfile(
 	if err := s.repo.Update(user); err != nil {
 		return nil, err
 	}
+	s.events.Updated(ctx, user)
 
 	return user, nil
 }
 
-func (s *userService) BecomeOrganizer(id uint) (*models.User, error) {
-	user, err := s.repo.GetByID(id)
-	if err != nil {
-		return nil, e.ErrUserNotFound
-	}
-
-	if user.Role == models.RoleOrganizer {
-		return nil, e.ErrAlreadyOrganizer
-	}
-
-	user.Role = models.RoleOrganizer
-	
-	if err := s.repo.Update(user); err != nil {
-		return nil, err
-	}
-
-	return user, nil
+func (s *userService) GetMany(ids []uint) ([]models.User, error) {
+	return s.repo.GetByIDs(ids)
 }
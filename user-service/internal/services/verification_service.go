@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/mailer"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+)
+
+// VerificationService подтверждает адрес почты одноразовой ссылкой из письма.
+type VerificationService struct {
+	userRepo repository.UserRepository
+	tokens   repository.OneTimeTokenRepository
+	mailer   mailer.Mailer
+	baseURL  string
+	ttl      time.Duration
+	events   *UserEvents
+	now      func() time.Time
+}
+
+func NewVerificationService(
+	userRepo repository.UserRepository,
+	tokens repository.OneTimeTokenRepository,
+	m mailer.Mailer,
+	baseURL string,
+	ttl time.Duration,
+	events *UserEvents,
+) *VerificationService {
+	return &VerificationService{
+		userRepo: userRepo,
+		tokens:   tokens,
+		mailer:   m,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		ttl:      ttl,
+		events:   events,
+		now:      time.Now,
+	}
+}
+
+// SendVerification выпускает новый токен и отправляет письмо.
+// Ранее отправленные ссылки перестают работать.
+func (s *VerificationService) SendVerification(ctx context.Context, user *models.User) error {
+	if user.EmailVerified() {
+		return e.ErrEmailAlreadyVerified
+	}
+
+	token, err := issueOneTimeToken(s.tokens, user.ID, models.PurposeEmailVerification, s.now(), s.ttl)
+	if err != nil {
+		return err
+	}
+
+	link := s.baseURL + "/verify-email?token=" + url.QueryEscape(token)
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Подтверждение адреса электронной почты",
+		Body: fmt.Sprintf(
+			"Здравствуйте, %s!\n\nЧтобы подтвердить адрес, перейдите по ссылке:\n%s\n\nСсылка действует %s.\n",
+			user.FirstName, link, s.ttl,
+		),
+	})
+}
+
+// VerifyEmail отмечает почту подтверждённой по токену из письма.
+func (s *VerificationService) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	now := s.now()
+	record, err := consumeOneTimeToken(s.tokens, models.PurposeEmailVerification, token, now)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(record.UserID)
+	if err != nil {
+		return nil, e.ErrUserNotFound
+	}
+
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+		s.events.Updated(ctx, user)
+	}
+
+	return user, nil
+}
+
+// ResendVerification отправляет письмо повторно. Неизвестный адрес и уже
+// подтверждённая почта не считаются ошибкой, чтобы по ответу нельзя было
+// узнать, зарегистрирован ли адрес. По той же причине письмо уходит через
+// mailer.Queue, а не в запросе.
+func (s *VerificationService) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	if !user.IsActive {
+		return nil
+	}
+
+	if err := s.SendVerification(ctx, user); err != nil && !errors.Is(err, e.ErrEmailAlreadyVerified) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	e "user-service/internal/errors"
+	"user-service/internal/mailer"
+	"user-service/internal/models"
+)
+
+type mockTokenRepo struct {
+	tokens map[uint]*models.OneTimeToken
+}
+
+func (m *mockTokenRepo) Create(t *models.OneTimeToken) error {
+	t.ID = uint(len(m.tokens) + 1)
+	m.tokens[t.ID] = t
+	return nil
+}
+
+func (m *mockTokenRepo) GetByHash(purpose models.TokenPurpose, hash string) (*models.OneTimeToken, error) {
+	for _, t := range m.tokens {
+		if t.Purpose == purpose && t.TokenHash == hash {
+			return t, nil
+		}
+	}
+	return nil, e.ErrInvalidOneTimeToken
+}
+
+func (m *mockTokenRepo) Consume(id uint, at time.Time) error {
+	t, ok := m.tokens[id]
+	if !ok || t.UsedAt != nil {
+		return e.ErrInvalidOneTimeToken
+	}
+	t.UsedAt = &at
+	return nil
+}
+
+func (m *mockTokenRepo) RegisterAttempt(id uint, max int) error {
+	t, ok := m.tokens[id]
+	if !ok || t.UsedAt != nil || t.Attempts >= max {
+		return e.ErrInvalidOneTimeToken
+	}
+	t.Attempts++
+	return nil
+}
+
+func (m *mockTokenRepo) InvalidateForUser(userID uint, purpose models.TokenPurpose, at time.Time) error {
+	for _, t := range m.tokens {
+		if t.UserID == userID && t.Purpose == purpose && t.UsedAt == nil {
+			t.UsedAt = &at
+		}
+	}
+	return nil
+}
+
+type mockMailer struct {
+	sent []mailer.Message
+}
+
+func (m *mockMailer) Send(_ context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// tokenFromMail достаёт токен из ссылки в письме.
+func tokenFromMail(t *testing.T, msg mailer.Message) string {
+	t.Helper()
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if u, err := url.Parse(line); err == nil && u.Query().Get("token") != "" {
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatalf("no token in mail body: %q", msg.Body)
+	return ""
+}
+
+func newVerificationService() (*VerificationService, *mockUserRepo, *mockMailer) {
+	users := &mockUserRepo{users: map[uint]*models.User{
+		1: {ID: 1, Email: "a@b.c", Role: models.RoleUser, IsActive: true},
+	}}
+	mail := &mockMailer{}
+	svc := NewVerificationService(users, &mockTokenRepo{tokens: map[uint]*models.OneTimeToken{}}, mail, "http://app", time.Hour, nil)
+	return svc, users, mail
+}
+
+func TestVerificationService_VerifyEmail(t *testing.T) {
+	svc, users, mail := newVerificationService()
+	ctx := context.Background()
+
+	if err := svc.SendVerification(ctx, users.users[1]); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	token := tokenFromMail(t, mail.sent[0])
+
+	user, err := svc.VerifyEmail(ctx, token)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !user.EmailVerified() {
+		t.Fatalf("email must be verified")
+	}
+
+	if _, err := svc.VerifyEmail(ctx, token); !errors.Is(err, e.ErrInvalidOneTimeToken) {
+		t.Fatalf("token must be single-use, got %v", err)
+	}
+}
+
+func TestVerificationService_ResendInvalidatesPreviousLink(t *testing.T) {
+	svc, users, mail := newVerificationService()
+	ctx := context.Background()
+
+	_ = svc.SendVerification(ctx, users.users[1])
+	if err := svc.ResendVerification(ctx, "a@b.c"); err != nil {
+		t.Fatalf("resend: %v", err)
+	}
+
+	if _, err := svc.VerifyEmail(ctx, tokenFromMail(t, mail.sent[0])); !errors.Is(err, e.ErrInvalidOneTimeToken) {
+		t.Fatalf("old link must stop working, got %v", err)
+	}
+	if _, err := svc.VerifyEmail(ctx, tokenFromMail(t, mail.sent[1])); err != nil {
+		t.Fatalf("new link must work: %v", err)
+	}
+}
+
+func TestVerificationService_ExpiredToken(t *testing.T) {
+	svc, users, mail := newVerificationService()
+	ctx := context.Background()
+
+	_ = svc.SendVerification(ctx, users.users[1])
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	if _, err := svc.VerifyEmail(ctx, tokenFromMail(t, mail.sent[0])); !errors.Is(err, e.ErrInvalidOneTimeToken) {
+		t.Fatalf("expected expired token to be rejected, got %v", err)
+	}
+}
+
+func TestVerificationService_ResendDoesNotRevealEmail(t *testing.T) {
+	svc, _, mail := newVerificationService()
+
+	if err := svc.ResendVerification(context.Background(), "unknown@b.c"); err != nil {
+		t.Fatalf("unknown email must not be an error, got %v", err)
+	}
+	if len(mail.sent) != 0 {
+		t.Fatalf("no mail expected for unknown email")
+	}
+}
@@ -0,0 +1,208 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+	"user-service/internal/services"
+	"user-service/internal/transport/dto"
+	"user-service/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	adminService *services.AdminService
+	logger       *slog.Logger
+}
+
+func NewAdminHandler(adminService *services.AdminService, logger *slog.Logger) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+		logger:       logger,
+	}
+}
+
+func (h *AdminHandler) RegisterRoutes(r *gin.Engine) {
+	admin := r.Group("/admin", middleware.RequireRole(string(models.RoleAdmin)))
+	{
+		admin.GET("/users", h.ListUsers)
+		admin.GET("/users/:id", h.GetUser)
+		admin.POST("/users/:id/activate", h.Activate)
+		admin.POST("/users/:id/deactivate", h.Deactivate)
+		admin.PUT("/users/:id/role", h.ChangeRole)
+		admin.GET("/audit-logs", h.ListAuditLogs)
+	}
+}
+
+func (h *AdminHandler) ListUsers(ctx *gin.Context) {
+	var query dto.AdminUserListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректные параметры"})
+		return
+	}
+	query.Normalize()
+
+	role := models.UserRole(strings.TrimSpace(query.Role))
+	if role != "" && !role.Valid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": e.ErrInvalidRole.Error()})
+		return
+	}
+
+	users, total, err := h.adminService.ListUsers(repository.UserFilter{
+		Query:    strings.TrimSpace(query.Query),
+		Role:     role,
+		IsActive: query.Active,
+		Offset:   (query.Page - 1) * query.Limit,
+		Limit:    query.Limit,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to list users", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	resp := dto.AdminUserListResponse{
+		Users: make([]dto.AdminUserResponse, 0, len(users)),
+		Total: total,
+		Page:  query.Page,
+		Limit: query.Limit,
+	}
+	for i := range users {
+		resp.Users = append(resp.Users, dto.ToAdminUserResponse(&users[i]))
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *AdminHandler) GetUser(ctx *gin.Context) {
+	id, ok := userIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminService.GetUser(id)
+	if err != nil {
+		h.respondError(ctx, "failed to get user", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToAdminUserResponse(user))
+}
+
+func (h *AdminHandler) Activate(ctx *gin.Context) {
+	h.setActive(ctx, true)
+}
+
+func (h *AdminHandler) Deactivate(ctx *gin.Context) {
+	h.setActive(ctx, false)
+}
+
+func (h *AdminHandler) setActive(ctx *gin.Context, active bool) {
+	actor, ok := auditActor(ctx)
+	if !ok {
+		return
+	}
+	id, ok := userIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminService.SetActive(ctx.Request.Context(), actor, id, active)
+	if err != nil {
+		h.respondError(ctx, "failed to change user status", err)
+		return
+	}
+
+	h.logger.InfoContext(ctx.Request.Context(), "user status changed by admin",
+		"admin_id", actor.UserID, "user_id", user.ID, "active", active)
+	ctx.JSON(http.StatusOK, dto.ToAdminUserResponse(user))
+}
+
+func (h *AdminHandler) ChangeRole(ctx *gin.Context) {
+	actor, ok := auditActor(ctx)
+	if !ok {
+		return
+	}
+	id, ok := userIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ChangeRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	user, err := h.adminService.ChangeRole(ctx.Request.Context(), actor, id, models.UserRole(strings.TrimSpace(req.Role)))
+	if err != nil {
+		h.respondError(ctx, "failed to change user role", err)
+		return
+	}
+
+	h.logger.InfoContext(ctx.Request.Context(), "user role changed by admin",
+		"admin_id", actor.UserID, "user_id", user.ID, "role", user.Role)
+	ctx.JSON(http.StatusOK, dto.ToAdminUserResponse(user))
+}
+
+func (h *AdminHandler) ListAuditLogs(ctx *gin.Context) {
+	var query dto.AuditLogListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректные параметры"})
+		return
+	}
+	query.Normalize()
+
+	entries, total, err := h.adminService.ListAuditLogs(query.UserID, (query.Page-1)*query.Limit, query.Limit)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to list audit logs", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.AuditLogListResponse{
+		Entries: dto.ToAuditLogResponses(entries),
+		Total:   total,
+		Page:    query.Page,
+		Limit:   query.Limit,
+	})
+}
+
+func (h *AdminHandler) respondError(ctx *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, e.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrInvalidRole):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrCannotModifySelf):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.ErrorContext(ctx.Request.Context(), msg, "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
+
+func auditActor(ctx *gin.Context) (services.AuditActor, bool) {
+	adminID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return services.AuditActor{}, false
+	}
+	return services.AuditActor{UserID: adminID, IP: ctx.ClientIP()}, true
+}
+
+func userIDParam(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
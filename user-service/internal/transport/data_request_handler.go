@@ -0,0 +1,182 @@
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	e "user-service/internal/errors"
+	"user-service/internal/services"
+	"user-service/internal/transport/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DataRequestHandler — выгрузка своих данных и удаление учётной записи.
+type DataRequestHandler struct {
+	service *services.DataRequestService
+	logger  *slog.Logger
+}
+
+func NewDataRequestHandler(service *services.DataRequestService, logger *slog.Logger) *DataRequestHandler {
+	return &DataRequestHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *DataRequestHandler) RegisterRoutes(r *gin.Engine) {
+	me := r.Group("/users/me")
+	{
+		me.POST("/data-export", h.RequestExport)
+		me.POST("/delete", h.RequestDeletion)
+		me.GET("/data-requests", h.List)
+		me.GET("/data-requests/:id", h.Get)
+		me.GET("/data-requests/:id/download", h.Download)
+	}
+}
+
+func (h *DataRequestHandler) RequestExport(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	req, err := h.service.RequestExport(ctx.Request.Context(), userID)
+	if err != nil {
+		h.respondError(ctx, "failed to request data export", err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, dto.ToDataRequestResponse(req))
+}
+
+// RequestDeletion запускает удаление учётной записи. Сессии завершаются
+// сразу, данные в сервисах удаляются асинхронно.
+func (h *DataRequestHandler) RequestDeletion(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var body dto.DeleteAccountRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	req, err := h.service.RequestDeletion(ctx.Request.Context(), userID, body.ConfirmEmail)
+	if err != nil {
+		h.respondError(ctx, "failed to request account deletion", err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, dto.ToDataRequestResponse(req))
+}
+
+func (h *DataRequestHandler) List(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	reqs, err := h.service.List(userID)
+	if err != nil {
+		h.respondError(ctx, "failed to list data requests", err)
+		return
+	}
+
+	resp := make([]dto.DataRequestResponse, 0, len(reqs))
+	for i := range reqs {
+		resp = append(resp, dto.ToDataRequestResponse(&reqs[i]))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"requests": resp})
+}
+
+func (h *DataRequestHandler) Get(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	id, ok := userIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	req, err := h.service.Get(userID, id)
+	if err != nil {
+		h.respondError(ctx, "failed to get data request", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToDataRequestResponse(req))
+}
+
+// Download отдаёт готовую выгрузку: ZIP по умолчанию, ?format=json — одним файлом.
+func (h *DataRequestHandler) Download(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	id, ok := userIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "zip")
+	if format != "zip" && format != "json" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "format: zip или json"})
+		return
+	}
+
+	req, err := h.service.Export(userID, id)
+	if err != nil {
+		h.respondError(ctx, "failed to load data export", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "application/zip"
+	if format == "json" {
+		contentType = "application/json"
+		err = services.WriteExportJSON(&buf, req)
+	} else {
+		err = services.WriteExportZip(&buf, req)
+	}
+	if err != nil {
+		h.respondError(ctx, "failed to build data export", err)
+		return
+	}
+
+	filename := fmt.Sprintf("sbor-export-%d.%s", req.ID, format)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+func (h *DataRequestHandler) respondError(ctx *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, e.ErrDataRequestNotFound),
+		errors.Is(err, e.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrDataExportNotReady),
+		errors.Is(err, e.ErrLastOrganizationOwner):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrDeletionNotConfirmed):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrDataRequestNotDeliverable):
+		h.logger.ErrorContext(ctx.Request.Context(), msg, "error", err)
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": e.ErrDataRequestNotDeliverable.Error()})
+	default:
+		h.logger.ErrorContext(ctx.Request.Context(), msg, "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"user-service/internal/models"
+)
+
+const (
+	DefaultPage  = 1
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+type AdminUserListQuery struct {
+	// Фильтры
+	Query  string `form:"q"`
+	Role   string `form:"role"`
+	Active *bool  `form:"active"`
+
+	// Пагинация
+	Page  int `form:"page"`
+	Limit int `form:"limit"`
+}
+
+// Normalize подставляет значения по умолчанию и ограничивает limit.
+func (q *AdminUserListQuery) Normalize() {
+	q.Page, q.Limit = normalizePage(q.Page, q.Limit)
+}
+
+type AuditLogListQuery struct {
+	UserID uint `form:"user_id"`
+	Page   int  `form:"page"`
+	Limit  int  `form:"limit"`
+}
+
+func (q *AuditLogListQuery) Normalize() {
+	q.Page, q.Limit = normalizePage(q.Page, q.Limit)
+}
+
+func normalizePage(page, limit int) (int, int) {
+	if page < 1 {
+		page = DefaultPage
+	}
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return page, limit
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type AdminUserResponse struct {
+	ID            uint      `json:"id"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Role          string    `json:"role"`
+	IsActive      bool      `json:"is_active"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type AdminUserListResponse struct {
+	Users []AdminUserResponse `json:"users"`
+	Total int64               `json:"total"`
+	Page  int                 `json:"page"`
+	Limit int                 `json:"limit"`
+}
+
+type AuditLogResponse struct {
+	ID           uint            `json:"id"`
+	ActorID      uint            `json:"actor_id"`
+	Action       string          `json:"action"`
+	TargetUserID uint            `json:"target_user_id"`
+	Details      json.RawMessage `json:"details"`
+	RequestID    string          `json:"request_id,omitempty"`
+	IP           string          `json:"ip,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+type AuditLogListResponse struct {
+	Entries []AuditLogResponse `json:"entries"`
+	Total   int64              `json:"total"`
+	Page    int                `json:"page"`
+	Limit   int                `json:"limit"`
+}
+
+func ToAdminUserResponse(u *models.User) AdminUserResponse {
+	return AdminUserResponse{
+		ID:            u.ID,
+		Email:         u.Email,
+		FirstName:     u.FirstName,
+		LastName:      u.LastName,
+		Role:          string(u.Role),
+		IsActive:      u.IsActive,
+		EmailVerified: u.EmailVerified(),
+		CreatedAt:     u.CreatedAt,
+	}
+}
+
+func ToAuditLogResponses(entries []models.AuditLog) []AuditLogResponse {
+	out := make([]AuditLogResponse, 0, len(entries))
+	for _, a := range entries {
+		out = append(out, AuditLogResponse{
+			ID:           a.ID,
+			ActorID:      a.ActorID,
+			Action:       string(a.Action),
+			TargetUserID: a.TargetUserID,
+			Details:      json.RawMessage(a.Details),
+			RequestID:    a.RequestID,
+			IP:           a.IP,
+			CreatedAt:    a.CreatedAt,
+		})
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"user-service/internal/models"
+)
+
+type DeleteAccountRequest struct {
+	// ConfirmEmail — адрес учётной записи, подтверждение удаления.
+	ConfirmEmail string `json:"confirm_email" binding:"required"`
+}
+
+type DataRequestPartResponse struct {
+	Service        string     `json:"service"`
+	Status         string     `json:"status"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+}
+
+type DataRequestResponse struct {
+	ID          uint                      `json:"id"`
+	Type        string                    `json:"type"`
+	Status      string                    `json:"status"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	CompletedAt *time.Time                `json:"completed_at"`
+	Services    []DataRequestPartResponse `json:"services"`
+}
+
+func ToDataRequestResponse(req *models.DataRequest) DataRequestResponse {
+	resp := DataRequestResponse{
+		ID:          req.ID,
+		Type:        string(req.Type),
+		Status:      string(req.Status),
+		CreatedAt:   req.CreatedAt,
+		CompletedAt: req.CompletedAt,
+		Services:    make([]DataRequestPartResponse, 0, len(req.Parts)),
+	}
+	for _, p := range req.Parts {
+		resp.Services = append(resp.Services, DataRequestPartResponse{
+			Service:        p.Service,
+			Status:         string(p.Status),
+			AcknowledgedAt: p.AcknowledgedAt,
+		})
+	}
+	return resp
+}
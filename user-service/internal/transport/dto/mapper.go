@@ -9,6 +9,8 @@ func ToUserResponse(u *models.User) UserResponse {
 		FirstName: u.FirstName,
 		LastName:  u.LastName,
 		Role:      string(u.Role),
+
+		EmailVerified: u.EmailVerified(),
 	}
 }
 
@@ -19,6 +21,8 @@ func ToMeResponse(u *models.User) MeResponse {
 		FirstName: u.FirstName,
 		LastName:  u.LastName,
 		Role:      string(u.Role),
+
+		EmailVerified: u.EmailVerified(),
 	}
 }
 
@@ -29,3 +33,18 @@ func ToPublicUserResponse(u *models.User) PublicUserResponse {
 		IsOrganizer: u.Role == models.RoleOrganizer,
 	}
 }
+
+func ToSessionResponses(sessions []models.Session, currentID uint) []SessionResponse {
+	out := make([]SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		out = append(out, SessionResponse{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			Current:    s.ID == currentID,
+		})
+	}
+	return out
+}
@@ -6,4 +6,6 @@ type MeResponse struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	Role      string `json:"role"`
+
+	EmailVerified bool `json:"email_verified"`
 }
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"user-service/internal/models"
+)
+
+type OrganizationRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description" binding:"max=5000"`
+}
+
+type AddOrganizationMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type ChangeOrganizationMemberRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type OrganizationResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedBy   uint      `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	// Role — роль текущего пользователя в организации.
+	Role string `json:"role,omitempty"`
+}
+
+type OrganizationMemberResponse struct {
+	UserID    uint      `json:"user_id"`
+	Email     string    `json:"email,omitempty"`
+	FirstName string    `json:"first_name,omitempty"`
+	LastName  string    `json:"last_name,omitempty"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ToOrganizationResponse(o *models.Organization, role models.OrganizationRole) OrganizationResponse {
+	return OrganizationResponse{
+		ID:          o.ID,
+		Name:        o.Name,
+		Description: o.Description,
+		CreatedBy:   o.CreatedBy,
+		CreatedAt:   o.CreatedAt,
+		Role:        string(role),
+	}
+}
+
+func ToOrganizationMemberResponse(m *models.OrganizationMember) OrganizationMemberResponse {
+	resp := OrganizationMemberResponse{
+		UserID:    m.UserID,
+		Role:      string(m.Role),
+		CreatedAt: m.CreatedAt,
+	}
+	if m.User != nil {
+		resp.Email = m.User.Email
+		resp.FirstName = m.User.FirstName
+		resp.LastName = m.User.LastName
+	}
+	return resp
+}
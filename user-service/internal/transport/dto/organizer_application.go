@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"user-service/internal/models"
+)
+
+type OrganizerApplicationRequest struct {
+	OrganizationName string `json:"organization_name" binding:"required,max=255"`
+	ContactName      string `json:"contact_name" binding:"required,max=255"`
+	ContactEmail     string `json:"contact_email" binding:"required,email,max=255"`
+	ContactPhone     string `json:"contact_phone" binding:"max=32"`
+	Website          string `json:"website" binding:"omitempty,url,max=255"`
+	Description      string `json:"description" binding:"required,max=5000"`
+}
+
+type ApplicationDecisionRequest struct {
+	Reason string `json:"reason" binding:"max=2000"`
+}
+
+type OrganizerApplicationListQuery struct {
+	Status string `form:"status"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
+
+func (q *OrganizerApplicationListQuery) Normalize() {
+	q.Page, q.Limit = normalizePage(q.Page, q.Limit)
+}
+
+type OrganizerApplicationResponse struct {
+	ID               uint       `json:"id"`
+	UserID           uint       `json:"user_id"`
+	OrganizationName string     `json:"organization_name"`
+	ContactName      string     `json:"contact_name"`
+	ContactEmail     string     `json:"contact_email"`
+	ContactPhone     string     `json:"contact_phone,omitempty"`
+	Website          string     `json:"website,omitempty"`
+	Description      string     `json:"description"`
+	Status           string     `json:"status"`
+	ReviewerID       *uint      `json:"reviewer_id,omitempty"`
+	DecisionReason   string     `json:"decision_reason,omitempty"`
+	ReviewedAt       *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type OrganizerApplicationListResponse struct {
+	Applications []OrganizerApplicationResponse `json:"applications"`
+	Total        int64                          `json:"total"`
+	Page         int                            `json:"page"`
+	Limit        int                            `json:"limit"`
+}
+
+func ToOrganizerApplicationResponse(a *models.OrganizerApplication) OrganizerApplicationResponse {
+	return OrganizerApplicationResponse{
+		ID:               a.ID,
+		UserID:           a.UserID,
+		OrganizationName: a.OrganizationName,
+		ContactName:      a.ContactName,
+		ContactEmail:     a.ContactEmail,
+		ContactPhone:     a.ContactPhone,
+		Website:          a.Website,
+		Description:      a.Description,
+		Status:           string(a.Status),
+		ReviewerID:       a.ReviewerID,
+		DecisionReason:   a.DecisionReason,
+		ReviewedAt:       a.ReviewedAt,
+		CreatedAt:        a.CreatedAt,
+	}
+}
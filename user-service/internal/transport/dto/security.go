@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"user-service/internal/models"
+)
+
+type SecurityEventListQuery struct {
+	// UserID учитывается только в админке.
+	UserID uint `form:"user_id"`
+	Page   int  `form:"page"`
+	Limit  int  `form:"limit"`
+}
+
+func (q *SecurityEventListQuery) Normalize() {
+	q.Page, q.Limit = normalizePage(q.Page, q.Limit)
+}
+
+type SecurityEventResponse struct {
+	ID        uint            `json:"id"`
+	UserID    uint            `json:"user_id"`
+	Type      string          `json:"type"`
+	IP        string          `json:"ip,omitempty"`
+	UserAgent string          `json:"user_agent,omitempty"`
+	Details   json.RawMessage `json:"details"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type SecurityEventListResponse struct {
+	Events []SecurityEventResponse `json:"events"`
+	Total  int64                   `json:"total"`
+	Page   int                     `json:"page"`
+	Limit  int                     `json:"limit"`
+}
+
+func ToSecurityEventResponses(events []models.SecurityEvent) []SecurityEventResponse {
+	out := make([]SecurityEventResponse, 0, len(events))
+	for _, ev := range events {
+		out = append(out, SecurityEventResponse{
+			ID:        ev.ID,
+			UserID:    ev.UserID,
+			Type:      string(ev.Type),
+			IP:        ev.IP,
+			UserAgent: ev.UserAgent,
+			Details:   json.RawMessage(ev.Details),
+			CreatedAt: ev.CreatedAt,
+		})
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	e "user-service/internal/errors"
+	"user-service/internal/services"
+	"user-service/userclient"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InternalHandler — API для других сервисов, gateway его не проксирует.
+type InternalHandler struct {
+	userService   services.UserService
+	orgService    *services.OrganizationService
+	dataRequests  *services.DataRequestService
+	serviceSecret string
+	logger        *slog.Logger
+}
+
+func NewInternalHandler(
+	userService services.UserService,
+	orgService *services.OrganizationService,
+	dataRequests *services.DataRequestService,
+	serviceSecret string,
+	logger *slog.Logger,
+) *InternalHandler {
+	return &InternalHandler{
+		userService:   userService,
+		orgService:    orgService,
+		dataRequests:  dataRequests,
+		serviceSecret: serviceSecret,
+		logger:        logger,
+	}
+}
+
+func (h *InternalHandler) RegisterRoutes(r *gin.Engine) {
+	internal := r.Group("/internal", userclient.RequireService(h.serviceSecret))
+	{
+		internal.POST("/users/batch", h.BatchUsers)
+		internal.GET("/organizations/:id/members/:userId", h.Membership)
+		internal.POST("/data-requests/:id/ack", h.AcknowledgeDataRequest)
+	}
+}
+
+// AcknowledgeDataRequest — сервис обработал запрос на выгрузку или удаление.
+// Сервис берётся из подписанного X-Service-Name. Секрет у сервисов общий,
+// так что это защищает от внешних вызовов, но не от сервиса, который
+// подписывается чужим именем.
+func (h *InternalHandler) AcknowledgeDataRequest(ctx *gin.Context) {
+	id, ok := userIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var req userclient.DataRequestAck
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	service := ctx.GetString(userclient.ContextService)
+	if err := h.dataRequests.Acknowledge(ctx.Request.Context(), id, service, req.Data); err != nil {
+		if errors.Is(err, e.ErrDataRequestNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "data request ack failed",
+			"request_id", id, "service", service, "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Membership — роль пользователя в организации; 404, если он в ней не состоит.
+func (h *InternalHandler) Membership(ctx *gin.Context) {
+	orgID, ok := userIDParam(ctx)
+	if !ok {
+		return
+	}
+	userID, ok := memberIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	member, err := h.orgService.Membership(orgID, userID)
+	if err != nil {
+		if errors.Is(err, e.ErrNotOrganizationMember) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "membership lookup failed", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, userclient.Membership{
+		OrganizationID: member.OrganizationID,
+		UserID:         member.UserID,
+		Role:           userclient.OrgRole(member.Role),
+	})
+}
+
+func (h *InternalHandler) BatchUsers(ctx *gin.Context) {
+	var req userclient.BatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+	if req.View == "" {
+		req.View = userclient.ViewPublic
+	}
+	if !req.View.Valid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный view"})
+		return
+	}
+	if len(req.IDs) == 0 || len(req.IDs) > userclient.MaxBatchSize {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("ids: от 1 до %d значений", userclient.MaxBatchSize)})
+		return
+	}
+
+	users, err := h.userService.GetMany(req.IDs)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "batch user lookup failed", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	resp := userclient.BatchResponse{
+		Users:   make([]userclient.User, 0, len(users)),
+		Missing: []uint{},
+	}
+	found := make(map[uint]struct{}, len(users))
+	for _, u := range users {
+		found[u.ID] = struct{}{}
+		item := userclient.User{
+			ID:        u.ID,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			IsActive:  u.IsActive,
+		}
+		if req.View == userclient.ViewContact {
+			item.Email = u.Email
+		}
+		resp.Users = append(resp.Users, item)
+	}
+	for _, id := range req.IDs {
+		if _, ok := found[id]; !ok {
+			resp.Missing = append(resp.Missing, id)
+		}
+	}
+
+	h.logger.InfoContext(ctx.Request.Context(), "batch user lookup",
+		"service", ctx.GetString(userclient.ContextService),
+		"requested", len(req.IDs),
+		"found", len(resp.Users),
+		"view", req.View)
+	ctx.JSON(http.StatusOK, resp)
+}
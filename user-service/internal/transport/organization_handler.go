@@ -0,0 +1,256 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/services"
+	"user-service/internal/transport/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	service *services.OrganizationService
+	logger  *slog.Logger
+}
+
+func NewOrganizationHandler(service *services.OrganizationService, logger *slog.Logger) *OrganizationHandler {
+	return &OrganizationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *OrganizationHandler) RegisterRoutes(r *gin.Engine) {
+	orgs := r.Group("/organizations")
+	{
+		orgs.POST("", h.Create)
+		orgs.GET("", h.ListMine)
+		orgs.GET("/:id", h.Get)
+		orgs.PATCH("/:id", h.Update)
+		orgs.GET("/:id/members", h.ListMembers)
+		orgs.POST("/:id/members", h.AddMember)
+		orgs.PATCH("/:id/members/:userId", h.ChangeMemberRole)
+		orgs.DELETE("/:id/members/:userId", h.RemoveMember)
+	}
+}
+
+func (h *OrganizationHandler) Create(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.OrganizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	org, err := h.service.Create(ctx.Request.Context(), userID, services.OrganizationInput{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.respondError(ctx, "failed to create organization", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToOrganizationResponse(org, models.OrgRoleOwner))
+}
+
+func (h *OrganizationHandler) ListMine(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	members, err := h.service.ListMine(userID)
+	if err != nil {
+		h.respondError(ctx, "failed to list organizations", err)
+		return
+	}
+
+	resp := make([]dto.OrganizationResponse, 0, len(members))
+	for _, m := range members {
+		if m.Organization != nil {
+			resp = append(resp, dto.ToOrganizationResponse(m.Organization, m.Role))
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"organizations": resp})
+}
+
+func (h *OrganizationHandler) Get(ctx *gin.Context) {
+	userID, orgID, ok := h.params(ctx)
+	if !ok {
+		return
+	}
+
+	org, role, err := h.service.Get(userID, orgID)
+	if err != nil {
+		h.respondError(ctx, "failed to get organization", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToOrganizationResponse(org, role))
+}
+
+func (h *OrganizationHandler) Update(ctx *gin.Context) {
+	userID, orgID, ok := h.params(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.OrganizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	org, err := h.service.Update(ctx.Request.Context(), userID, orgID, services.OrganizationInput{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.respondError(ctx, "failed to update organization", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToOrganizationResponse(org, models.OrgRoleOwner))
+}
+
+func (h *OrganizationHandler) ListMembers(ctx *gin.Context) {
+	userID, orgID, ok := h.params(ctx)
+	if !ok {
+		return
+	}
+
+	members, err := h.service.Members(userID, orgID)
+	if err != nil {
+		h.respondError(ctx, "failed to list organization members", err)
+		return
+	}
+
+	resp := make([]dto.OrganizationMemberResponse, 0, len(members))
+	for i := range members {
+		resp = append(resp, dto.ToOrganizationMemberResponse(&members[i]))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"members": resp})
+}
+
+func (h *OrganizationHandler) AddMember(ctx *gin.Context) {
+	userID, orgID, ok := h.params(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.AddOrganizationMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	member, err := h.service.AddMember(ctx.Request.Context(), userID, orgID, req.Email, models.OrganizationRole(req.Role))
+	if err != nil {
+		h.respondError(ctx, "failed to add organization member", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.ToOrganizationMemberResponse(member))
+}
+
+func (h *OrganizationHandler) ChangeMemberRole(ctx *gin.Context) {
+	userID, orgID, ok := h.params(ctx)
+	if !ok {
+		return
+	}
+	memberID, ok := memberIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ChangeOrganizationMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	err := h.service.ChangeMemberRole(ctx.Request.Context(), userID, orgID, memberID, models.OrganizationRole(req.Role))
+	if err != nil {
+		h.respondError(ctx, "failed to change organization member role", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *OrganizationHandler) RemoveMember(ctx *gin.Context) {
+	userID, orgID, ok := h.params(ctx)
+	if !ok {
+		return
+	}
+	memberID, ok := memberIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveMember(ctx.Request.Context(), userID, orgID, memberID); err != nil {
+		h.respondError(ctx, "failed to remove organization member", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// params — текущий пользователь и ID организации из пути.
+func (h *OrganizationHandler) params(ctx *gin.Context) (uint, uint, bool) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return 0, 0, false
+	}
+	orgID, ok := userIDParam(ctx)
+	if !ok {
+		return 0, 0, false
+	}
+	return userID, orgID, true
+}
+
+func memberIDParam(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("userId"), 10, 64)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID пользователя"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *OrganizationHandler) respondError(ctx *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, e.ErrOrganizationNotFound),
+		errors.Is(err, e.ErrUserNotFound),
+		errors.Is(err, e.ErrNotOrganizationMember):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrNotOrganizer),
+		errors.Is(err, e.ErrInsufficientOrganizationRole):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrAlreadyOrganizationMember),
+		errors.Is(err, e.ErrLastOrganizationOwner),
+		errors.Is(err, e.ErrUserInactive):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrInvalidOrganizationRole):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.ErrorContext(ctx.Request.Context(), msg, "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
@@ -0,0 +1,199 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	e "user-service/internal/errors"
+	"user-service/internal/models"
+	"user-service/internal/services"
+	"user-service/internal/transport/dto"
+	"user-service/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizerApplicationHandler struct {
+	service *services.OrganizerApplicationService
+	logger  *slog.Logger
+}
+
+func NewOrganizerApplicationHandler(service *services.OrganizerApplicationService, logger *slog.Logger) *OrganizerApplicationHandler {
+	return &OrganizerApplicationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *OrganizerApplicationHandler) RegisterRoutes(r *gin.Engine) {
+	users := r.Group("/users")
+	{
+		users.POST("/me/organizer-application", h.Submit)
+		users.GET("/me/organizer-application", h.GetMine)
+	}
+
+	admin := r.Group("/admin", middleware.RequireRole(string(models.RoleAdmin)))
+	{
+		admin.GET("/organizer-applications", h.List)
+		admin.GET("/organizer-applications/:id", h.Get)
+		admin.POST("/organizer-applications/:id/approve", h.Approve)
+		admin.POST("/organizer-applications/:id/reject", h.Reject)
+	}
+}
+
+func (h *OrganizerApplicationHandler) Submit(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.OrganizerApplicationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	app, err := h.service.Submit(ctx.Request.Context(), userID, services.ApplicationInput{
+		OrganizationName: req.OrganizationName,
+		ContactName:      req.ContactName,
+		ContactEmail:     req.ContactEmail,
+		ContactPhone:     req.ContactPhone,
+		Website:          req.Website,
+		Description:      req.Description,
+	})
+	if err != nil {
+		h.respondError(ctx, "failed to submit organizer application", err)
+		return
+	}
+
+	h.logger.InfoContext(ctx.Request.Context(), "organizer application submitted",
+		"user_id", userID, "application_id", app.ID)
+	ctx.JSON(http.StatusCreated, dto.ToOrganizerApplicationResponse(app))
+}
+
+func (h *OrganizerApplicationHandler) GetMine(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	app, err := h.service.Latest(userID)
+	if err != nil {
+		h.respondError(ctx, "failed to get organizer application", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToOrganizerApplicationResponse(app))
+}
+
+func (h *OrganizerApplicationHandler) List(ctx *gin.Context) {
+	var query dto.OrganizerApplicationListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректные параметры"})
+		return
+	}
+	query.Normalize()
+
+	status := models.ApplicationStatus(strings.TrimSpace(query.Status))
+	if status != "" && !status.Valid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный статус"})
+		return
+	}
+
+	apps, total, err := h.service.List(status, (query.Page-1)*query.Limit, query.Limit)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to list organizer applications", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	resp := dto.OrganizerApplicationListResponse{
+		Applications: make([]dto.OrganizerApplicationResponse, 0, len(apps)),
+		Total:        total,
+		Page:         query.Page,
+		Limit:        query.Limit,
+	}
+	for i := range apps {
+		resp.Applications = append(resp.Applications, dto.ToOrganizerApplicationResponse(&apps[i]))
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *OrganizerApplicationHandler) Get(ctx *gin.Context) {
+	id, ok := userIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	app, err := h.service.Get(id)
+	if err != nil {
+		h.respondError(ctx, "failed to get organizer application", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToOrganizerApplicationResponse(app))
+}
+
+func (h *OrganizerApplicationHandler) Approve(ctx *gin.Context) {
+	h.decide(ctx, h.service.Approve)
+}
+
+func (h *OrganizerApplicationHandler) Reject(ctx *gin.Context) {
+	h.decide(ctx, h.service.Reject)
+}
+
+type decisionFunc func(ctx context.Context, actor services.AuditActor, id uint, reason string) (*models.OrganizerApplication, error)
+
+func (h *OrganizerApplicationHandler) decide(ctx *gin.Context, decide decisionFunc) {
+	actor, ok := auditActor(ctx)
+	if !ok {
+		return
+	}
+	id, ok := userIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ApplicationDecisionRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+			return
+		}
+	}
+
+	app, err := decide(ctx.Request.Context(), actor, id, req.Reason)
+	if err != nil {
+		h.respondError(ctx, "failed to decide organizer application", err)
+		return
+	}
+
+	h.logger.InfoContext(ctx.Request.Context(), "organizer application reviewed",
+		"admin_id", actor.UserID, "application_id", app.ID, "status", app.Status)
+	ctx.JSON(http.StatusOK, dto.ToOrganizerApplicationResponse(app))
+}
+
+func (h *OrganizerApplicationHandler) respondError(ctx *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, e.ErrApplicationNotFound), errors.Is(err, e.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrEmailNotVerified):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "email_not_verified"})
+	case errors.Is(err, e.ErrAlreadyOrganizer),
+		errors.Is(err, e.ErrApplicationPending),
+		errors.Is(err, e.ErrApplicationAlreadyReviewed),
+		errors.Is(err, e.ErrCannotModifySelf):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrDecisionReasonRequired):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.ErrorContext(ctx.Request.Context(), msg, "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"log/slog"
+	"net/http"
+
+	"user-service/internal/models"
+	"user-service/internal/services"
+	"user-service/internal/transport/dto"
+	"user-service/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SecurityHandler struct {
+	service *services.SecurityService
+	logger  *slog.Logger
+}
+
+func NewSecurityHandler(service *services.SecurityService, logger *slog.Logger) *SecurityHandler {
+	return &SecurityHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *SecurityHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/users/me/security-events", h.ListMine)
+
+	admin := r.Group("/admin", middleware.RequireRole(string(models.RoleAdmin)))
+	{
+		admin.GET("/security-events", h.ListAll)
+	}
+}
+
+func (h *SecurityHandler) ListMine(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	h.list(ctx, func(q *dto.SecurityEventListQuery) { q.UserID = userID })
+}
+
+func (h *SecurityHandler) ListAll(ctx *gin.Context) {
+	h.list(ctx, func(*dto.SecurityEventListQuery) {})
+}
+
+func (h *SecurityHandler) list(ctx *gin.Context, scope func(q *dto.SecurityEventListQuery)) {
+	var query dto.SecurityEventListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректные параметры"})
+		return
+	}
+	query.Normalize()
+	scope(&query)
+
+	events, total, err := h.service.ListEvents(query.UserID, (query.Page-1)*query.Limit, query.Limit)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to list security events", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.SecurityEventListResponse{
+		Events: dto.ToSecurityEventResponses(events),
+		Total:  total,
+		Page:   query.Page,
+		Limit:  query.Limit,
+	})
+}
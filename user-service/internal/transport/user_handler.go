@@ -1,53 +1,85 @@
 package transport
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	e "user-service/internal/errors"
 	"user-service/internal/services"
 	"user-service/internal/transport/dto"
+	"user-service/internal/utils"
 	"user-service/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
-	userService services.UserService
-	authService *services.AuthService
-	logger      *slog.Logger
+	userService         services.UserService
+	authService         *services.AuthService
+	verificationService *services.VerificationService
+	resetService        *services.PasswordResetService
+	mfaService          *services.MFAService
+	oidcService         *services.OIDCService
+	logger              *slog.Logger
 }
 
 func NewUserHandler(
 	userService services.UserService,
 	authService *services.AuthService,
+	verificationService *services.VerificationService,
+	resetService *services.PasswordResetService,
+	mfaService *services.MFAService,
+	oidcService *services.OIDCService,
 	logger *slog.Logger,
 
 ) *UserHandler {
 	return &UserHandler{
-		userService: userService,
-		authService: authService,
-		logger:      logger,
+		userService:         userService,
+		authService:         authService,
+		verificationService: verificationService,
+		resetService:        resetService,
+		mfaService:          mfaService,
+		oidcService:         oidcService,
+		logger:              logger,
 	}
 }
 
 func (h *UserHandler) RegisterRoutes(r *gin.Engine) {
 	r.GET("/ping", h.Ping)
+	r.GET("/.well-known/jwks.json", h.JWKS)
 
 	auth := r.Group("/auth")
 	{
 		auth.POST("/register", h.Register)
 		auth.POST("/login", h.Login)
 		auth.POST("/refresh", h.Refresh)
+		auth.POST("/logout", h.Logout)
+		auth.POST("/logout-all", h.LogoutAll)
+		auth.POST("/verify-email", h.VerifyEmail)
+		auth.POST("/resend-verification", h.ResendVerification)
+		auth.POST("/forgot-password", h.ForgotPassword)
+		auth.POST("/reset-password", h.ResetPassword)
+		auth.POST("/mfa/verify", h.VerifyMFA)
+		auth.GET("/oidc/:provider/start", h.OIDCStart)
+		auth.GET("/oidc/:provider/callback", h.OIDCCallback)
 	}
 
 	users := r.Group("/users")
 	{
 		users.GET("/me", h.GetMe)
 		users.PUT("/me", h.UpdateMe)
-		users.POST("/me/become-organizer", h.BecomeOrganizer)
+		users.GET("/me/sessions", h.ListSessions)
+		users.DELETE("/me/sessions/:id", h.RevokeSession)
+		users.GET("/me/mfa", h.MFAStatus)
+		users.POST("/me/mfa/enroll", h.EnrollMFA)
+		users.POST("/me/mfa/confirm", h.ConfirmMFA)
+		users.POST("/me/mfa/disable", h.DisableMFA)
+		users.POST("/me/mfa/recovery-codes", h.RegenerateRecoveryCodes)
 		
 		users.GET("/:id",middleware.RequireRole("organizer"), h.GetPublicProfile)
 	}
@@ -57,6 +89,11 @@ func (h *UserHandler) Ping(ctx *gin.Context) {
 	ctx.Status(http.StatusOK)
 }
 
+func (h *UserHandler) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, h.authService.JWKS())
+}
+
 func (h *UserHandler) Register(ctx *gin.Context) {
 	var req dto.RegisterRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -65,17 +102,22 @@ func (h *UserHandler) Register(ctx *gin.Context) {
 	}
 
 	user, access, refresh, err :=
-		h.authService.Register(req.Email, req.Password, req.FirstName, req.LastName)
+		h.authService.Register(ctx.Request.Context(), req.Email, req.Password, req.FirstName, req.LastName, sessionMeta(ctx))
 	if err != nil {
 		if errors.Is(err, e.ErrEmailAlreadyExists) {
 			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
-		h.logger.Error("register failed", "error", err)
+		h.logger.ErrorContext(ctx.Request.Context(), "register failed", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
 		return
 	}
 
+	// письмо можно запросить повторно, регистрацию из-за него не откатываем
+	if err := h.verificationService.SendVerification(ctx.Request.Context(), user); err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to send verification email", "error", err, "user_id", user.ID)
+	}
+
 	ctx.JSON(http.StatusCreated, dto.AuthResponse{
 		User:         dto.ToUserResponse(user),
 		AccessToken:  access,
@@ -90,17 +132,146 @@ func (h *UserHandler) Login(ctx *gin.Context) {
 		return
 	}
 
-	user, access, refresh, err :=
-		h.authService.Login(req.Email, req.Password)
+	result, err := h.authService.Login(ctx.Request.Context(), req.Email, req.Password, sessionMeta(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		var blocked *services.LoginBlockedError
+		switch {
+		case errors.As(err, &blocked):
+			retryAfter := int(math.Ceil(blocked.RetryAfter.Seconds()))
+			ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+
+			status, code := http.StatusTooManyRequests, "login_throttled"
+			if errors.Is(err, e.ErrAccountLocked) {
+				status, code = http.StatusLocked, "account_locked"
+			}
+			ctx.JSON(status, gin.H{"error": err.Error(), "code": code, "retry_after": retryAfter})
+		case errors.Is(err, e.ErrInvalidCredentials), errors.Is(err, e.ErrUserInactive):
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "login failed", "error", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		}
+		return
+	}
+
+	if result.MFARequired() {
+		ctx.JSON(http.StatusOK, dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    result.MFAToken,
+		})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, dto.AuthResponse{
-		User:         dto.ToUserResponse(user),
-		AccessToken:  access,
-		RefreshToken: refresh,
+		User:         dto.ToUserResponse(result.User),
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+	})
+}
+
+// oidcStateCookie привязывает state входа через провайдера к браузеру,
+// иначе чужую ссылку callback можно подсунуть жертве (login CSRF).
+const (
+	oidcStateCookie = "oidc_state"
+	oidcStateMaxAge = 10 * 60 // секунд, столько же живёт state в OIDCService
+)
+
+func (h *UserHandler) OIDCStart(ctx *gin.Context) {
+	authURL, state, err := h.oidcService.Start(ctx.Request.Context(), ctx.Param("provider"))
+	if err != nil {
+		if errors.Is(err, e.ErrUnknownOIDCProvider) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "oidc start failed", "error", err, "provider", ctx.Param("provider"))
+		ctx.JSON(http.StatusBadGateway, gin.H{"error": "identity provider is unavailable"})
+		return
+	}
+
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(oidcStateCookie, state, oidcStateMaxAge, "/", "", true, true)
+	ctx.Redirect(http.StatusFound, authURL)
+}
+
+// OIDCCallback отвечает так же, как /auth/login: токенами
+// или challenge второго фактора.
+func (h *UserHandler) OIDCCallback(ctx *gin.Context) {
+	if providerErr := ctx.Query("error"); providerErr != "" {
+		h.logger.WarnContext(ctx.Request.Context(), "oidc provider returned error",
+			"provider", ctx.Param("provider"), "error", providerErr, "description", ctx.Query("error_description"))
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "login was cancelled or denied"})
+		return
+	}
+
+	browserState, _ := ctx.Cookie(oidcStateCookie)
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(oidcStateCookie, "", -1, "/", "", true, true)
+
+	result, err := h.oidcService.Callback(
+		ctx.Request.Context(),
+		ctx.Param("provider"),
+		ctx.Query("state"),
+		browserState,
+		ctx.Query("code"),
+		sessionMeta(ctx),
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, e.ErrUnknownOIDCProvider):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, e.ErrInvalidOIDCState), errors.Is(err, e.ErrOIDCEmailMissing):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, e.ErrOIDCEmailUnverified):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, e.ErrUserInactive):
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		default:
+			h.logger.WarnContext(ctx.Request.Context(), "oidc login failed", "error", err, "provider", ctx.Param("provider"))
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "oidc login failed"})
+		}
+		return
+	}
+
+	if result.MFARequired() {
+		ctx.JSON(http.StatusOK, dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    result.MFAToken,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.AuthResponse{
+		User:         dto.ToUserResponse(result.User),
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+	})
+}
+
+func (h *UserHandler) VerifyMFA(ctx *gin.Context) {
+	var req dto.MFAVerifyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	result, err := h.authService.VerifyMFA(req.MFAToken, req.Code, sessionMeta(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, e.ErrInvalidMFAChallenge), errors.Is(err, e.ErrInvalidMFACode):
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, e.ErrUserInactive), errors.Is(err, e.ErrUserNotFound), errors.Is(err, e.ErrMFANotEnrolled):
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		default:
+			h.logger.ErrorContext(ctx.Request.Context(), "mfa verification failed", "error", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.AuthResponse{
+		User:         dto.ToUserResponse(result.User),
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
 	})
 }
 
@@ -112,8 +283,13 @@ func (h *UserHandler) Refresh(ctx *gin.Context) {
 	}
 
 	access, refresh, err :=
-		h.authService.RefreshTokens(req.RefreshToken)
+		h.authService.RefreshTokens(ctx.Request.Context(), req.RefreshToken, sessionMeta(ctx))
 	if err != nil {
+		if errors.Is(err, e.ErrRefreshTokenReused) {
+			h.logger.WarnContext(ctx.Request.Context(), "refresh token reuse detected, session revoked")
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
 		return
 	}
@@ -124,6 +300,176 @@ func (h *UserHandler) Refresh(ctx *gin.Context) {
 	})
 }
 
+func (h *UserHandler) VerifyEmail(ctx *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	user, err := h.verificationService.VerifyEmail(ctx.Request.Context(), req.Token)
+	if err != nil {
+		if errors.Is(err, e.ErrInvalidOneTimeToken) || errors.Is(err, e.ErrUserNotFound) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": e.ErrInvalidOneTimeToken.Error()})
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "email verification failed", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	// в уже выданных access-токенах почта не подтверждена —
+	// клиент получит новый claim при следующем /auth/refresh
+	ctx.JSON(http.StatusOK, dto.ToMeResponse(user))
+}
+
+func (h *UserHandler) ResendVerification(ctx *gin.Context) {
+	var req dto.ResendVerificationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	if err := h.verificationService.ResendVerification(ctx.Request.Context(), req.Email); err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to resend verification email", "error", err)
+	}
+
+	// ответ одинаковый для любого адреса
+	ctx.Status(http.StatusAccepted)
+}
+
+func (h *UserHandler) ForgotPassword(ctx *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	if err := h.resetService.ForgotPassword(ctx.Request.Context(), req.Email); err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to send password reset email", "error", err)
+	}
+
+	// ответ одинаковый для любого адреса
+	ctx.Status(http.StatusAccepted)
+}
+
+func (h *UserHandler) ResetPassword(ctx *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	if err := h.resetService.ResetPassword(ctx.Request.Context(), req.Token, req.NewPassword); err != nil {
+		if errors.Is(err, e.ErrInvalidOneTimeToken) || errors.Is(err, e.ErrUserInactive) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": e.ErrInvalidOneTimeToken.Error()})
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "password reset failed", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) Logout(ctx *gin.Context) {
+	h.logout(ctx, h.authService.Logout)
+}
+
+func (h *UserHandler) LogoutAll(ctx *gin.Context) {
+	h.logout(ctx, h.authService.LogoutAll)
+}
+
+func (h *UserHandler) logout(ctx *gin.Context, logout func(context.Context, string) error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "missing access token"})
+		return
+	}
+
+	if err := logout(ctx.Request.Context(), token); err != nil {
+		if errors.Is(err, utils.ErrInvalidToken) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "invalid access token"})
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "logout failed", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) ListSessions(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	sessions, err := h.authService.ListSessions(userID)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to list sessions", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"sessions": dto.ToSessionResponses(sessions, h.currentSessionID(ctx))})
+}
+
+func (h *UserHandler) RevokeSession(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	sessionID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		return
+	}
+
+	if err := h.authService.RevokeSession(ctx.Request.Context(), userID, uint(sessionID)); err != nil {
+		if errors.Is(err, e.ErrSessionNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to revoke session", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func bearerToken(ctx *gin.Context) (string, bool) {
+	token, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	return token, ok && token != ""
+}
+
+// currentSessionID — сессия из access-токена, который gateway передаёт
+// вместе с запросом. 0, если токена нет.
+func (h *UserHandler) currentSessionID(ctx *gin.Context) uint {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return 0
+	}
+	claims, err := h.authService.ParseAccessToken(token)
+	if err != nil {
+		return 0
+	}
+	return claims.SessionID
+}
+
+func sessionMeta(ctx *gin.Context) services.SessionMeta {
+	return services.SessionMeta{
+		UserAgent: ctx.Request.UserAgent(),
+		IP:        ctx.ClientIP(),
+	}
+}
+
 func getUserID(ctx *gin.Context) (uint, error) {
 	userIDStr := ctx.GetHeader("X-User-Id")
 	if userIDStr == "" {
@@ -171,7 +517,7 @@ func (h *UserHandler) UpdateMe(ctx *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.UpdateProfile(userID, req.FirstName, req.LastName)
+	user, err := h.userService.UpdateProfile(ctx.Request.Context(), userID, req.FirstName, req.LastName)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
 		return
@@ -180,53 +526,156 @@ func (h *UserHandler) UpdateMe(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, dto.ToMeResponse(user))
 }
 
-func (h *UserHandler) BecomeOrganizer(ctx *gin.Context) {
-	userID, err := getUserID(ctx)
+func (h *UserHandler) GetPublicProfile(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
 		return
 	}
 
-	user, err := h.userService.BecomeOrganizer(userID)
+	user, err := h.userService.GetByID(uint(id))
 	if err != nil {
-		if errors.Is(err, e.ErrAlreadyOrganizer) {
-			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		if errors.Is(err, e.ErrUserNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
 		return
 	}
 
-	accessToken, err := h.authService.IssueAccessTokenForUser(user)
+	ctx.JSON(http.StatusOK, dto.ToPublicUserResponse(user))
+}
+
+func (h *UserHandler) MFAStatus(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	enabled, err := h.mfaService.Enabled(userID)
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "failed to get mfa status", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.MFAStatusResponse{Enabled: enabled})
+}
+
+func (h *UserHandler) EnrollMFA(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	enrollment, err := h.mfaService.Enroll(userID)
 	if err != nil {
-		h.logger.Error("token generation failed", "error", err)
+		h.mfaError(ctx, "mfa enrollment failed", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.MFAEnrollResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.URI,
+	})
+}
+
+// ConfirmMFA включает 2FA и сразу отмечает текущую сессию как
+// подтверждённую, чтобы не заставлять пользователя входить заново.
+func (h *UserHandler) ConfirmMFA(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var req dto.MFACodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	codes, err := h.mfaService.Confirm(userID, req.Code)
+	if err != nil {
+		h.mfaError(ctx, "mfa confirmation failed", err)
+		return
+	}
+
+	user, err := h.userService.GetByIDs(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	accessToken, err := h.authService.MarkSessionMFA(user, h.currentSessionID(ctx))
+	if errors.Is(err, e.ErrSessionNotFound) {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(ctx.Request.Context(), "token generation failed", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "token generation failed"})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"user":         dto.ToMeResponse(user),
-		"access_token": accessToken,
+		"recovery_codes": codes,
+		"access_token":   accessToken,
 	})
+}
 
+func (h *UserHandler) DisableMFA(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var req dto.MFACodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
+		return
+	}
+
+	if err := h.mfaService.Disable(userID, req.Code); err != nil {
+		h.mfaError(ctx, "failed to disable mfa", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
 }
 
-func (h *UserHandler) GetPublicProfile(ctx *gin.Context) {
-	id, err := strconv.Atoi(ctx.Param("id"))
+func (h *UserHandler) RegenerateRecoveryCodes(ctx *gin.Context) {
+	userID, err := getUserID(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный ID"})
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var req dto.MFACodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "некорректный JSON"})
 		return
 	}
 
-	user, err := h.userService.GetByID(uint(id))
+	codes, err := h.mfaService.RegenerateRecoveryCodes(userID, req.Code)
 	if err != nil {
-		if errors.Is(err, e.ErrUserNotFound) {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		h.mfaError(ctx, "failed to regenerate recovery codes", err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, dto.ToPublicUserResponse(user))
+	ctx.JSON(http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (h *UserHandler) mfaError(ctx *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, e.ErrInvalidMFACode):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrMFAAlreadyEnabled), errors.Is(err, e.ErrMFANotEnrolled):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, e.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.ErrorContext(ctx.Request.Context(), msg, "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
 }
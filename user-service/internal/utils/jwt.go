@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var (
@@ -21,81 +22,110 @@ const (
 )
 
 type TokenClaims struct {
-	UserID uint      `json:"user_id"`
-	Role   string    `json:"role,omitempty"`
-	Type   TokenType `json:"type"`
+	UserID    uint      `json:"user_id"`
+	Role      string    `json:"role,omitempty"`
+	Type      TokenType `json:"type"`
+	SessionID uint      `json:"sid,omitempty"`
+	// EmailVerified — почта подтверждена; gateway закрывает часть маршрутов без неё.
+	EmailVerified bool `json:"email_verified,omitempty"`
+	// MFA — сессия подтверждена вторым фактором.
+	MFA bool `json:"mfa,omitempty"`
 	jwt.RegisteredClaims
 }
 
 type TokenManager struct {
-	secretKey  string
+	keys       *KeyStore
 	accessTTL  time.Duration
 	refreshTTL time.Duration
 	issuer     string
 }
 
 func NewTokenManager(
-	secretKey string,
+	keys *KeyStore,
 	accessTTL time.Duration,
 	refreshTTL time.Duration,
 	issuer string,
 ) *TokenManager {
 	return &TokenManager{
-		secretKey:  secretKey,
+		keys:       keys,
 		accessTTL:  accessTTL,
 		refreshTTL: refreshTTL,
 		issuer:     issuer,
 	}
 }
 
+// AccessParams — что попадает в access-токен.
+type AccessParams struct {
+	UserID        uint
+	Role          string
+	SessionID     uint
+	EmailVerified bool
+	MFA           bool
+}
 
-func (tm *TokenManager) GenerateAccessToken(
-	userID uint,
-	role string,
-) (string, error) {
-	return tm.generateToken(userID, role, AccessToken, tm.accessTTL)
+func (tm *TokenManager) GenerateAccessToken(p AccessParams) (string, error) {
+	return tm.generateToken(TokenClaims{
+		UserID:        p.UserID,
+		Role:          p.Role,
+		Type:          AccessToken,
+		SessionID:     p.SessionID,
+		EmailVerified: p.EmailVerified,
+		MFA:           p.MFA,
+	}, tm.accessTTL)
 }
 
 func (tm *TokenManager) GenerateRefreshToken(
 	userID uint,
+	sessionID uint,
 ) (string, error) {
-	return tm.generateToken(userID, "", RefreshToken, tm.refreshTTL)
+	return tm.generateToken(TokenClaims{
+		UserID:    userID,
+		Type:      RefreshToken,
+		SessionID: sessionID,
+	}, tm.refreshTTL)
 }
 
-func (tm *TokenManager) generateToken(
-	userID uint,
-	role string,
-	tokenType TokenType,
-	ttl time.Duration,
-) (string, error) {
+func (tm *TokenManager) generateToken(claims TokenClaims, ttl time.Duration) (string, error) {
 
 	now := time.Now()
 
-	claims := TokenClaims{
-		UserID: userID,
-		Role:   role,
-		Type:   tokenType,
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    tm.issuer,
-			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
-		},
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Issuer:    tm.issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(tm.secretKey))
+	key := tm.keys.Active()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
 }
 
 
+func (tm *TokenManager) AccessTTL() time.Duration {
+	return tm.accessTTL
+}
+
+// RefreshTTL — дольше этого срока не живёт ни один выданный токен.
+func (tm *TokenManager) RefreshTTL() time.Duration {
+	return tm.refreshTTL
+}
+
+func (tm *TokenManager) JWKS() JWKSet {
+	return tm.keys.JWKS()
+}
+
 func (tm *TokenManager) ParseToken(tokenString string) (*TokenClaims, error) {
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&TokenClaims{},
 		func(token *jwt.Token) (any, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
 				return nil, ErrUnexpectedSign
 			}
-			return []byte(tm.secretKey), nil
+			kid, _ := token.Header["kid"].(string)
+			return tm.keys.PublicKey(kid)
 		},
 	)
 
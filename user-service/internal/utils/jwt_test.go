@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeKey(t *testing.T, dir, kid string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTokenManager_RotationKeepsOldTokensValid(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "2026-01")
+
+	oldKeys, err := LoadKeyStore(dir, "")
+	if err != nil {
+		t.Fatalf("load keys: %v", err)
+	}
+	oldToken, err := NewTokenManager(oldKeys, time.Minute, time.Hour, "test").GenerateAccessToken(AccessParams{UserID: 1, Role: "user", SessionID: 3})
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	writeKey(t, dir, "2026-02")
+	keys, err := LoadKeyStore(dir, "")
+	if err != nil {
+		t.Fatalf("reload keys: %v", err)
+	}
+	if keys.Active().ID != "2026-02" {
+		t.Fatalf("expected newest key to be active, got %s", keys.Active().ID)
+	}
+	if len(keys.JWKS().Keys) != 2 {
+		t.Fatalf("expected both keys in JWKS, got %d", len(keys.JWKS().Keys))
+	}
+
+	tm := NewTokenManager(keys, time.Minute, time.Hour, "test")
+	claims, err := tm.ParseToken(oldToken)
+	if err != nil {
+		t.Fatalf("token signed with previous key must stay valid: %v", err)
+	}
+	if claims.UserID != 1 || claims.Role != "user" || claims.SessionID != 3 {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	if _, err := LoadKeyStore(dir, "missing"); !errors.Is(err, ErrUnknownKeyID) {
+		t.Fatalf("expected ErrUnknownKeyID, got %v", err)
+	}
+}
+
+func TestTokenManager_RejectsUnknownKey(t *testing.T) {
+	a, _ := NewEphemeralKeyStore()
+	b, _ := NewEphemeralKeyStore()
+
+	token, err := NewTokenManager(a, time.Minute, time.Hour, "test").GenerateAccessToken(AccessParams{UserID: 1, Role: "user", SessionID: 3})
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	if _, err := NewTokenManager(b, time.Minute, time.Hour, "test").ParseToken(token); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNoSigningKeys = errors.New("no signing keys found")
+	ErrUnknownKeyID  = errors.New("unknown key id")
+)
+
+type SigningKey struct {
+	ID         string
+	PrivateKey *rsa.PrivateKey
+}
+
+// KeyStore хранит ключи подписи JWT. Токены подписываются активным
+// ключом, а проверяются и публикуются в JWKS все ключи из хранилища.
+//
+// Ротация без простоя:
+//  1. положить новый <kid>.pem в JWT_KEYS_DIR и выкатить — ключ
+//     появится в JWKS, но подписывать им ещё никто не будет;
+//  2. после обновления кэша JWKS в gateway переключить JWT_ACTIVE_KID;
+//  3. удалить старый ключ, когда истекут выданные им refresh-токены.
+type KeyStore struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// LoadKeyStore читает RSA-ключи из файлов <kid>.pem (PKCS#1 или PKCS#8).
+// Если activeKID пустой, активным становится последний по имени ключ.
+func LoadKeyStore(dir, activeKID string) (*KeyStore, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrNoSigningKeys, dir)
+	}
+	sort.Strings(paths)
+
+	ks := &KeyStore{keys: make(map[string]*SigningKey, len(paths))}
+	for _, path := range paths {
+		kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := parseRSAPrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", kid, err)
+		}
+		ks.keys[kid] = &SigningKey{ID: kid, PrivateKey: key}
+	}
+
+	if activeKID == "" {
+		activeKID = strings.TrimSuffix(filepath.Base(paths[len(paths)-1]), ".pem")
+	}
+	active, ok := ks.keys[activeKID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q: %w", activeKID, ErrUnknownKeyID)
+	}
+	ks.active = active
+
+	return ks, nil
+}
+
+// NewEphemeralKeyStore генерирует ключ в памяти. Только для локальной
+// разработки: после перезапуска все выданные токены станут невалидны.
+func NewEphemeralKeyStore() (*KeyStore, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	sk := &SigningKey{ID: thumbprint(&key.PublicKey), PrivateKey: key}
+	return &KeyStore{
+		active: sk,
+		keys:   map[string]*SigningKey{sk.ID: sk},
+	}, nil
+}
+
+func (ks *KeyStore) Active() *SigningKey {
+	return ks.active
+}
+
+func (ks *KeyStore) PublicKey(kid string) (*rsa.PublicKey, error) {
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+	return &key.PrivateKey.PublicKey, nil
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS — публичные части всех ключей для проверки токенов в gateway.
+func (ks *KeyStore) JWKS() JWKSet {
+	kids := make([]string, 0, len(ks.keys))
+	for kid := range ks.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	set := JWKSet{Keys: make([]JWK, 0, len(kids))}
+	for _, kid := range kids {
+		pub := ks.keys[kid].PrivateKey.PublicKey
+		set.Keys = append(set.Keys, JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: "RS256",
+			Kid: kid,
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		})
+	}
+	return set
+}
+
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid PEM")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA private key")
+	}
+	return key, nil
+}
+
+func thumbprint(pub *rsa.PublicKey) string {
+	sum := sha256.Sum256(x509.MarshalPKCS1PublicKey(pub))
+	return hex.EncodeToString(sum[:8])
+}
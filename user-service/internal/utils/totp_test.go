@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// Векторы из RFC 6238, приложение B (SHA1, 8 цифр → последние 6).
+func TestTOTPCode_RFC6238(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range cases {
+		got, err := TOTPCode(secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("t=%d: expected %s, got %s", unix, want, got)
+		}
+	}
+}
+
+func TestValidateTOTP_SkewAndReplay(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+
+	prev, _ := TOTPCode(secret, now.Add(-30*time.Second))
+	step, ok := ValidateTOTP(secret, prev, now, 0)
+	if !ok {
+		t.Fatalf("code from previous interval must be accepted")
+	}
+
+	if _, ok := ValidateTOTP(secret, prev, now, step); ok {
+		t.Fatalf("already used code must be rejected")
+	}
+
+	old, _ := TOTPCode(secret, now.Add(-5*time.Minute))
+	if _, ok := ValidateTOTP(secret, old, now, 0); ok {
+		t.Fatalf("stale code must be rejected")
+	}
+}
+
+func TestSecretBox_RoundTrip(t *testing.T) {
+	box, err := NewEphemeralSecretBox()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sealed, err := box.Seal("JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sealed == "JBSWY3DPEHPK3PXP" {
+		t.Fatalf("secret must be encrypted")
+	}
+
+	plain, err := box.Open(sealed)
+	if err != nil || plain != "JBSWY3DPEHPK3PXP" {
+		t.Fatalf("unexpected round trip: %q %v", plain, err)
+	}
+
+	other, _ := NewEphemeralSecretBox()
+	if _, err := other.Open(sealed); err == nil {
+		t.Fatalf("foreign key must not decrypt")
+	}
+}
@@ -0,0 +1,166 @@
+// Package userclient — клиент внутреннего API user-service. Им пользуются
+// другие сервисы, чтобы получить имена и контакты пользователей пачкой.
+package userclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"user-service/userclient/requestid"
+)
+
+// MaxBatchSize — сколько ID user-service принимает в одном запросе.
+// Client сам разбивает более длинные списки.
+const MaxBatchSize = 100
+
+// tokenTTL — срок действия подписи одного запроса.
+const tokenTTL = time.Minute
+
+// View — набор полей в ответе.
+type View string
+
+const (
+	// ViewPublic — только имя и фамилия.
+	ViewPublic View = "public"
+	// ViewContact — дополнительно email, для рассылок и списков участников.
+	ViewContact View = "contact"
+)
+
+func (v View) Valid() bool {
+	return v == ViewPublic || v == ViewContact
+}
+
+type User struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email,omitempty"`
+	IsActive  bool   `json:"is_active"`
+}
+
+func (u User) FullName() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+type BatchRequest struct {
+	IDs  []uint `json:"ids"`
+	View View   `json:"view"`
+}
+
+type BatchResponse struct {
+	Users []User `json:"users"`
+	// Missing — запрошенные ID, которых нет в user-service.
+	Missing []uint `json:"missing"`
+}
+
+// Client передаёт X-Request-Id из контекста вызова (см. пакет requestid).
+type Client struct {
+	baseURL string
+	service string
+	secret  []byte
+	http    *http.Client
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(c *http.Client) Option {
+	return func(cl *Client) { cl.http = c }
+}
+
+// New создаёт клиент. service — имя вызывающего сервиса, оно попадает
+// в подпись и логи user-service; secret — INTERNAL_SERVICE_SECRET.
+func New(baseURL, service, secret string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		service: service,
+		secret:  []byte(secret),
+		http:    &http.Client{Timeout: 5 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// GetUsers возвращает найденных пользователей по ID. Отсутствующие ID
+// в результат не попадают, повторяющиеся запрашиваются один раз.
+func (c *Client) GetUsers(ctx context.Context, ids []uint, view View) (map[uint]User, error) {
+	users := make(map[uint]User, len(ids))
+
+	unique := make([]uint, 0, len(ids))
+	seen := make(map[uint]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id == 0 {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+
+	for start := 0; start < len(unique); start += MaxBatchSize {
+		end := min(start+MaxBatchSize, len(unique))
+
+		resp, err := c.batch(ctx, BatchRequest{IDs: unique[start:end], View: view})
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range resp.Users {
+			users[u.ID] = u
+		}
+	}
+
+	return users, nil
+}
+
+func (c *Client) batch(ctx context.Context, body BatchRequest) (*BatchResponse, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/internal/users/batch", bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("user-service batch lookup: unexpected status %d", resp.StatusCode)
+	}
+
+	var out BatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// newRequest создаёт запрос к user-service, подписанный сервисным токеном.
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	expires := time.Now().Add(tokenTTL).Unix()
+	req.Header.Set(HeaderService, c.service)
+	req.Header.Set(HeaderExpires, strconv.FormatInt(expires, 10))
+	req.Header.Set(HeaderSignature, Sign(c.secret, c.service, expires))
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+	return req, nil
+}
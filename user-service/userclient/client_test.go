@@ -0,0 +1,137 @@
+package userclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestClient_GetUsersSplitsBatches(t *testing.T) {
+	var batches [][]uint
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/users/batch" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		err := Verify([]byte("secret"), r.Header.Get(HeaderService), r.Header.Get(HeaderExpires), r.Header.Get(HeaderSignature), time.Now())
+		if err != nil || r.Header.Get(HeaderService) != "ticket-service" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var req BatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.View != ViewContact {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		batches = append(batches, req.IDs)
+
+		var resp BatchResponse
+		for _, id := range req.IDs {
+			if id%2 == 0 {
+				resp.Users = append(resp.Users, User{ID: id, FirstName: "U"})
+			} else {
+				resp.Missing = append(resp.Missing, id)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	ids := make([]uint, 0, 2*MaxBatchSize+10)
+	for i := 1; i <= MaxBatchSize+50; i++ {
+		ids = append(ids, uint(i), uint(i))
+	}
+
+	users, err := New(srv.URL, "ticket-service", "secret").GetUsers(context.Background(), ids, ViewContact)
+	if err != nil {
+		t.Fatalf("get users: %v", err)
+	}
+
+	if len(batches) != 2 || len(batches[0]) != MaxBatchSize || len(batches[1]) != 50 {
+		t.Fatalf("unexpected batches: %d", len(batches))
+	}
+	if len(users) != (MaxBatchSize+50)/2 {
+		t.Fatalf("unexpected users: %d", len(users))
+	}
+	if _, ok := users[4]; !ok {
+		t.Fatalf("user 4 must be found")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+	exp := now.Add(time.Minute).Unix()
+	sig := Sign(secret, "notification-service", exp)
+
+	if err := Verify(secret, "notification-service", strconv.FormatInt(exp, 10), sig, now); err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if err := Verify(secret, "ticket-service", strconv.FormatInt(exp, 10), sig, now); err != ErrInvalidServiceToken {
+		t.Fatalf("token for another service accepted: %v", err)
+	}
+	if err := Verify([]byte("other"), "notification-service", strconv.FormatInt(exp, 10), sig, now); err != ErrInvalidServiceToken {
+		t.Fatalf("token with another secret accepted: %v", err)
+	}
+	if err := Verify(secret, "notification-service", strconv.FormatInt(exp, 10), sig, now.Add(2*time.Minute)); err != ErrServiceTokenExpired {
+		t.Fatalf("expired token accepted: %v", err)
+	}
+}
+
+func TestClient_GetMembership(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/internal/organizations/3/members/7":
+			_ = json.NewEncoder(w).Encode(Membership{OrganizationID: 3, UserID: 7, Role: OrgRoleCheckin})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "event-service", "secret")
+
+	m, err := c.GetMembership(context.Background(), 3, 7)
+	if err != nil {
+		t.Fatalf("get membership: %v", err)
+	}
+	if m.Role.CanManageEvents() || !m.Role.CanCheckIn() {
+		t.Fatalf("unexpected permissions for %s", m.Role)
+	}
+
+	if _, err := c.GetMembership(context.Background(), 3, 8); err != ErrNotMember {
+		t.Fatalf("expected ErrNotMember, got %v", err)
+	}
+}
+
+func TestClient_AcknowledgeDataRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/data-requests/5/ack" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var ack DataRequestAck
+		if err := json.NewDecoder(r.Body).Decode(&ack); err != nil {
+			t.Errorf("decode ack: %v", err)
+		}
+		if string(ack.Data) != `{"tickets":2}` {
+			t.Errorf("unexpected data %s", ack.Data)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "ticket-service", "secret")
+
+	if err := c.AcknowledgeDataRequest(context.Background(), 5, map[string]int{"tickets": 2}); err != nil {
+		t.Fatalf("ack: %v", err)
+	}
+	if err := c.AcknowledgeDataRequest(context.Background(), 6, nil); err != ErrDataRequestNotFound {
+		t.Fatalf("expected ErrDataRequestNotFound, got %v", err)
+	}
+}
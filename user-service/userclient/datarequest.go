@@ -0,0 +1,81 @@
+package userclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Топики запросов пользователя на выгрузку и удаление данных. Их публикует
+// user-service, каждый сервис выгружает или обезличивает свои строки и
+// подтверждает обработку через AcknowledgeDataRequest.
+const (
+	TopicExportRequested   = "user.export_requested"
+	TopicDeletionRequested = "user.deletion_requested"
+)
+
+type DataRequestType string
+
+const (
+	DataRequestExport   DataRequestType = "export"
+	DataRequestDeletion DataRequestType = "deletion"
+)
+
+// DataRequestMessage — сообщение в топиках user.export_requested и
+// user.deletion_requested. Может прийти повторно: обработка должна быть
+// идемпотентной.
+type DataRequestMessage struct {
+	RequestID   uint            `json:"request_id"`
+	UserID      uint            `json:"user_id"`
+	Type        DataRequestType `json:"type"`
+	RequestedAt time.Time       `json:"requested_at"`
+}
+
+// DataRequestAck — подтверждение сервиса. Для выгрузки Data — данные
+// пользователя в сервисе, для удаления — сводка, что было обезличено.
+type DataRequestAck struct {
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// ErrDataRequestNotFound — user-service не ждёт подтверждения от этого
+// сервиса по запросу. Повторять отправку бессмысленно.
+var ErrDataRequestNotFound = errors.New("userclient: data request not found")
+
+// AcknowledgeDataRequest сообщает user-service, что запрос обработан.
+// data сериализуется в JSON; повторное подтверждение ничего не меняет.
+func (c *Client) AcknowledgeDataRequest(ctx context.Context, requestID uint, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(DataRequestAck{Data: raw})
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/internal/data-requests/%d/ack", requestID)
+	req, err := c.newRequest(ctx, http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return ErrDataRequestNotFound
+	default:
+		return fmt.Errorf("user-service data request ack: unexpected status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,49 @@
+package userclient
+
+import "time"
+
+// Топики жизненного цикла пользователя. Их публикует user-service, ключ
+// сообщения — ID пользователя, поэтому события одного пользователя в одном
+// топике приходят по порядку. Между топиками порядок не гарантирован:
+// более старый снимок (по OccurredAt) не должен затирать новый.
+// По ним сервисы ведут локальные копии пользователей.
+const (
+	TopicUserRegistered  = "user.registered"
+	TopicUserUpdated     = "user.updated"
+	TopicUserRoleChanged = "user.role_changed"
+	TopicUserDeactivated = "user.deactivated"
+)
+
+// UserEventVersion — текущая версия UserEvent. Новые поля добавляются без
+// смены версии, версия растёт только при несовместимых изменениях;
+// сообщения неизвестной версии консьюмер должен пропускать.
+const UserEventVersion = 1
+
+// Причины деактивации в UserEvent.Reason.
+const (
+	DeactivatedByAdmin    = "admin"
+	DeactivatedByDeletion = "account_deleted"
+)
+
+// UserEvent — сообщение в топиках user.*: снимок пользователя после
+// изменения. При деактивации с причиной account_deleted локальную копию
+// пользователя нужно удалить.
+type UserEvent struct {
+	Version       int       `json:"version"`
+	Type          string    `json:"type"`
+	UserID        uint      `json:"user_id"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Role          string    `json:"role"`
+	PreviousRole  string    `json:"previous_role,omitempty"`
+	IsActive      bool      `json:"is_active"`
+	EmailVerified bool      `json:"email_verified"`
+	Reason        string    `json:"reason,omitempty"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// Supported сообщает, может ли консьюмер разобрать сообщение.
+func (e UserEvent) Supported() bool {
+	return e.Version == UserEventVersion
+}
@@ -0,0 +1,95 @@
+package userclient
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Заголовки личности пользователя, которые gateway подписывает
+// секретом INTERNAL_IDENTITY_SECRET после проверки access-токена.
+const (
+	HeaderUserID            = "X-User-Id"
+	HeaderUserRole          = "X-User-Role"
+	HeaderIdentityExpires   = "X-Identity-Expires"
+	HeaderIdentitySignature = "X-Identity-Signature"
+)
+
+// RequireIdentity пропускает X-User-Id/X-User-Role только с валидной
+// и не просроченной подписью gateway. Запросы без личности проходят
+// как анонимные — доступ к закрытым ручкам решают сами хендлеры.
+func RequireIdentity(secret string) gin.HandlerFunc {
+	key := []byte(secret)
+
+	return func(c *gin.Context) {
+		userID := c.GetHeader(HeaderUserID)
+		role := c.GetHeader(HeaderUserRole)
+		if userID == "" && role == "" {
+			c.Next()
+			return
+		}
+
+		expires := c.GetHeader(HeaderIdentityExpires)
+		signature := c.GetHeader(HeaderIdentitySignature)
+		if !validIdentity(key, userID, role, expires, signature) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "invalid identity signature",
+			})
+			return
+		}
+
+		exp, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil || time.Now().Unix() > exp {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "identity expired",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ContextService — ключ gin-контекста с именем вызвавшего сервиса.
+const ContextService = "service"
+
+// RequireService пропускает только запросы других сервисов, подписанные
+// общим секретом INTERNAL_SERVICE_SECRET (см. Sign).
+func RequireService(secret string) gin.HandlerFunc {
+	key := []byte(secret)
+
+	return func(c *gin.Context) {
+		service := c.GetHeader(HeaderService)
+		err := Verify(
+			key,
+			service,
+			c.GetHeader(HeaderExpires),
+			c.GetHeader(HeaderSignature),
+			time.Now(),
+		)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.Set(ContextService, service)
+		c.Next()
+	}
+}
+
+func validIdentity(key []byte, userID, role, expires, signature string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("v1\n" + userID + "\n" + role + "\n" + expires))
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,107 @@
+package userclient
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func signIdentity(secret, userID, role, expires string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v1\n" + userID + "\n" + role + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestRequireIdentity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequireIdentity("secret"))
+	r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	valid := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+	expired := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+
+	cases := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"anonymous", nil, http.StatusOK},
+		{"signed", map[string]string{
+			HeaderUserID: "5", HeaderUserRole: "user", HeaderIdentityExpires: valid,
+			HeaderIdentitySignature: signIdentity("secret", "5", "user", valid),
+		}, http.StatusOK},
+		{"unsigned", map[string]string{HeaderUserID: "5", HeaderUserRole: "user"}, http.StatusUnauthorized},
+		{"role escalated", map[string]string{
+			HeaderUserID: "5", HeaderUserRole: "organizer", HeaderIdentityExpires: valid,
+			HeaderIdentitySignature: signIdentity("secret", "5", "user", valid),
+		}, http.StatusUnauthorized},
+		{"wrong secret", map[string]string{
+			HeaderUserID: "5", HeaderUserRole: "user", HeaderIdentityExpires: valid,
+			HeaderIdentitySignature: signIdentity("other", "5", "user", valid),
+		}, http.StatusUnauthorized},
+		{"expired", map[string]string{
+			HeaderUserID: "5", HeaderUserRole: "user", HeaderIdentityExpires: expired,
+			HeaderIdentitySignature: signIdentity("secret", "5", "user", expired),
+		}, http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tc.want {
+				t.Fatalf("expected %d, got %d", tc.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestRequireService(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequireService("secret"))
+	r.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, c.GetString(ContextService)) })
+
+	valid := time.Now().Add(time.Minute).Unix()
+	expired := time.Now().Add(-time.Minute).Unix()
+
+	cases := []struct {
+		name    string
+		secret  string
+		expires int64
+		want    int
+	}{
+		{"signed", "secret", valid, http.StatusOK},
+		{"wrong secret", "other", valid, http.StatusUnauthorized},
+		{"expired", "secret", expired, http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			req.Header.Set(HeaderService, "ticket-service")
+			req.Header.Set(HeaderExpires, strconv.FormatInt(tc.expires, 10))
+			req.Header.Set(HeaderSignature, Sign([]byte(tc.secret), "ticket-service", tc.expires))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tc.want {
+				t.Fatalf("expected %d, got %d", tc.want, w.Code)
+			}
+			if tc.want == http.StatusOK && w.Body.String() != "ticket-service" {
+				t.Fatalf("expected caller in context, got %q", w.Body.String())
+			}
+		})
+	}
+}
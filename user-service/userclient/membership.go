@@ -0,0 +1,74 @@
+package userclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// OrgRole — роль участника организации.
+type OrgRole string
+
+const (
+	OrgRoleOwner   OrgRole = "owner"
+	OrgRoleManager OrgRole = "manager"
+	// OrgRoleCheckin — сотрудник на входе: только проверка билетов.
+	OrgRoleCheckin OrgRole = "checkin"
+)
+
+func (r OrgRole) Valid() bool {
+	switch r {
+	case OrgRoleOwner, OrgRoleManager, OrgRoleCheckin:
+		return true
+	}
+	return false
+}
+
+// CanManageEvents — создание, изменение, публикация и отмена мероприятий организации.
+func (r OrgRole) CanManageEvents() bool {
+	return r == OrgRoleOwner || r == OrgRoleManager
+}
+
+// CanCheckIn — проверка и погашение билетов на мероприятия организации.
+func (r OrgRole) CanCheckIn() bool {
+	return r.Valid()
+}
+
+type Membership struct {
+	OrganizationID uint    `json:"organization_id"`
+	UserID         uint    `json:"user_id"`
+	Role           OrgRole `json:"role"`
+}
+
+// ErrNotMember — пользователь не состоит в организации или её нет.
+var ErrNotMember = errors.New("not an organization member")
+
+// GetMembership возвращает роль пользователя в организации.
+func (c *Client) GetMembership(ctx context.Context, organizationID, userID uint) (*Membership, error) {
+	path := fmt.Sprintf("/internal/organizations/%d/members/%d", organizationID, userID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotMember
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("user-service membership lookup: unexpected status %d", resp.StatusCode)
+	}
+
+	var out Membership
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,56 @@
+package userclient
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Заголовки сервисного токена: имя вызывающего сервиса, срок действия
+// и HMAC-SHA256 подпись общим секретом INTERNAL_SERVICE_SECRET.
+const (
+	HeaderService   = "X-Service-Name"
+	HeaderExpires   = "X-Service-Expires"
+	HeaderSignature = "X-Service-Signature"
+)
+
+var (
+	ErrInvalidServiceToken = errors.New("invalid service token")
+	ErrServiceTokenExpired = errors.New("service token expired")
+)
+
+// Sign подписывает имя сервиса и срок действия токена (unix-секунды).
+func Sign(secret []byte, service string, expires int64) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("svc1\n" + service + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify проверяет значения заголовков сервисного токена.
+func Verify(secret []byte, service, expires, signature string, now time.Time) error {
+	if service == "" || expires == "" || signature == "" {
+		return ErrInvalidServiceToken
+	}
+
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidServiceToken
+	}
+
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidServiceToken
+	}
+	want, _ := hex.DecodeString(Sign(secret, service, exp))
+	if !hmac.Equal(got, want) {
+		return ErrInvalidServiceToken
+	}
+
+	if now.Unix() > exp {
+		return ErrServiceTokenExpired
+	}
+	return nil
+}